@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"os"
 	"os/signal"
 	"syscall"
@@ -21,6 +22,13 @@ func main() {
 	if err != nil {
 		logger.Fatal("Failed to load configuration:", err)
 	}
+	logger.RegisterSecret(cfg.Discord.Token)
+	if cfg.Log.File != "" {
+		if err := logger.SetFile(cfg.Log.File, cfg.Log.MaxSizeMB, cfg.Log.MaxFiles, cfg.Log.FileOnly); err != nil {
+			logger.Fatal("Failed to open log file:", err)
+		}
+		defer logger.Close()
+	}
 	logger.Info("Configuration loaded successfully")
 	logger.Info("Discord Guild ID:", cfg.Discord.GuildID)
 	logger.Info("Monitor interval:", cfg.Monitor.Interval)
@@ -37,6 +45,9 @@ func main() {
 
 	logger.Info("Starting bot...")
 	if err := systemBot.Start(); err != nil {
+		if errors.Is(err, bot.ErrInvalidToken) {
+			logger.Fatal(err, " - the token may be mistyped, revoked or regenerated; copy a fresh one from the Bot page of the Discord Developer Portal")
+		}
 		logger.Fatal("Failed to start bot:", err)
 	}
 	defer func() {
@@ -3,9 +3,16 @@
 package bot
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
 	"system-monitor-bot/internal/config"
 	"system-monitor-bot/internal/embed"
+	"system-monitor-bot/internal/httpclient"
+	"system-monitor-bot/internal/metrics"
 	"system-monitor-bot/internal/monitor"
 	"system-monitor-bot/pkg/logger"
 	"time"
@@ -13,16 +20,99 @@ import (
 	"github.com/bwmarrin/discordgo"
 )
 
+// maxAlertWorkers bounds concurrent alert sends across channels
+const maxAlertWorkers = 5
+
 type SystemMonitor struct {
-	discord        *discordgo.Session
-	config         *config.Config
-	tempMonitor    *monitor.TemperatureMonitor
-	netMonitor     *monitor.NetworkMonitor
-	memMonitor     *monitor.MemoryMonitor
-	embedBuilder   *embed.Builder
-	alertChannels  map[string]bool
-	lastAlert      time.Time
+	discord       discordSession
+	config        *config.Config
+	tempMonitor   monitor.SensorReader
+	netMonitor    monitor.PortReader
+	memMonitor    monitor.ProcessReader
+	fdMonitor     monitor.FileDescriptorReader
+	sysInfo       monitor.SystemInfoReader
+	powerMonitor  monitor.PowerReader
+	diskMonitor   monitor.DiskReader
+	cpuMonitor    monitor.CPUReader
+	psiMonitor    monitor.PressureReader
+	embedBuilder  *embed.Builder
+	alertMu       sync.RWMutex // guards the four alert channel maps
+	alertChannels map[string]ChannelAlertConfig
+	alertWebhooks map[string]alertWebhook
+	alertGuilds   map[string]string // alert channel ID -> guild ID, for message links
+	alertThreads  *alertThreads
+	commandGuilds *commandGuilds
+	snoozedUntil  map[string]time.Time
+	lastRefresh   *refreshTimes
+	state         *discordgo.State // nil when driven without a gateway
+	botUserMu     sync.RWMutex     // onReady sets botUserID on every reconnect
+	botUserID     string
+
+	// snapshotMu guards the latest readings, which the monitor goroutines
+	// replace each cycle while command handlers read them
+	snapshotMu     sync.RWMutex
 	lastMemoryData []monitor.ProcessMemory
+	lastSensorData []monitor.TemperatureSensor
+	lastPortData   []monitor.NetworkPort // nil until the first network cycle
+
+	// Per-sensor readings from the previous cycle, for trend arrows
+	trends *sensorTrends
+
+	// alertStateMu guards lastAlert, lastPresence, lastAlertFingerprint and
+	// the pending alert, which command handlers read while the monitor
+	// goroutine updates them
+	alertStateMu sync.Mutex
+	lastAlert    time.Time
+	lastPresence string
+
+	// Fingerprint of the last alert condition that was sent
+	lastAlertFingerprint string
+
+	// normalCycles counts consecutive cycles without an alert condition;
+	// the condition clears once it reaches RECOVERY_CYCLES
+	normalCycles int
+
+	lastFDAlert       time.Time
+	lastPressureAlert time.Time
+	powerAlertActive  bool
+
+	// Level of an active alert condition held back by the cooldown, and
+	// when it was first held back; empty when nothing is pending
+	pendingAlertLevel string
+	pendingAlertSince time.Time
+
+	// Recent usage samples per mountpoint, for disk fill projection; the
+	// disk monitor appends while /reset-history clears, so access is
+	// guarded by diskHistoryMu
+	diskHistory   map[string]*diskHistory
+	diskHistoryMu sync.Mutex
+
+	// Watched ports found closed and already alerted on
+	closedPorts map[monitor.WatchedPort]bool
+
+	// Sensors seen in previous cycles, for missing sensor detection
+	sensorPresence *sensorPresence
+
+	// Sensors excluded from alerting with /alerts silence
+	silences *sensorSilences
+
+	// Destinations outside Discord that also receive alerts
+	sinks []alertSink
+
+	// Client for fetching peer snapshots; nil without PEERS
+	fleetClient *http.Client
+
+	// Command invocations per user, when per-user stats are enabled
+	commandUsers metrics.LabeledCounter
+
+	// Ongoing incident when alerts are delivered in evolving mode
+	incident *incident
+
+	// done is closed by Stop to end the monitoring goroutines, which
+	// monitors tracks so Stop can wait for them
+	done     chan struct{}
+	stopOnce sync.Once
+	monitors sync.WaitGroup
 }
 
 func New(cfg *config.Config) (*SystemMonitor, error) {
@@ -36,30 +126,165 @@ func New(cfg *config.Config) (*SystemMonitor, error) {
 	}
 	logger.Info("Discord session created successfully")
 
+	logger.Info("Configuring Discord HTTP client...")
+	httpClient, err := httpclient.New(cfg.Discord.ProxyURL, cfg.Discord.HTTPTimeout)
+	if err != nil {
+		logger.Error("Failed to create HTTP client:", err)
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+	session.Client = httpClient
+
+	// Copy the dialer so the shared default dialer is not modified
+	dialer := *session.Dialer
+	dialer.Proxy = httpClient.Transport.(*http.Transport).Proxy
+	dialer.HandshakeTimeout = cfg.Discord.HTTPTimeout
+	session.Dialer = &dialer
+
+	logger.Info("Setting Discord intents to Guilds")
+	session.Identify.Intents = discordgo.IntentsGuilds
+
+	if cfg.Remote.Enabled() {
+		logger.Info("Configuring remote host for external commands...")
+		err := monitor.SetRemoteHost(monitor.RemoteHost{
+			Addr:           cfg.Remote.Host,
+			User:           cfg.Remote.User,
+			KeyFile:        cfg.Remote.KeyFile,
+			KnownHostsFile: cfg.Remote.KnownHostsFile,
+		})
+		if err != nil {
+			logger.Error("Failed to configure remote host:", err)
+			return nil, err
+		}
+	}
+
+	if len(cfg.Monitor.SudoCommands) > 0 {
+		logger.Info("Configuring sudo for external commands...")
+		if err := monitor.SetSudoCommands(cfg.Monitor.SudoCommands); err != nil {
+			logger.Error("Failed to configure sudo commands:", err)
+			return nil, err
+		}
+	}
+
 	logger.Info("Initializing temperature monitor...")
-	tempMonitor := monitor.NewTemperatureMonitor(cfg.Thresholds.Critical, cfg.Thresholds.Warning)
+	if err := monitor.SetSeverityLevels(cfg.Thresholds.Levels); err != nil {
+		logger.Error("Invalid severity levels:", err)
+		return nil, err
+	}
+	monitor.SetSensorThresholds(cfg.Thresholds.Sensors)
+	tempMonitor := monitor.NewTemperatureMonitor(cfg.Thresholds.Hysteresis, cfg.Monitor.TempBackend)
 
 	logger.Info("Initializing network monitor...")
-	netMonitor := monitor.NewNetworkMonitor()
+	netMonitor := monitor.NewNetworkMonitor(cfg.Display.ExcludeSelf)
 
 	logger.Info("Initializing memory monitor...")
-	memMonitor := monitor.NewMemoryMonitor()
+	memMonitor := monitor.NewMemoryMonitor(cfg.Display.ExcludeSelf, cfg.Monitor.ProcScanWorkers)
+
+	logger.Info("Loading port service names...")
+	monitor.LoadServices()
+
+	if cfg.Display.ProcessAliasesFile != "" {
+		logger.Info("Loading process aliases...")
+		if err := monitor.LoadProcessAliases(cfg.Display.ProcessAliasesFile); err != nil {
+			logger.Error("Failed to load process aliases:", err)
+			return nil, err
+		}
+	}
 
+	sm := newSystemMonitor(cfg, session, tempMonitor, netMonitor, memMonitor)
+	sm.state = session.State
+
+	logger.Info("Loading silenced sensors...")
+	sm.silences, err = loadSensorSilences(cfg.Monitor.SilencedSensorsFile)
+	if err != nil {
+		logger.Error("Failed to load silenced sensors:", err)
+		return nil, err
+	}
+
+	if cfg.Matrix.Enabled() {
+		logger.Info("Initializing Matrix alert sink...")
+		matrix, err := newMatrixSink(cfg.Matrix, cfg.Discord.ProxyURL, cfg.Discord.HTTPTimeout)
+		if err != nil {
+			logger.Error("Failed to create Matrix sink:", err)
+			return nil, err
+		}
+		sm.sinks = append(sm.sinks, matrix)
+	}
+
+	if cfg.Monitor.AlertOutput != "" {
+		logger.Info("Initializing JSON alert sink...")
+		if cfg.Monitor.AlertOutput == config.AlertOutputStdout && !cfg.Log.FileOnly {
+			logger.Warn("JSON alert records share stdout with console logs - set LOG_FILE and LOG_FILE_ONLY to keep stdout for records only")
+		}
+		sm.sinks = append(sm.sinks, newJSONSink(cfg.Monitor.AlertOutput))
+	}
+
+	if len(cfg.Fleet.Peers) > 0 {
+		logger.Info("Initializing fleet HTTP client for", len(cfg.Fleet.Peers), "peers...")
+		sm.fleetClient, err = httpclient.New(cfg.Discord.ProxyURL, fleetTimeout)
+		if err != nil {
+			logger.Error("Failed to create fleet HTTP client:", err)
+			return nil, fmt.Errorf("failed to create fleet HTTP client: %w", err)
+		}
+	}
+	logger.RegisterSecret(cfg.Fleet.Token)
+
+	logger.Info("Initializing file descriptor monitor...")
+	sm.fdMonitor = monitor.NewFileDescriptorMonitor(cfg.Monitor.ProcScanWorkers)
+
+	logger.Info("Initializing system info monitor...")
+	sm.sysInfo = monitor.NewSystemInfoMonitor()
+
+	logger.Info("Initializing power monitor...")
+	sm.powerMonitor = monitor.NewPowerMonitor()
+
+	logger.Info("Initializing disk monitor...")
+	sm.diskMonitor = monitor.NewDiskMonitor()
+
+	logger.Info("Initializing CPU monitor...")
+	sm.cpuMonitor = monitor.NewCPUMonitor(cfg.Monitor.SampleWindow)
+
+	logger.Info("Initializing pressure monitor...")
+	sm.psiMonitor = monitor.NewPressureMonitor()
+
+	logger.Info("SystemMonitor instance created successfully")
+	return sm, nil
+}
+
+// newSystemMonitor wires a SystemMonitor from its dependencies so that the
+// monitors can be swapped for fakes
+func newSystemMonitor(cfg *config.Config, session discordSession, sensors monitor.SensorReader, ports monitor.PortReader, processes monitor.ProcessReader) *SystemMonitor {
 	logger.Info("Initializing embed builder...")
-	embedBuilder := embed.NewBuilder(cfg.Thresholds.Critical, cfg.Thresholds.Warning)
+	embedBuilder := embed.NewBuilder(cfg.Thresholds.Critical, cfg.Thresholds.Warning, cfg.Display)
 
-	sm := &SystemMonitor{
-		discord:       session,
+	return &SystemMonitor{
+		discord:       countErrors(session),
 		config:        cfg,
-		tempMonitor:   tempMonitor,
-		netMonitor:    netMonitor,
-		memMonitor:    memMonitor,
+		tempMonitor:   sensors,
+		netMonitor:    ports,
+		memMonitor:    processes,
 		embedBuilder:  embedBuilder,
-		alertChannels: make(map[string]bool),
+		alertChannels: make(map[string]ChannelAlertConfig),
+		alertWebhooks: make(map[string]alertWebhook),
+		alertGuilds:   make(map[string]string),
+		alertThreads:  newAlertThreads(),
+		commandGuilds: newCommandGuilds(),
+		snoozedUntil:  make(map[string]time.Time),
+		lastRefresh:   newRefreshTimes(),
+		diskHistory:   make(map[string]*diskHistory),
+		closedPorts:   make(map[monitor.WatchedPort]bool),
+		silences:      &sensorSilences{until: make(map[string]time.Time)},
+		trends:        newSensorTrends(),
+		done:          make(chan struct{}),
 	}
+}
 
-	logger.Info("SystemMonitor instance created successfully")
-	return sm, nil
+// goMonitor runs a monitoring loop in the background, tracked for Stop
+func (sm *SystemMonitor) goMonitor(loop func()) {
+	sm.monitors.Add(1)
+	go func() {
+		defer sm.monitors.Done()
+		loop()
+	}()
 }
 
 func (sm *SystemMonitor) Start() error {
@@ -69,31 +294,86 @@ func (sm *SystemMonitor) Start() error {
 	logger.Info("Adding Discord event handlers...")
 	sm.discord.AddHandler(sm.onReady)
 	sm.discord.AddHandler(sm.onInteraction)
-
-	logger.Info("Setting Discord intents to Guilds")
-	sm.discord.Identify.Intents = discordgo.IntentsGuilds
+	sm.discord.AddHandler(sm.onGuildCreate)
+	sm.discord.AddHandler(sm.onGuildDelete)
 
 	// Start Discord connection
 	logger.Info("Opening Discord connection...")
 	if err := sm.discord.Open(); err != nil {
 		logger.Error("Failed to open Discord connection:", err)
+		if isInvalidTokenError(err) {
+			return ErrInvalidToken
+		}
 		return fmt.Errorf("failed to open Discord connection: %w", err)
 	}
 	logger.Info("Discord connection opened successfully")
 
+	if sm.config.Monitor.DefaultAlertChannel != "" {
+		sm.enableDefaultAlertChannel(sm.config.Monitor.DefaultAlertChannel)
+	}
+
 	// Start background monitoring
 	logger.Info("Starting background temperature monitoring goroutine...")
-	go sm.startTemperatureMonitoring()
+	sm.goMonitor(sm.startTemperatureMonitoring)
 
 	logger.Info("Starting background memory monitoring goroutine...")
-	go sm.startMemoryMonitoring()
+	sm.goMonitor(sm.startMemoryMonitoring)
+
+	if sm.config.Monitor.AlertOnBattery || sm.config.Thresholds.LowBatteryPercent > 0 {
+		logger.Info("Starting background power monitoring goroutine...")
+		sm.goMonitor(sm.startPowerMonitoring)
+	} else {
+		logger.Info("Power alerts disabled - skipping power monitoring goroutine")
+	}
+
+	if sm.config.Thresholds.DiskFillWindow > 0 {
+		logger.Info("Starting background disk monitoring goroutine...")
+		sm.goMonitor(sm.startDiskMonitoring)
+	} else {
+		logger.Info("Disk fill alerts disabled - skipping disk monitoring goroutine")
+	}
+
+	logger.Info("Starting background network monitoring goroutine...")
+	sm.goMonitor(sm.startNetworkMonitoring)
+
+	if sm.config.Monitor.MetricsAddr != "" {
+		routes := make(map[string]http.Handler)
+		if sm.config.Fleet.ServeSnapshot {
+			routes[snapshotPath] = sm.snapshotHandler()
+		}
+		metrics.Serve(sm.config.Monitor.MetricsAddr, routes)
+	}
 
 	logger.Info("SystemMonitor started successfully")
 	return nil
 }
 
+// enableDefaultAlertChannel enables alerts in channelID as /alerts enable
+// would, before any monitoring runs. The channel is looked up only for its
+// guild, which recovery messages link to; alerts are enabled regardless,
+// and a channel that cannot be posted to is dropped by the first alert.
+func (sm *SystemMonitor) enableDefaultAlertChannel(channelID string) {
+	guildID := ""
+	channel, err := sm.discord.Channel(channelID)
+	if err != nil {
+		logger.Warn("Could not look up default alert channel", channelID, "error:", err)
+	} else {
+		guildID = channel.GuildID
+	}
+	sm.enableAlertChannel(channelID, guildID, ChannelAlertConfig{})
+	logger.Info("Alerts auto-enabled for default alert channel", channelID, "from DEFAULT_ALERT_CHANNEL")
+}
+
+// Stop ends the monitoring goroutines, waiting for any cycle in progress,
+// then closes the Discord connection. It is safe to call more than once.
 func (sm *SystemMonitor) Stop() {
 	logger.Info("Stopping SystemMonitor...")
+	sm.stopOnce.Do(func() {
+		logger.Info("Stopping monitoring goroutines...")
+		close(sm.done)
+		sm.monitors.Wait()
+		logger.Info("Monitoring goroutines stopped")
+	})
 	if sm.discord != nil {
 		logger.Info("Closing Discord connection...")
 		err := sm.discord.Close()
@@ -108,8 +388,12 @@ func (sm *SystemMonitor) Stop() {
 
 func (sm *SystemMonitor) startMemoryMonitoring() {
 	logger.Info("Memory monitoring goroutine started")
-	logger.Info("Creating memory ticker with 5 second interval")
 
+	// Seed data immediately instead of waiting for the first tick
+	logger.Info("Running initial memory collection...")
+	runCycle("memory", sm.collectMemory)
+
+	logger.Info("Creating memory ticker with 5 second interval")
 	ticker := time.NewTicker(5 * time.Second)
 	defer func() {
 		logger.Info("Stopping memory monitoring ticker")
@@ -118,54 +402,98 @@ func (sm *SystemMonitor) startMemoryMonitoring() {
 
 	logger.Info("Memory monitoring started with 5-second intervals")
 
-	// Use range over ticker channel - much cleaner!
-	for range ticker.C {
-		logger.Info("Memory monitoring cycle started (5s interval)")
-
-		processes, err := sm.memMonitor.GetTopProcesses()
-		if err != nil {
-			logger.Error("Memory monitoring failed:", err)
-			continue
+	for {
+		select {
+		case <-sm.done:
+			return
+		case <-ticker.C:
+			logger.Info("Memory monitoring cycle started (5s interval)")
+			runCycle("memory", sm.collectMemory)
 		}
+	}
+}
 
-		if len(processes) == 0 {
-			logger.Warn("No processes found in this memory monitoring cycle")
-			continue
-		}
+// memorySnapshot returns a copy of the processes from the latest memory
+// cycle, or nil before the first one
+func (sm *SystemMonitor) memorySnapshot() []monitor.ProcessMemory {
+	sm.snapshotMu.RLock()
+	defer sm.snapshotMu.RUnlock()
+	return append([]monitor.ProcessMemory(nil), sm.lastMemoryData...)
+}
 
-		logger.Info("Processing", len(processes), "memory processes (sorted by %MEM)")
+// sensorSnapshot returns a copy of the sensors from the latest temperature
+// cycle, or nil before the first one
+func (sm *SystemMonitor) sensorSnapshot() []monitor.TemperatureSensor {
+	sm.snapshotMu.RLock()
+	defer sm.snapshotMu.RUnlock()
+	return append([]monitor.TemperatureSensor(nil), sm.lastSensorData...)
+}
 
-		// Store the latest memory data for status commands
-		sm.lastMemoryData = processes
+// portSnapshot returns a copy of the listening ports from the latest
+// network cycle; ok is false before the first one
+func (sm *SystemMonitor) portSnapshot() (ports []monitor.NetworkPort, ok bool) {
+	sm.snapshotMu.RLock()
+	defer sm.snapshotMu.RUnlock()
+	if sm.lastPortData == nil {
+		return nil, false
+	}
+	return append([]monitor.NetworkPort(nil), sm.lastPortData...), true
+}
 
-		// Log top process for monitoring
-		if len(processes) > 0 {
-			topProcess := processes[0]
-			logger.Info("Top memory process: PID", topProcess.PID, topProcess.Command, "using", topProcess.MemoryPercent, "% memory")
+// collectMemory runs a single memory monitoring cycle
+func (sm *SystemMonitor) collectMemory() {
+	processes, err := sm.memMonitor.GetTopProcesses(sm.config.Display.Limits.MemoryTopN)
+	if err != nil {
+		logger.Error("Memory monitoring failed:", err)
+		return
+	}
 
-			// Log high memory usage warnings
-			if topProcess.MemoryPercent > 20.0 {
-				logger.Warn("Very high memory usage detected:", topProcess.Command, "using", topProcess.MemoryPercent, "% memory")
-			} else if topProcess.MemoryPercent > 10.0 {
-				logger.Warn("High memory usage detected:", topProcess.Command, "using", topProcess.MemoryPercent, "% memory")
-			}
+	if len(processes) == 0 {
+		logger.Warn("No processes found in this memory monitoring cycle")
+		return
+	}
+
+	logger.Info("Processing", len(processes), "memory processes (sorted by %MEM)")
+
+	// Store the latest memory data for status commands
+	sm.snapshotMu.Lock()
+	sm.lastMemoryData = append([]monitor.ProcessMemory(nil), processes...)
+	sm.snapshotMu.Unlock()
+
+	// Log top process for monitoring
+	if len(processes) > 0 {
+		topProcess := processes[0]
+		logger.Info("Top memory process: PID", topProcess.PID, topProcess.Command, "using", topProcess.MemoryPercent, "% memory")
+
+		// Log high memory usage warnings
+		if topProcess.MemoryPercent > 20.0 {
+			logger.Warn("Very high memory usage detected:", topProcess.Command, "using", topProcess.MemoryPercent, "% memory")
+		} else if topProcess.MemoryPercent > 10.0 {
+			logger.Warn("High memory usage detected:", topProcess.Command, "using", topProcess.MemoryPercent, "% memory")
 		}
+	}
 
-		// Log summary of top 5 for quick monitoring
-		if len(processes) >= 5 {
-			logger.Info("Top 5 memory processes summary:")
-			for i := 0; i < 5; i++ {
-				p := processes[i]
-				logger.Info(fmt.Sprintf("  #%d: %s (PID %s) - %.1f%%", i+1, p.Command, p.PID, p.MemoryPercent))
-			}
+	sm.checkFileDescriptors()
+	sm.checkMemoryPressure()
+
+	// Log summary of top 5 for quick monitoring
+	if len(processes) >= 5 {
+		logger.Info("Top 5 memory processes summary:")
+		for i := 0; i < 5; i++ {
+			p := processes[i]
+			logger.Info(fmt.Sprintf("  #%d: %s (PID %s) - %.1f%%", i+1, p.Command, p.PID, p.MemoryPercent))
 		}
 	}
 }
 
 func (sm *SystemMonitor) startTemperatureMonitoring() {
 	logger.Info("Temperature monitoring goroutine started")
-	logger.Info("Creating ticker with interval:", sm.config.Monitor.Interval)
 
+	// Seed data immediately instead of waiting for the first tick
+	logger.Info("Running initial temperature collection...")
+	runCycle("temperature", sm.collectTemperature)
+
+	logger.Info("Creating ticker with interval:", sm.config.Monitor.Interval)
 	ticker := time.NewTicker(sm.config.Monitor.Interval)
 	defer func() {
 		logger.Info("Stopping temperature monitoring ticker")
@@ -176,44 +504,194 @@ func (sm *SystemMonitor) startTemperatureMonitoring() {
 
 	for {
 		select {
+		case <-sm.done:
+			return
 		case <-ticker.C:
 			logger.Info("Temperature monitoring cycle started")
+			runCycle("temperature", sm.collectTemperature)
+		}
+	}
+}
 
-			sensors, err := sm.tempMonitor.GetSensors()
-			if err != nil {
-				logger.Error("Temperature monitoring failed:", err)
-				continue
-			}
+// collectTemperature runs a single temperature monitoring cycle
+func (sm *SystemMonitor) collectTemperature() {
+	sensors, err := sm.tempMonitor.GetSensors()
+	if err != nil {
+		logger.Error("Temperature monitoring failed:", err)
+		return
+	}
 
-			if len(sensors) == 0 {
-				logger.Warn("No temperature sensors found in this cycle")
-				continue
-			}
+	if len(sensors) == 0 {
+		logger.Warn("No temperature sensors found in this cycle")
+		return
+	}
 
-			logger.Info("Processing", len(sensors), "temperature sensors")
+	logger.Info("Processing", len(sensors), "temperature sensors")
 
-			// Find highest temperature
-			var maxSensor monitor.TemperatureSensor
-			for _, sensor := range sensors {
-				if sensor.Temperature > maxSensor.Temperature {
-					maxSensor = sensor
-				}
-			}
+	sm.silences.apply(sensors)
 
-			logger.Info("Highest temperature found:", maxSensor.Temperature, "°C from sensor:", maxSensor.Name)
-
-			// Check for alert conditions
-			if maxSensor.Status == monitor.TempCritical {
-				logger.Warn("CRITICAL temperature detected:", maxSensor.Temperature, "°C")
-				sm.sendTemperatureAlert("🚨 CRITICAL", sensors, "⚠️ **IMMEDIATE ACTION REQUIRED** - System temperature critical!")
-			} else if maxSensor.Status == monitor.TempWarning {
-				logger.Warn("WARNING temperature detected:", maxSensor.Temperature, "°C")
-				sm.sendTemperatureAlert("⚠️ WARNING", sensors, "🔥 System temperature elevated - monitor closely")
-			} else {
-				logger.Info("All temperatures normal. Max temp:", maxSensor.Temperature, "°C")
-			}
+	// Store the latest sensor data for status commands
+	sm.snapshotMu.Lock()
+	sm.lastSensorData = append([]monitor.TemperatureSensor(nil), sensors...)
+	sm.snapshotMu.Unlock()
+	sm.trends.record(sensors)
+	sm.trackSensorPresence(sensors)
+
+	maxSensor := worstSensor(sensors)
+
+	logger.Info("Highest temperature found:", maxSensor.Temperature, "°C from sensor:", maxSensor.Name)
+
+	if sm.config.Discord.DynamicStatus {
+		sm.updatePresence(maxSensor)
+	}
+
+	// Check for alert conditions
+	// Critical and any level above it alert immediately under their own name
+	if maxSensor.Status >= monitor.TempCritical {
+		logger.Warn(strings.ToUpper(maxSensor.Status.String()), "temperature detected:", maxSensor.Temperature, "°C")
+		sm.raiseTemperatureAlert(alertLevelLabel(maxSensor.Status), sensors, criticalAlertMessage, maxSensor)
+	} else if warn, message := sm.warningCondition(sensors, maxSensor); warn {
+		logger.Warn("WARNING temperature detected:", maxSensor.Temperature, "°C")
+		sm.raiseTemperatureAlert(alertLevelLabel(monitor.TempWarning), sensors, message, maxSensor)
+	} else if sm.config.Monitor.AlertMode != config.AlertModeEvolving && channelAlerting(sm.channelStatuses(sensors)) {
+		// Channels with lower thresholds of their own still hear about it
+		logger.Warn("Temperature alert for channels with their own thresholds only. Max temp:", maxSensor.Temperature, "°C")
+		sm.sendTemperatureAlert("", sensors, "")
+	} else {
+		logger.Info("No alert condition under the", sm.config.Monitor.AlertPolicy, "policy. Max temp:", maxSensor.Temperature, "°C")
+		sm.recordNormalCycle(maxSensor)
+		return
+	}
+	sm.normalCycles = 0
+}
+
+// recordNormalCycle counts a cycle without an alert condition. The condition
+// only clears after RECOVERY_CYCLES consecutive normal cycles, so a brief dip
+// between spikes neither re-alerts nor announces a recovery.
+func (sm *SystemMonitor) recordNormalCycle(maxSensor monitor.TemperatureSensor) {
+	sm.normalCycles++
+	if sm.normalCycles < sm.config.Monitor.RecoveryCycles {
+		logger.Info("Alert condition clear for", sm.normalCycles, "of", sm.config.Monitor.RecoveryCycles, "cycles - condition held")
+		return
+	}
+
+	sm.alertStateMu.Lock()
+	if sm.lastAlertFingerprint != "" {
+		logger.Info("Alert condition resolved - clearing alert fingerprint")
+		sm.lastAlertFingerprint = ""
+	}
+	sm.clearPendingAlert()
+	sm.alertStateMu.Unlock()
+	if sm.incident != nil {
+		sm.resolveIncident(maxSensor)
+	}
+}
+
+// criticalAlertMessage introduces critical temperature alerts
+const criticalAlertMessage = "⚠️ **IMMEDIATE ACTION REQUIRED** - System temperature critical!"
+
+// alertLevelLabel is the level shown in alert titles, e.g. "🚨 CRITICAL"
+func alertLevelLabel(status monitor.TempStatus) string {
+	level := status.Level()
+	return fmt.Sprintf("%s %s", level.Icon, strings.ToUpper(level.Name))
+}
+
+// worstSensor returns the worst sensor among those that drive alerting:
+// highest status first, since hysteresis can hold a cooler sensor at a higher
+// status, then highest temperature
+func worstSensor(sensors []monitor.TemperatureSensor) monitor.TemperatureSensor {
+	var maxSensor monitor.TemperatureSensor
+	for _, sensor := range sensors {
+		if sensor.ExcludeFromAlerts {
+			continue
+		}
+		if sensor.Status > maxSensor.Status ||
+			(sensor.Status == maxSensor.Status && sensor.Temperature > maxSensor.Temperature) {
+			maxSensor = sensor
 		}
 	}
+	return maxSensor
+}
+
+// raiseTemperatureAlert delivers an alert according to the configured mode
+func (sm *SystemMonitor) raiseTemperatureAlert(level string, sensors []monitor.TemperatureSensor, message string, maxSensor monitor.TemperatureSensor) {
+	if sm.config.Monitor.AlertMode == config.AlertModeEvolving {
+		sm.updateIncident(level, sensors, message, maxSensor)
+		return
+	}
+	sm.sendTemperatureAlert(level, sensors, message)
+}
+
+func (sm *SystemMonitor) startPowerMonitoring() {
+	logger.Info("Power monitoring goroutine started")
+
+	logger.Info("Running initial power collection...")
+	runCycle("power", sm.collectPower)
+
+	logger.Info("Creating power ticker with interval:", sm.config.Monitor.Interval)
+	ticker := time.NewTicker(sm.config.Monitor.Interval)
+	defer func() {
+		logger.Info("Stopping power monitoring ticker")
+		ticker.Stop()
+	}()
+
+	for {
+		select {
+		case <-sm.done:
+			return
+		case <-ticker.C:
+			logger.Info("Power monitoring cycle started")
+			runCycle("power", sm.collectPower)
+		}
+	}
+}
+
+// collectPower runs a single power monitoring cycle and alerts when a
+// battery starts discharging or drops below the configured charge
+func (sm *SystemMonitor) collectPower() {
+	supplies, err := sm.powerMonitor.GetPowerSupplies()
+	if err != nil {
+		logger.Error("Power monitoring failed:", err)
+		return
+	}
+
+	var reasons []string
+	for _, supply := range supplies {
+		if !supply.IsBattery() {
+			continue
+		}
+		if sm.config.Monitor.AlertOnBattery && supply.OnBattery() {
+			reasons = append(reasons, fmt.Sprintf("**%s** is running on battery", supply.Name))
+		}
+		threshold := sm.config.Thresholds.LowBatteryPercent
+		if threshold > 0 && supply.HasCapacity && supply.Capacity < threshold {
+			reasons = append(reasons, fmt.Sprintf("**%s** charge is %.0f%% (below %.0f%%)", supply.Name, supply.Capacity, threshold))
+		}
+	}
+
+	if len(reasons) == 0 {
+		if sm.powerAlertActive {
+			logger.Info("Power alert condition resolved")
+			sm.powerAlertActive = false
+		}
+		return
+	}
+
+	if sm.powerAlertActive {
+		logger.Info("Power alert condition still active - already alerted")
+		return
+	}
+
+	logger.Warn("Power alert condition detected:", strings.Join(reasons, "; "))
+	if !sm.hasAlertTargets() {
+		logger.Warn("No alert channels or sinks configured - power alert not sent")
+		return
+	}
+
+	logger.Info("Building power alert embed...")
+	embed := sm.embedBuilder.BuildPowerAlert(supplies, strings.Join(reasons, "\n"))
+	sm.broadcastAlert(embed)
+	sm.powerAlertActive = true
 }
 
 type AlertData struct {
@@ -222,22 +700,25 @@ type AlertData struct {
 	Message string
 }
 
+// sendTemperatureAlert sends an alert at level to the sinks and the alert
+// channels without thresholds of their own. Channels with their own
+// thresholds are alerted when the sensors reach them instead, and are all
+// that is alerted when level is empty.
 func (sm *SystemMonitor) sendTemperatureAlert(level string, sensors []monitor.TemperatureSensor, message string) {
 	logger.Info("Processing temperature alert:", level)
+	statuses := sm.channelStatuses(sensors)
 
-	// Check cooldown
-	timeSinceLastAlert := time.Since(sm.lastAlert)
-	if timeSinceLastAlert < sm.config.Monitor.AlertCooldown {
-		logger.Info("Alert suppressed - cooldown active. Time since last:", timeSinceLastAlert, "Required:", sm.config.Monitor.AlertCooldown)
+	fingerprint := alertFingerprint(sensors) + channelFingerprint(statuses)
+	if !sm.admitAlert(level, fingerprint, statuses) {
 		return
 	}
 
-	if len(sm.alertChannels) == 0 {
-		logger.Warn("No alert channels configured - alert not sent")
+	if !sm.hasAlertTargets() {
+		logger.Warn("No alert channels or sinks configured - alert not sent")
 		return
 	}
 
-	logger.Info("Sending alerts to", len(sm.alertChannels), "configured channels")
+	logger.Info("Sending alerts to", sm.alertChannelCount(), "configured channels")
 
 	alertData := AlertData{
 		Level:   level,
@@ -245,26 +726,377 @@ func (sm *SystemMonitor) sendTemperatureAlert(level string, sensors []monitor.Te
 		Message: message,
 	}
 
-	logger.Info("Building alert embed...")
-	embed := sm.embedBuilder.BuildAlert(alertData.Level, alertData.Sensors, alertData.Message)
+	var embed *discordgo.MessageEmbed
+	var text string
+	if level != "" {
+		logger.Info("Building alert embed...")
+		embed = sm.embedBuilder.BuildAlert(alertData.Level, alertData.Sensors, alertData.Message)
+		text = sm.embedBuilder.BuildAlertText(alertData.Level, alertData.Sensors, alertData.Message)
+		sm.notifySinks(embed, text)
+	}
+
+	channelAlerts := sm.buildChannelAlerts(sensors, statuses)
+	sm.fanOutAlert(func(channelID string) error {
+		if _, own := statuses[channelID]; own {
+			alert, alerting := channelAlerts[channelID]
+			if !alerting {
+				return errAlertSkipped
+			}
+			logger.Info("Sending alert at the channel's own thresholds to channel:", channelID)
+			return sm.sendAlert(channelID, alert.embed, alert.text)
+		}
+		if embed == nil {
+			return errAlertSkipped
+		}
+		logger.Info("Sending alert to channel:", channelID)
+		return sm.sendAlert(channelID, embed, text)
+	})
+	sm.alertStateMu.Lock()
+	sm.lastAlert = time.Now()
+	sm.lastAlertFingerprint = fingerprint
+	sm.alertStateMu.Unlock()
+	logger.Info("Last alert time updated for fingerprint:", fingerprint)
+}
+
+// admitAlert reports whether an alert condition should be sent now, holding
+// it back as pending when it is a repeat or the cooldown is active
+func (sm *SystemMonitor) admitAlert(level, fingerprint string, statuses map[string]monitor.TempStatus) bool {
+	sm.alertStateMu.Lock()
+	defer sm.alertStateMu.Unlock()
+
+	// Check whether this exact condition was already alerted
+	if fingerprint == sm.lastAlertFingerprint {
+		logger.Info("Alert suppressed - condition unchanged since last alert. Fingerprint:", fingerprint)
+		sm.clearPendingAlert()
+		return false
+	}
+
+	// Check cooldown; under the repeats policy a condition entered while no
+	// alert was active goes out at once
+	timeSinceLastAlert := time.Since(sm.lastAlert)
+	newCondition := sm.lastAlertFingerprint == ""
+	if newCondition && sm.config.Monitor.CooldownPolicy == config.CooldownPolicyRepeats {
+		logger.Info("New alert condition - sending immediately regardless of cooldown")
+	} else if timeSinceLastAlert < sm.config.Monitor.AlertCooldown {
+		logger.Info("Alert suppressed - cooldown active. Time since last:", timeSinceLastAlert, "Required:", sm.config.Monitor.AlertCooldown)
+		if sm.pendingAlertLevel == "" {
+			sm.pendingAlertSince = time.Now()
+		}
+		sm.pendingAlertLevel = level
+		if level == "" {
+			sm.pendingAlertLevel = alertLevelLabel(worstChannelStatus(statuses))
+		}
+		return false
+	}
+	sm.clearPendingAlert()
+	return true
+}
+
+// channelAlert is a temperature alert graded against one channel's own
+// thresholds
+type channelAlert struct {
+	embed *discordgo.MessageEmbed
+	text  string
+}
+
+// buildChannelAlerts builds the alerts of the channels whose own thresholds
+// the sensors reach
+func (sm *SystemMonitor) buildChannelAlerts(sensors []monitor.TemperatureSensor, statuses map[string]monitor.TempStatus) map[string]channelAlert {
+	overrides := sm.channelOverrides()
+	alerts := make(map[string]channelAlert)
+	for channelID, status := range statuses {
+		alertConfig, ok := overrides[channelID]
+		if !ok || status < monitor.TempWarning {
+			continue
+		}
+		warning, critical := alertConfig.thresholds(sm.config.Thresholds)
+		graded, _ := gradeSensors(sensors, warning, critical)
+
+		level := alertLevelLabel(status)
+		message := criticalAlertMessage
+		if status < monitor.TempCritical {
+			message = fmt.Sprintf("Temperature above this channel's warning threshold of %.1f°C", warning)
+		}
+		alerts[channelID] = channelAlert{
+			embed: sm.embedBuilder.BuildAlert(level, graded, message),
+			text:  sm.embedBuilder.BuildAlertTextWithThresholds(level, graded, message, warning, critical),
+		}
+	}
+	return alerts
+}
+
+// channelAlerting reports whether any channel's own thresholds are reached
+func channelAlerting(statuses map[string]monitor.TempStatus) bool {
+	return worstChannelStatus(statuses) >= monitor.TempWarning
+}
+
+// worstChannelStatus returns the worst status graded for any channel
+func worstChannelStatus(statuses map[string]monitor.TempStatus) monitor.TempStatus {
+	worst := monitor.TempNormal
+	for _, status := range statuses {
+		worst = max(worst, status)
+	}
+	return worst
+}
+
+// channelFingerprint extends alertFingerprint with the channels alerting at
+// their own thresholds, so a change there is a new condition
+func channelFingerprint(statuses map[string]monitor.TempStatus) string {
+	var parts []string
+	for channelID, status := range statuses {
+		if status >= monitor.TempWarning {
+			parts = append(parts, fmt.Sprintf("%s=%s", channelID, status))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	sort.Strings(parts)
+	return "|" + strings.Join(parts, ";")
+}
+
+// clearPendingAlert forgets an alert held back by the cooldown, once it is
+// sent, already covered by the last alert, or its condition has cleared;
+// callers hold alertStateMu
+func (sm *SystemMonitor) clearPendingAlert() {
+	if sm.pendingAlertLevel != "" {
+		logger.Info("Clearing pending alert:", sm.pendingAlertLevel)
+		sm.pendingAlertLevel = ""
+	}
+}
+
+// alertFingerprint identifies an alert condition by the sensors that are in
+// warning or critical state, so identical conditions can be recognised
+func alertFingerprint(sensors []monitor.TemperatureSensor) string {
+	var parts []string
+	for _, sensor := range sensors {
+		if sensor.ExcludeFromAlerts {
+			continue
+		}
+		if sensor.Status >= monitor.TempWarning {
+			parts = append(parts, fmt.Sprintf("%s=%s", sensor.ID, sensor.Status))
+		}
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ";")
+}
+
+// broadcastAlert sends an alert embed to all configured alert channels,
+// deriving the plain text form from the embed itself
+func (sm *SystemMonitor) broadcastAlert(embed *discordgo.MessageEmbed) {
+	sm.broadcastAlertWithText(embed, sm.embedBuilder.PlainText(embed))
+}
 
-	// Send to all configured channels
-	successCount := 0
-	errorCount := 0
-	for channelID := range sm.alertChannels {
+// broadcastAlertWithText sends an alert to all configured alert channels
+// using text for the plain text alert format
+func (sm *SystemMonitor) broadcastAlertWithText(embed *discordgo.MessageEmbed, text string) {
+	sm.notifySinks(embed, text)
+	sm.fanOutAlert(func(channelID string) error {
 		logger.Info("Sending alert to channel:", channelID)
-		_, err := sm.discord.ChannelMessageSendEmbed(channelID, embed)
+		return sm.sendAlert(channelID, embed, text)
+	})
+}
+
+// errAlertSkipped is returned by a fanOutAlert send function for a channel
+// the alert does not concern; it counts as neither sent nor failed
+var errAlertSkipped = errors.New("alert not sent to this channel")
+
+// fanOutAlert runs send for every active alert channel over a bounded
+// worker pool and drops channels that fail
+func (sm *SystemMonitor) fanOutAlert(send func(channelID string) error) {
+	targets := sm.activeAlertChannels()
+	workers := min(maxAlertWorkers, len(targets))
+	logger.Info("Sending alert to", len(targets), "channels with", workers, "workers")
+
+	// Fan out over a bounded pool; discordgo's rate limiter still applies
+	// per route so concurrent sends queue rather than trip the limits
+	jobs := make(chan string)
+	var mu sync.Mutex
+	var failed []string
+	successCount, skippedCount := 0, 0
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for channelID := range jobs {
+				err := send(channelID)
+
+				mu.Lock()
+				if errors.Is(err, errAlertSkipped) {
+					skippedCount++
+				} else if err != nil {
+					logger.Error("Failed to send alert to channel", channelID, "error:", err)
+					metrics.AlertSendFailures.Inc()
+					failed = append(failed, channelID)
+				} else {
+					logger.Info("Alert sent successfully to channel:", channelID)
+					metrics.AlertsSent.Inc()
+					successCount++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, channelID := range targets {
+		jobs <- channelID
+	}
+	close(jobs)
+	wg.Wait()
+
+	// Remove invalid channels
+	for _, channelID := range failed {
+		sm.removeAlertChannel(channelID)
+		metrics.ChannelsRemoved.Inc()
+	}
+
+	logger.Info("Alert sending complete. Success:", successCount, "Skipped:", skippedCount, "Errors:", len(failed))
+}
+
+// checkMemoryPressure alerts when the share of time tasks stalled on memory
+// over the last minute exceeds the configured threshold. Kernels without
+// PSI simply never alert.
+func (sm *SystemMonitor) checkMemoryPressure() {
+	threshold := sm.config.Thresholds.MemoryPressurePercent
+	if threshold <= 0 {
+		return
+	}
+
+	pressures, err := sm.psiMonitor.GetPressure()
+	if errors.Is(err, monitor.ErrPressureUnavailable) {
+		logger.Info("Memory pressure check skipped:", err)
+		return
+	}
+	if err != nil {
+		logger.Error("Memory pressure check failed:", err)
+		return
+	}
+
+	var memory *monitor.ResourcePressure
+	for idx := range pressures {
+		if pressures[idx].Resource == "memory" {
+			memory = &pressures[idx]
+		}
+	}
+	if memory == nil || memory.Some.Avg60 < threshold {
+		return
+	}
+
+	logger.Warn("High memory pressure detected:", fmt.Sprintf("%.2f%%", memory.Some.Avg60), "threshold:", threshold, "%")
+
+	timeSinceLastAlert := time.Since(sm.lastPressureAlert)
+	if timeSinceLastAlert < sm.config.Monitor.AlertCooldown {
+		logger.Info("Memory pressure alert suppressed - cooldown active. Time since last:", timeSinceLastAlert)
+		return
+	}
+
+	if !sm.hasAlertTargets() {
+		logger.Warn("No alert channels or sinks configured - memory pressure alert not sent")
+		return
+	}
+
+	logger.Info("Building memory pressure alert embed...")
+	embed := sm.embedBuilder.BuildPressureAlert(*memory, threshold)
+	sm.broadcastAlert(embed)
+	sm.lastPressureAlert = time.Now()
+}
+
+// checkFileDescriptors alerts when system-wide fd usage exceeds the
+// configured threshold
+func (sm *SystemMonitor) checkFileDescriptors() {
+	threshold := sm.config.Thresholds.FileDescriptorPercent
+	if threshold <= 0 {
+		return
+	}
+
+	stats, err := sm.fdMonitor.GetFileDescriptorStats(nil)
+	if err != nil {
+		logger.Error("File descriptor check failed:", err)
+		return
+	}
+
+	usage := stats.UsagePercent()
+	if usage < threshold {
+		return
+	}
+
+	logger.Warn("High file descriptor usage detected:", fmt.Sprintf("%.1f%%", usage), "threshold:", threshold, "%")
+
+	timeSinceLastAlert := time.Since(sm.lastFDAlert)
+	if timeSinceLastAlert < sm.config.Monitor.AlertCooldown {
+		logger.Info("File descriptor alert suppressed - cooldown active. Time since last:", timeSinceLastAlert)
+		return
+	}
+
+	if !sm.hasAlertTargets() {
+		logger.Warn("No alert channels or sinks configured - file descriptor alert not sent")
+		return
+	}
+
+	logger.Info("Building file descriptor alert embed...")
+	embed := sm.embedBuilder.BuildFileDescriptorAlert(stats, threshold)
+	sm.broadcastAlert(embed)
+	sm.lastFDAlert = time.Now()
+}
+
+// snoozeAlerts suppresses alerts for a channel for the given duration and
+// schedules a resume notice when it expires
+func (sm *SystemMonitor) snoozeAlerts(channelID string, duration time.Duration) time.Time {
+	until := time.Now().Add(duration)
+	sm.alertMu.Lock()
+	sm.snoozedUntil[channelID] = until
+	sm.alertMu.Unlock()
+	logger.Info("Alerts snoozed for channel:", channelID, "until:", until)
+
+	time.AfterFunc(duration, func() {
+		// A newer snooze or a disable replaces this one
+		sm.alertMu.Lock()
+		current, snoozed := sm.snoozedUntil[channelID]
+		if !snoozed || !current.Equal(until) {
+			sm.alertMu.Unlock()
+			logger.Info("Snooze for channel", channelID, "was replaced - skipping resume notice")
+			return
+		}
+		delete(sm.snoozedUntil, channelID)
+		sm.alertMu.Unlock()
+		logger.Info("Snooze expired - alerts resumed for channel:", channelID)
+
+		_, err := sm.postAlert(channelID, &discordgo.MessageSend{Content: "🔔 **Temperature alerts resumed** for this channel."})
 		if err != nil {
-			logger.Error("Failed to send alert to channel", channelID, "error:", err)
-			delete(sm.alertChannels, channelID) // Remove invalid channels
-			errorCount++
-		} else {
-			logger.Info("Alert sent successfully to channel:", channelID)
-			successCount++
+			logger.Error("Failed to send alerts resumed notice to channel", channelID, "error:", err)
 		}
+	})
+
+	return until
+}
+
+// updatePresence shows the current max temperature as the bot's status,
+// only calling Discord when the displayed text changes
+func (sm *SystemMonitor) updatePresence(maxSensor monitor.TemperatureSensor) {
+	icon := "🌡️"
+	switch {
+	case maxSensor.Status >= monitor.TempCritical:
+		icon = "🚨"
+	case maxSensor.Status >= monitor.TempWarning:
+		icon = "🔥"
 	}
 
-	logger.Info("Alert sending complete. Success:", successCount, "Errors:", errorCount)
-	sm.lastAlert = time.Now()
-	logger.Info("Last alert time updated to:", sm.lastAlert)
+	presence := fmt.Sprintf("%s %.0f°C", icon, maxSensor.Temperature)
+	sm.alertStateMu.Lock()
+	unchanged := presence == sm.lastPresence
+	sm.alertStateMu.Unlock()
+	if unchanged {
+		return
+	}
+
+	logger.Info("Updating bot presence to:", presence)
+	if err := sm.discord.UpdateGameStatus(0, presence); err != nil {
+		logger.Error("Failed to update bot presence:", err)
+		return
+	}
+	sm.alertStateMu.Lock()
+	sm.lastPresence = presence
+	sm.alertStateMu.Unlock()
 }
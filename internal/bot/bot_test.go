@@ -0,0 +1,117 @@
+package bot
+
+import (
+	"fmt"
+	"sync"
+	"system-monitor-bot/internal/monitor"
+	"testing"
+)
+
+// TestAlertStateConcurrentAccess drives /alerts and /status from handler
+// goroutines while the monitor goroutine sends alerts; run with -race to
+// check the locking
+func TestAlertStateConcurrentAccess(t *testing.T) {
+	session := newStubSession()
+	sm := newTestMonitor(t, session, nil, nil, nil)
+	sm.config.Monitor.AlertCooldown = 0
+	sm.handleAlertsCommand(session, commandInteraction("alerts", "alerts", stringOption("action", "enable")))
+
+	const rounds = 50
+	var wg sync.WaitGroup
+	for n := 0; n < 2; n++ {
+		channelID := fmt.Sprintf("channel-%d", n)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for round := 0; round < rounds; round++ {
+				action := "enable"
+				if round%2 == 1 {
+					action = "disable"
+				}
+				sm.handleAlertsCommand(session, commandInteraction(channelID, "alerts", stringOption("action", action)))
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for round := 0; round < rounds; round++ {
+			sensors := []monitor.TemperatureSensor{{
+				ID:          "cpu",
+				Name:        "CPU",
+				Temperature: 95 + float64(round%2),
+				Status:      monitor.TempWarning + monitor.TempStatus(round%2),
+			}}
+			sm.sendTemperatureAlert(alertLevelLabel(monitor.TempCritical), sensors, criticalAlertMessage)
+			sm.updatePresence(sensors[0])
+		}
+	}()
+
+	// /status reads the alert state from its own handler goroutine
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for round := 0; round < rounds; round++ {
+			sm.lastAlertField()
+		}
+	}()
+	wg.Wait()
+
+	if sent := len(session.sentTo("alerts")); sent != rounds {
+		t.Errorf("alert channel received %d alerts, want %d", sent, rounds)
+	}
+}
+
+// TestSnapshotConcurrentAccess reads the latest readings the way /status
+// does while the monitor goroutines replace them; run with -race to check
+// the locking
+func TestSnapshotConcurrentAccess(t *testing.T) {
+	sensors := fakeSensors{sensors: []monitor.TemperatureSensor{
+		{ID: "coretemp_package_id_0", Name: "CPU Package", Temperature: 48, Category: monitor.CategoryCPU},
+	}}
+	processes := fakeProcesses{processes: []monitor.ProcessMemory{
+		{PID: "1", Command: "init", MemoryPercent: 0.1},
+	}}
+	ports := fakePorts{ports: []monitor.NetworkPort{
+		{Protocol: "TCP", Address: "0.0.0.0", Port: "22", State: "LISTEN", ProcessName: "sshd"},
+	}}
+	session := newStubSession()
+	sm := newTestMonitor(t, session, sensors, ports, processes)
+	if _, ok := sm.portSnapshot(); ok {
+		t.Error("portSnapshot() reports data before the first network cycle")
+	}
+
+	const rounds = 50
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for round := 0; round < rounds; round++ {
+			sm.collectTemperature()
+			sm.collectMemory()
+			sm.collectNetwork()
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for round := 0; round < rounds; round++ {
+			sm.sensorSnapshot()
+			sm.memorySnapshot()
+			sm.portSnapshot()
+		}
+	}()
+	wg.Wait()
+
+	if got := sm.sensorSnapshot(); len(got) != 1 || got[0].Name != "CPU Package" {
+		t.Errorf("sensorSnapshot() = %+v, want the CPU Package reading", got)
+	}
+	if got := sm.memorySnapshot(); len(got) != 1 || got[0].Command != "init" {
+		t.Errorf("memorySnapshot() = %+v, want the init process", got)
+	}
+	if got, ok := sm.portSnapshot(); !ok || len(got) != 1 || got[0].Port != "22" {
+		t.Errorf("portSnapshot() = %+v, %v, want port 22", got, ok)
+	}
+}
@@ -0,0 +1,192 @@
+package bot
+
+import (
+	"fmt"
+	"system-monitor-bot/internal/config"
+	"system-monitor-bot/internal/monitor"
+	"system-monitor-bot/pkg/logger"
+	"time"
+)
+
+// ChannelAlertConfig holds the alert thresholds a channel sets for itself,
+// in °C; zero falls back to the configured default
+type ChannelAlertConfig struct {
+	Warning  float64
+	Critical float64
+}
+
+// overrides reports whether the channel sets any threshold of its own
+func (c ChannelAlertConfig) overrides() bool {
+	return c.Warning != 0 || c.Critical != 0
+}
+
+// thresholds resolves the channel's warning and critical thresholds
+func (c ChannelAlertConfig) thresholds(defaults config.ThresholdConfig) (warning, critical float64) {
+	warning, critical = defaults.Warning, defaults.Critical
+	if c.Warning != 0 {
+		warning = c.Warning
+	}
+	if c.Critical != 0 {
+		critical = c.Critical
+	}
+	return warning, critical
+}
+
+// validate checks that the resolved warning threshold is below critical
+func (c ChannelAlertConfig) validate(defaults config.ThresholdConfig) error {
+	if warning, critical := c.thresholds(defaults); warning >= critical {
+		return fmt.Errorf("the warning threshold (%.1f°C) must be below the critical threshold (%.1f°C)", warning, critical)
+	}
+	return nil
+}
+
+// The alert channel maps are written by command handlers and read and
+// pruned by the monitoring goroutines, so all access goes through alertMu.
+
+// enableAlertChannel adds an alert channel in guildID, or replaces its
+// thresholds when it already is one, clearing any snooze
+func (sm *SystemMonitor) enableAlertChannel(channelID, guildID string, alertConfig ChannelAlertConfig) {
+	sm.alertMu.Lock()
+	defer sm.alertMu.Unlock()
+	sm.alertChannels[channelID] = alertConfig
+	if guildID != "" {
+		sm.alertGuilds[channelID] = guildID
+	}
+	delete(sm.snoozedUntil, channelID)
+}
+
+// removeAlertChannel forgets an alert channel along with its webhook,
+// thread and snooze
+func (sm *SystemMonitor) removeAlertChannel(channelID string) {
+	sm.alertMu.Lock()
+	delete(sm.alertChannels, channelID)
+	delete(sm.alertWebhooks, channelID)
+	delete(sm.alertGuilds, channelID)
+	delete(sm.snoozedUntil, channelID)
+	sm.alertMu.Unlock()
+	sm.alertThreads.remove(channelID)
+}
+
+func (sm *SystemMonitor) isAlertChannel(channelID string) bool {
+	sm.alertMu.RLock()
+	defer sm.alertMu.RUnlock()
+	_, enabled := sm.alertChannels[channelID]
+	return enabled
+}
+
+// channelOverrides returns the thresholds of the alert channels that set
+// their own, including snoozed ones
+func (sm *SystemMonitor) channelOverrides() map[string]ChannelAlertConfig {
+	sm.alertMu.RLock()
+	defer sm.alertMu.RUnlock()
+	overrides := make(map[string]ChannelAlertConfig)
+	for channelID, alertConfig := range sm.alertChannels {
+		if alertConfig.overrides() {
+			overrides[channelID] = alertConfig
+		}
+	}
+	return overrides
+}
+
+func (sm *SystemMonitor) alertChannelCount() int {
+	sm.alertMu.RLock()
+	defer sm.alertMu.RUnlock()
+	return len(sm.alertChannels)
+}
+
+// alertChannelIDs returns a snapshot of the alert channels
+func (sm *SystemMonitor) alertChannelIDs() []string {
+	sm.alertMu.RLock()
+	defer sm.alertMu.RUnlock()
+	ids := make([]string, 0, len(sm.alertChannels))
+	for channelID := range sm.alertChannels {
+		ids = append(ids, channelID)
+	}
+	return ids
+}
+
+// alertGuild returns the guild of an alert channel, empty when unknown
+func (sm *SystemMonitor) alertGuild(channelID string) string {
+	sm.alertMu.RLock()
+	defer sm.alertMu.RUnlock()
+	return sm.alertGuilds[channelID]
+}
+
+func (sm *SystemMonitor) setAlertWebhook(channelID string, webhook alertWebhook) {
+	sm.alertMu.Lock()
+	defer sm.alertMu.Unlock()
+	sm.alertWebhooks[channelID] = webhook
+}
+
+func (sm *SystemMonitor) alertWebhook(channelID string) (alertWebhook, bool) {
+	sm.alertMu.RLock()
+	defer sm.alertMu.RUnlock()
+	webhook, exists := sm.alertWebhooks[channelID]
+	return webhook, exists
+}
+
+// snoozedChannelCount counts channels with a snooze set
+func (sm *SystemMonitor) snoozedChannelCount() int {
+	sm.alertMu.RLock()
+	defer sm.alertMu.RUnlock()
+	return len(sm.snoozedUntil)
+}
+
+// activeAlertChannels returns the alert channels that are not snoozed
+func (sm *SystemMonitor) activeAlertChannels() []string {
+	sm.alertMu.RLock()
+	defer sm.alertMu.RUnlock()
+
+	var targets []string
+	for channelID := range sm.alertChannels {
+		if until, snoozed := sm.snoozedUntil[channelID]; snoozed && time.Now().Before(until) {
+			logger.Info("Skipping snoozed channel:", channelID, "until:", until)
+			continue
+		}
+		targets = append(targets, channelID)
+	}
+	return targets
+}
+
+// channelStatuses grades the sensors against the thresholds of every
+// unsnoozed alert channel that sets its own
+func (sm *SystemMonitor) channelStatuses(sensors []monitor.TemperatureSensor) map[string]monitor.TempStatus {
+	sm.alertMu.RLock()
+	defer sm.alertMu.RUnlock()
+
+	statuses := make(map[string]monitor.TempStatus)
+	for channelID, alertConfig := range sm.alertChannels {
+		if !alertConfig.overrides() {
+			continue
+		}
+		if until, snoozed := sm.snoozedUntil[channelID]; snoozed && time.Now().Before(until) {
+			continue
+		}
+		warning, critical := alertConfig.thresholds(sm.config.Thresholds)
+		_, statuses[channelID] = gradeSensors(sensors, warning, critical)
+	}
+	return statuses
+}
+
+// gradeSensors returns a copy of sensors graded against the given warning
+// and critical thresholds alone, and the worst status among those that
+// drive alerting
+func gradeSensors(sensors []monitor.TemperatureSensor, warning, critical float64) ([]monitor.TemperatureSensor, monitor.TempStatus) {
+	graded := make([]monitor.TemperatureSensor, len(sensors))
+	worst := monitor.TempNormal
+	for idx, sensor := range sensors {
+		switch {
+		case sensor.Temperature >= critical:
+			sensor.Status = monitor.TempCritical
+		case sensor.Temperature >= warning:
+			sensor.Status = monitor.TempWarning
+		default:
+			sensor.Status = monitor.TempNormal
+		}
+		if !sensor.ExcludeFromAlerts {
+			worst = max(worst, sensor.Status)
+		}
+		graded[idx] = sensor
+	}
+	return graded, worst
+}
@@ -1,59 +1,455 @@
 package bot
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"system-monitor-bot/internal/config"
+	"system-monitor-bot/internal/embed"
+	"system-monitor-bot/internal/metrics"
+	"system-monitor-bot/internal/monitor"
 	"system-monitor-bot/pkg/logger"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 )
 
-func (sm *SystemMonitor) registerSlashCommands(s *discordgo.Session) {
-	logger.Info("Starting slash command registration...")
+// Bounds for /alerts snooze durations, in minutes
+var (
+	minSnoozeMinutes     = 1.0
+	maxSnoozeMinutes     = 1440.0
+	defaultSnoozeMinutes = 60
+)
+
+// Bounds for the number of processes listed by /memory
+var (
+	minMemoryCount = 1.0
+	maxMemoryCount = float64(config.MaxMemoryTopN)
+)
+
+// Bounds for the thresholds a channel can set with /alerts enable, in °C
+var (
+	minChannelThreshold = 1.0
+	maxChannelThreshold = 150.0
+)
+
+// maxListedChannelThresholds caps the channels /status lists with their
+// own thresholds
+const maxListedChannelThresholds = 10
+
+// adminPermission restricts admin-only commands to server administrators
+var adminPermission int64 = discordgo.PermissionAdministrator
+
+// Bounds for the number of lines returned by /logs
+var (
+	minLogLines     = 1.0
+	defaultLogLines = 50
+)
+
+// Bounds for the number of remotes listed by /connections
+var (
+	minConnectionsLimit     = 1.0
+	maxConnectionsLimit     = 25.0
+	defaultConnectionsLimit = 10
+)
+
+// Bounds for the /ports filters; the process filter is capped so the refresh
+// button's custom ID stays within Discord's 100 character limit
+var (
+	minPortNumber          = 1.0
+	maxPortNumber          = 65535.0
+	maxProcessFilterLength = 32
+)
+
+// debugOption lets admins attach the raw command output a response was
+// parsed from
+func debugOption() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionBoolean,
+		Name:        "debug",
+		Description: "Attach the raw command output behind this response (admin only)",
+		Required:    false,
+	}
+}
+
+// commandDefinition is a slash command and whether it changes bot state;
+// mutating commands are not offered in read-only mode
+type commandDefinition struct {
+	*discordgo.ApplicationCommand
+	Mutating bool
+
+	// Deferred commands run external programs, sample over time or call
+	// other hosts, so their handlers defer before collecting anything. The
+	// rest answer from memory and respond directly.
+	Deferred bool
 
-	commands := []*discordgo.ApplicationCommand{
+	// Ephemeral commands answer only the invoking user
+	Ephemeral bool
+}
+
+// slashCommands returns every slash command the bot can register
+func slashCommands() []commandDefinition {
+	return []commandDefinition{
+		{
+			Deferred: true, // runs sensors and ipmitool
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:        "temp",
+				Description: "Display current system temperatures",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "compact",
+						Description: "Group sensors into one field per category (default: one field per sensor)",
+						Required:    false,
+					},
+					debugOption(),
+				},
+			},
+		},
+		{
+			Deferred: true, // runs sensors and ipmitool
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:        "sensors",
+				Description: "Describe available temperature sensors",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "list",
+						Description: "List sensor IDs, categories, readings and chip limits",
+					},
+				},
+			},
+		},
+		{
+			Deferred: true, // runs sensors and ipmitool
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:        "disktemp",
+				Description: "Display NVMe and SATA drive temperatures",
+			},
+		},
+		{
+			Deferred: true, // runs ss, again for connection counts and Unix sockets
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:        "ports",
+				Description: "Show network ports and connections",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "all",
+						Description: "Show all connections (default: listening only)",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "connections",
+						Description: "Show established connections per listening service",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "unix",
+						Description: "Also show listening Unix domain sockets",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "port",
+						Description: "Only show this port number",
+						Required:    false,
+						MinValue:    &minPortNumber,
+						MaxValue:    maxPortNumber,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "process",
+						Description: "Only show ports of processes whose name contains this text",
+						Required:    false,
+						MaxLength:   maxProcessFilterLength,
+					},
+					debugOption(),
+				},
+			},
+		},
+		{
+			Deferred: true, // runs ss
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:        "connections",
+				Description: "Show remote addresses with the most established connections",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "limit",
+						Description: "Number of remote addresses to show (default: 10)",
+						Required:    false,
+						MinValue:    &minConnectionsLimit,
+						MaxValue:    maxConnectionsLimit,
+					},
+				},
+			},
+		},
+		{
+			Deferred: true, // runs top
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:        "memory",
+				Description: "Display top processes by %MEM (memory percentage)",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "sort",
+						Description: "Order by percent of memory or absolute resident size (default: percent)",
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "percent", Value: "percent"},
+							{Name: "rss", Value: "rss"},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "count",
+						Description: "Number of processes to list (default: MEMORY_TOP_N)",
+						Required:    false,
+						MinValue:    &minMemoryCount,
+						MaxValue:    maxMemoryCount,
+					},
+					debugOption(),
+				},
+			},
+		},
+		{
+			Deferred: true, // runs top
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:        "top",
+				Description: "Display top processes with both %CPU and %MEM",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "sort",
+						Description: "Order by CPU or memory usage (default: cpu)",
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "cpu", Value: "cpu"},
+							{Name: "memory", Value: "memory"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Deferred: true, // samples /proc/stat over SAMPLE_WINDOW
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:        "cpu",
+				Description: "Display per-core and overall CPU load",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "detailed",
+						Description: "Pair each core's load with its temperature",
+						Required:    false,
+					},
+				},
+			},
+		},
+		{
+			// Answers from memory
+			Mutating: true,
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:        "alerts",
+				Description: "Configure temperature alerts for this channel",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "action",
+						Description: "Enable, disable or snooze alerts, or silence a single sensor",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "enable", Value: "enable"},
+							{Name: "disable", Value: "disable"},
+							{Name: "snooze", Value: "snooze"},
+							{Name: "silence", Value: "silence"},
+							{Name: "unsilence", Value: "unsilence"},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "sensor",
+						Description: "Sensor ID to silence or unsilence (see /sensors list)",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "duration",
+						Description: "Minutes to snooze (default: 60) or silence (default: until unsilenced)",
+						Required:    false,
+						MinValue:    &minSnoozeMinutes,
+						MaxValue:    maxSnoozeMinutes,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "webhook",
+						Description: "Webhook URL for this channel, used when normal alert delivery fails",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "thread",
+						Description: "Create a thread with this name and post alerts there instead of the channel",
+						Required:    false,
+						MaxLength:   maxThreadNameLength,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionNumber,
+						Name:        "warning",
+						Description: "Warning threshold in °C for this channel (default: the configured threshold)",
+						Required:    false,
+						MinValue:    &minChannelThreshold,
+						MaxValue:    maxChannelThreshold,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionNumber,
+						Name:        "critical",
+						Description: "Critical threshold in °C for this channel (default: the configured threshold)",
+						Required:    false,
+						MinValue:    &minChannelThreshold,
+						MaxValue:    maxChannelThreshold,
+					},
+				},
+			},
+		},
 		{
-			Name:        "temp",
-			Description: "Display current system temperatures",
+			// Answers from memory
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:        "status",
+				Description: "Show bot status and system information",
+			},
 		},
 		{
-			Name:        "ports",
-			Description: "Show network ports and connections",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:        discordgo.ApplicationCommandOptionBoolean,
-					Name:        "all",
-					Description: "Show all connections (default: listening only)",
-					Required:    false,
+			Deferred: true, // statfs on a hung network mount can stall
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:        "disk",
+				Description: "Display filesystem space usage",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "all",
+						Description: "Include pseudo-filesystems such as tmpfs (default: false)",
+						Required:    false,
+					},
 				},
 			},
 		},
 		{
-			Name:        "memory",
-			Description: "Display top 10 processes by %MEM (memory percentage)",
+			Deferred: true, // battery reads go through ACPI and can stall
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:        "power",
+				Description: "Display battery, UPS and power supply status",
+			},
 		},
 		{
-			Name:        "alerts",
-			Description: "Configure temperature alerts for this channel",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "action",
-					Description: "Enable or disable temperature alerts",
-					Required:    true,
-					Choices: []*discordgo.ApplicationCommandOptionChoice{
-						{Name: "enable", Value: "enable"},
-						{Name: "disable", Value: "disable"},
+			Ephemeral: true, // answers from the in-memory log buffer
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:                     "logs",
+				Description:              "Show recent bot log lines (admin only)",
+				DefaultMemberPermissions: &adminPermission,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "lines",
+						Description: "Number of log lines to return (default: 50)",
+						Required:    false,
+						MinValue:    &minLogLines,
+						MaxValue:    float64(logger.RecentCapacity),
 					},
 				},
 			},
 		},
 		{
-			Name:        "status",
-			Description: "Show bot status and system information",
+			// Answers from the gateway state cache
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:        "diagnostics",
+				Description: "Check the bot's permissions in this channel",
+			},
+		},
+		{
+			Deferred: true, // fetches every peer's snapshot
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:        "fleet",
+				Description: "Compare temperatures and memory across this host and its peers",
+			},
+		},
+		{
+			Ephemeral: true, // answers from memory
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:                     "config",
+				Description:              "Show the effective configuration with secrets hidden (admin only)",
+				DefaultMemberPermissions: &adminPermission,
+			},
+		},
+		{
+			Ephemeral: true, // answers from memory
+			Mutating:  true,
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:                     "reset-history",
+				Description:              "Clear the stored temperature trends and disk usage history (admin only)",
+				DefaultMemberPermissions: &adminPermission,
+			},
+		},
+		{
+			Deferred:  true, // runs every monitor
+			Ephemeral: true,
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:                     "selftest",
+				Description:              "Run every monitor and check alert channel permissions (admin only)",
+				DefaultMemberPermissions: &adminPermission,
+			},
 		},
 	}
+}
+
+// lookupCommand finds the definition of the named command
+func lookupCommand(name string) (commandDefinition, bool) {
+	for _, cmd := range slashCommands() {
+		if cmd.Name == name {
+			return cmd, true
+		}
+	}
+	return commandDefinition{}, false
+}
+
+// isMutatingCommand reports whether the named command changes bot state
+func isMutatingCommand(name string) bool {
+	cmd, _ := lookupCommand(name)
+	return cmd.Mutating
+}
+
+// registrableCommands returns the slash commands this configuration offers
+func (sm *SystemMonitor) registrableCommands() []*discordgo.ApplicationCommand {
+	var commands []*discordgo.ApplicationCommand
+	for _, cmd := range slashCommands() {
+		if sm.config.Discord.ReadOnly && cmd.Mutating {
+			logger.Info("Read-only mode - skipping mutating command:", cmd.Name)
+			continue
+		}
+		if cmd.Name == "fleet" && len(sm.config.Fleet.Peers) == 0 {
+			logger.Info("No peers configured - skipping command:", cmd.Name)
+			continue
+		}
+		commands = append(commands, cmd.ApplicationCommand)
+	}
+	return commands
+}
 
+func (sm *SystemMonitor) registerSlashCommands(s *discordgo.Session) {
+	logger.Info("Starting slash command registration...")
+
+	// Each guild is registered by onGuildCreate as it becomes available
+	if sm.config.Discord.PerGuildCommands {
+		logger.Info("Per-guild commands - registering as guilds become available")
+		return
+	}
+
+	commands := sm.registrableCommands()
 	logger.Info("Registering", len(commands), "slash commands")
 	guildID := sm.config.Discord.GuildID
 	logger.Info("Target guild ID:", guildID)
@@ -66,6 +462,7 @@ func (sm *SystemMonitor) registerSlashCommands(s *discordgo.Session) {
 		_, err := s.ApplicationCommandCreate(s.State.User.ID, guildID, cmd)
 		if err != nil {
 			logger.Error("Failed to register command", cmd.Name, "error:", err)
+			metrics.DiscordAPIErrors.Inc()
 			errorCount++
 		} else {
 			logger.Info("Successfully registered command:", cmd.Name)
@@ -76,38 +473,62 @@ func (sm *SystemMonitor) registerSlashCommands(s *discordgo.Session) {
 	logger.Info("Command registration complete. Success:", successCount, "Errors:", errorCount)
 }
 
-func (sm *SystemMonitor) handleTemperatureCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (sm *SystemMonitor) handleTemperatureCommand(s discordSession, i *discordgo.InteractionCreate) {
 	logger.Info("Handling temperature command for user:", i.Member.User.Username)
 
-	logger.Info("Sending deferred response...")
-	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
-	})
-	if err != nil {
-		logger.Error("Failed to send deferred response:", err)
+	raw, ok := sm.debugCapture(s, i)
+	if !ok {
 		return
 	}
+	if !sm.deferResponse(s, i, discordgo.InteractionResponseDeferredChannelMessageWithSource, 0) {
+		return
+	}
+
+	compact := false
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "compact" {
+			compact = opt.BoolValue()
+			logger.Info("Compact layout parameter:", compact)
+		}
+	}
 
 	logger.Info("Getting temperature sensors...")
-	sensors, err := sm.tempMonitor.GetSensors()
+	sensors, err := sm.tempMonitor.GetSensorsRaw(raw)
 	if err != nil {
 		logger.Error("Failed to get temperature sensors:", err)
-		sm.sendError(s, i, "Failed to read temperature sensors", err)
+		switch {
+		case errors.Is(err, monitor.ErrNoSensorChips):
+			sm.sendError(s, i, "No sensor chips detected - lm-sensors has not been configured", err)
+		case errors.Is(err, monitor.ErrSensorsNotInstalled):
+			sm.sendError(s, i, "lm-sensors is not installed", err)
+		default:
+			sm.sendError(s, i, "Failed to read temperature sensors", err)
+		}
 		return
 	}
 
 	if len(sensors) == 0 {
 		logger.Warn("No temperature sensors found")
-		sm.sendError(s, i, "No temperature sensors found", fmt.Errorf("ensure lm-sensors is installed and configured"))
+		sm.sendError(s, i, "No temperature sensors found", fmt.Errorf("sensor chips were found but none report temperatures"))
 		return
 	}
 
+	sm.trends.annotate(sensors)
+
 	logger.Info("Building temperature embed for", len(sensors), "sensors")
-	embed := sm.embedBuilder.BuildTemperature(sensors)
+	embed := sm.embedBuilder.BuildTemperature(sensors, compact)
+	sm.addTopProcess(embed, sensors)
+
+	refreshKind := "temp"
+	if compact {
+		refreshKind = "temp:compact"
+	}
 
 	logger.Info("Sending temperature response...")
 	_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
-		Embeds: []*discordgo.MessageEmbed{embed},
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: refreshComponents(refreshKind),
+		Files:      rawOutputFiles("temp", raw),
 	})
 	if err != nil {
 		logger.Error("Failed to send temperature response:", err)
@@ -116,26 +537,151 @@ func (sm *SystemMonitor) handleTemperatureCommand(s *discordgo.Session, i *disco
 	}
 }
 
-func (sm *SystemMonitor) handlePortsCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	logger.Info("Handling ports command for user:", i.Member.User.Username)
+// addTopProcess adds the top CPU-consuming process to a temperature embed
+// when DISPLAY_TOP_PROCESS is set; the temperatures are still shown if top
+// fails
+func (sm *SystemMonitor) addTopProcess(embed *discordgo.MessageEmbed, sensors []monitor.TemperatureSensor) {
+	if !sm.config.Display.ShowTopProcess {
+		return
+	}
+	processes, err := sm.memMonitor.GetTopProcessesBy(monitor.SortByCPU, 1, nil)
+	if err != nil || len(processes) == 0 {
+		logger.Warn("Top CPU process unavailable for temperature embed:", err)
+		return
+	}
+	sm.embedBuilder.AddTopProcess(embed, sensors, processes[0])
+}
+
+// memoryTotals reads system memory usage and rescales the processes' %MEM
+// to it, so the memory embed can account for the rest as other processes.
+// It returns nil, leaving top's figures, when usage cannot be read or the
+// processes come from a remote host.
+func (sm *SystemMonitor) memoryTotals(processes []monitor.ProcessMemory) *monitor.MemoryUsage {
+	if monitor.IsRemote() {
+		return nil
+	}
+	usage, err := sm.sysInfo.GetMemoryUsage()
+	if err != nil {
+		logger.Warn("Memory usage unavailable - keeping top's percentages:", err)
+		return nil
+	}
+	monitor.ResidentPercent(processes, usage.TotalBytes)
+	return usage
+}
+
+func (sm *SystemMonitor) handleDiskTempCommand(s discordSession, i *discordgo.InteractionCreate) {
+	logger.Info("Handling disktemp command for user:", i.Member.User.Username)
+
+	if !sm.deferResponse(s, i, discordgo.InteractionResponseDeferredChannelMessageWithSource, 0) {
+		return
+	}
+
+	sensors, err := sm.tempMonitor.GetSensors()
+	if err != nil {
+		logger.Error("Failed to get temperature sensors:", err)
+		sm.sendError(s, i, "Failed to read temperature sensors", err)
+		return
+	}
+
+	storage := monitor.StorageSensors(sensors)
+	if len(storage) == 0 {
+		logger.Warn("No storage temperature sensors found among", len(sensors), "sensors")
+		sm.sendError(s, i, "No storage temperature sensors found", fmt.Errorf("load the nvme or drivetemp kernel module to expose drive temperatures"))
+		return
+	}
+
+	sm.trends.annotate(storage)
+
+	logger.Info("Building disk temperature embed for", len(storage), "sensors")
+	embed := sm.embedBuilder.BuildDiskTemperature(storage)
+
+	_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: refreshComponents("disktemp"),
+	})
+	if err != nil {
+		logger.Error("Failed to send disktemp response:", err)
+	} else {
+		logger.Info("Disktemp command completed successfully for user:", i.Member.User.Username)
+	}
+}
+
+func (sm *SystemMonitor) handleSensorsCommand(s discordSession, i *discordgo.InteractionCreate) {
+	logger.Info("Handling sensors command for user:", i.Member.User.Username)
+
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 || options[0].Name != "list" {
+		logger.Warn("Unknown sensors subcommand from user:", i.Member.User.Username)
+		return
+	}
+
+	if !sm.deferResponse(s, i, discordgo.InteractionResponseDeferredChannelMessageWithSource, 0) {
+		return
+	}
+
+	sensors, err := sm.tempMonitor.GetSensors()
+	if err != nil {
+		logger.Error("Failed to get temperature sensors:", err)
+		sm.sendError(s, i, "Failed to read temperature sensors", err)
+		return
+	}
+	if len(sensors) == 0 {
+		logger.Warn("No temperature sensors found")
+		sm.sendError(s, i, "No temperature sensors found", fmt.Errorf("sensor chips were found but none report temperatures"))
+		return
+	}
 
-	logger.Info("Sending deferred response...")
-	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	embed, totalPages := sm.embedBuilder.BuildSensorList(sensors, 0)
+	_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: sensorPageComponents(0, totalPages),
 	})
 	if err != nil {
-		logger.Error("Failed to send deferred response:", err)
+		logger.Error("Failed to send sensors response:", err)
+	} else {
+		logger.Info("Sensors command completed successfully for user:", i.Member.User.Username)
+	}
+}
+
+func (sm *SystemMonitor) handlePortsCommand(s discordSession, i *discordgo.InteractionCreate) {
+	logger.Info("Handling ports command for user:", i.Member.User.Username)
+
+	raw, ok := sm.debugCapture(s, i)
+	if !ok {
 		return
 	}
 
-	showAll := false
-	if len(i.ApplicationCommandData().Options) > 0 {
-		showAll = i.ApplicationCommandData().Options[0].BoolValue()
-		logger.Info("Show all connections parameter:", showAll)
+	var query portsQuery
+	for _, opt := range i.ApplicationCommandData().Options {
+		switch opt.Name {
+		case "all":
+			query.showAll = opt.BoolValue()
+			logger.Info("Show all connections parameter:", query.showAll)
+		case "connections":
+			query.withConns = opt.BoolValue()
+			logger.Info("Show connection counts parameter:", query.withConns)
+		case "unix":
+			query.withUnix = opt.BoolValue()
+			logger.Info("Show Unix sockets parameter:", query.withUnix)
+		case "port":
+			port, err := validateIntOption(opt, minPortNumber, maxPortNumber)
+			if err != nil {
+				sm.respondInvalidOption(s, i, err)
+				return
+			}
+			query.filter.Port = port
+			logger.Info("Port filter parameter:", query.filter.Port)
+		case "process":
+			query.filter.Process = strings.TrimSpace(opt.StringValue())
+			logger.Info("Process filter parameter:", query.filter.Process)
+		}
+	}
+
+	if !sm.deferResponse(s, i, discordgo.InteractionResponseDeferredChannelMessageWithSource, 0) {
+		return
 	}
 
-	logger.Info("Getting network ports with showAll:", showAll)
-	ports, err := sm.netMonitor.GetPorts(showAll)
+	ports, err := sm.readPorts(query, raw)
 	if err != nil {
 		logger.Error("Failed to get network ports:", err)
 		sm.sendError(s, i, "Failed to read network ports", err)
@@ -146,6 +692,7 @@ func (sm *SystemMonitor) handlePortsCommand(s *discordgo.Session, i *discordgo.I
 		logger.Info("No network ports found")
 		_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
 			Content: "🔍 No network ports found",
+			Files:   rawOutputFiles("ports", raw),
 		})
 		if err != nil {
 			logger.Error("Failed to send no ports response:", err)
@@ -154,33 +701,211 @@ func (sm *SystemMonitor) handlePortsCommand(s *discordgo.Session, i *discordgo.I
 	}
 
 	logger.Info("Building ports embed for", len(ports), "ports")
-	embed := sm.embedBuilder.BuildPorts(ports, showAll)
+	pages := sm.embedBuilder.BuildPortPages(ports, query.showAll, query.filter)
+	refreshKind := query.kind()
+
+	logger.Info("Sending ports response in", len(pages), "messages...")
+	for n, page := range pages {
+		params := &discordgo.WebhookParams{Embeds: []*discordgo.MessageEmbed{page}}
+		// Refreshing rebuilds the first page only, so only it gets the button
+		if n == 0 {
+			params.Components = refreshComponents(refreshKind)
+			params.Files = rawOutputFiles("ports", raw)
+		}
+		if _, err = s.FollowupMessageCreate(i.Interaction, false, params); err != nil {
+			logger.Error("Failed to send ports response page", n+1, "of", len(pages), "error:", err)
+			return
+		}
+	}
+	logger.Info("Ports command completed successfully for user:", i.Member.User.Username)
+}
 
-	logger.Info("Sending ports response...")
+// portsQuery is what a /ports invocation asked for, carried in the refresh
+// button as e.g. "ports:listening:conns:unix:port=443:proc=nginx"
+type portsQuery struct {
+	showAll   bool
+	withConns bool
+	withUnix  bool
+	filter    embed.PortFilter
+}
+
+// kind encodes the query as a refresh kind; the process filter goes last as
+// it may itself contain colons
+func (q portsQuery) kind() string {
+	kind := "ports:listening"
+	if q.showAll {
+		kind = "ports:all"
+	}
+	if q.withConns {
+		kind += ":conns"
+	}
+	if q.withUnix {
+		kind += ":unix"
+	}
+	if q.filter.Port != 0 {
+		kind += fmt.Sprintf(":port=%d", q.filter.Port)
+	}
+	if q.filter.Process != "" {
+		kind += ":proc=" + q.filter.Process
+	}
+	return kind
+}
+
+// parsePortsKind decodes a ports refresh kind, reporting false for any
+// other kind
+func parsePortsKind(kind string) (portsQuery, bool) {
+	var q portsQuery
+	rest, found := strings.CutPrefix(kind, "ports:")
+	if !found {
+		return q, false
+	}
+	rest, q.filter.Process, _ = strings.Cut(rest, ":proc=")
+
+	parts := strings.Split(rest, ":")
+	switch parts[0] {
+	case "all":
+		q.showAll = true
+	case "listening":
+	default:
+		return q, false
+	}
+	for _, part := range parts[1:] {
+		switch part {
+		case "conns":
+			q.withConns = true
+			continue
+		case "unix":
+			q.withUnix = true
+			continue
+		}
+		value, found := strings.CutPrefix(part, "port=")
+		port, err := strconv.Atoi(value)
+		if !found || err != nil {
+			return q, false
+		}
+		q.filter.Port = port
+	}
+	return q, true
+}
+
+// readPorts reads the network ports the query asks for: listening Unix
+// sockets are added with withUnix, and withConns fills in how many
+// established connections each listening TCP port currently has. The
+// command output is recorded into raw when it is not nil.
+func (sm *SystemMonitor) readPorts(query portsQuery, raw *monitor.RawOutput) ([]monitor.NetworkPort, error) {
+	logger.Info("Getting network ports with showAll:", query.showAll)
+	ports, err := sm.netMonitor.GetPortsRaw(query.showAll, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.withUnix {
+		logger.Info("Getting listening Unix sockets...")
+		sockets, err := sm.netMonitor.GetUnixSocketsRaw(raw)
+		if err != nil {
+			return nil, err
+		}
+		ports = append(ports, sockets...)
+	}
+	if !query.withConns {
+		return ports, nil
+	}
+
+	logger.Info("Getting established connections by local port...")
+	counts, err := sm.netMonitor.GetConnectionsByLocalPortRaw(raw)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range ports {
+		if ports[idx].Protocol == "TCP" && ports[idx].State == "LISTEN" {
+			ports[idx].Established = counts[ports[idx].Port]
+		}
+	}
+	return ports, nil
+}
+
+func (sm *SystemMonitor) handleConnectionsCommand(s discordSession, i *discordgo.InteractionCreate) {
+	logger.Info("Handling connections command for user:", i.Member.User.Username)
+
+	limit := defaultConnectionsLimit
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "limit" {
+			var err error
+			if limit, err = validateIntOption(opt, minConnectionsLimit, maxConnectionsLimit); err != nil {
+				sm.respondInvalidOption(s, i, err)
+				return
+			}
+			logger.Info("Connections limit parameter:", limit)
+		}
+	}
+
+	if !sm.deferResponse(s, i, discordgo.InteractionResponseDeferredChannelMessageWithSource, 0) {
+		return
+	}
+
+	logger.Info("Getting connections by remote address...")
+	remotes, err := sm.netMonitor.GetConnectionsByRemote()
+	if err != nil {
+		logger.Error("Failed to get connections:", err)
+		sm.sendError(s, i, "Failed to read network connections", err)
+		return
+	}
+
+	if len(remotes) == 0 {
+		logger.Info("No established connections found")
+		_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+			Content: "🔍 No established connections found",
+		})
+		if err != nil {
+			logger.Error("Failed to send no connections response:", err)
+		}
+		return
+	}
+
+	logger.Info("Building connections embed for", len(remotes), "remotes")
+	embed := sm.embedBuilder.BuildConnections(remotes, limit)
+
+	logger.Info("Sending connections response...")
 	_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
 		Embeds: []*discordgo.MessageEmbed{embed},
 	})
 	if err != nil {
-		logger.Error("Failed to send ports response:", err)
+		logger.Error("Failed to send connections response:", err)
 	} else {
-		logger.Info("Ports command completed successfully for user:", i.Member.User.Username)
+		logger.Info("Connections command completed successfully for user:", i.Member.User.Username)
 	}
 }
 
-func (sm *SystemMonitor) handleMemoryCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (sm *SystemMonitor) handleMemoryCommand(s discordSession, i *discordgo.InteractionCreate) {
 	logger.Info("Handling memory command for user:", i.Member.User.Username)
 
-	logger.Info("Sending deferred response...")
-	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
-	})
-	if err != nil {
-		logger.Error("Failed to send deferred response:", err)
+	raw, ok := sm.debugCapture(s, i)
+	if !ok {
+		return
+	}
+
+	query := memoryQuery{count: sm.config.Display.Limits.MemoryTopN}
+	for _, opt := range i.ApplicationCommandData().Options {
+		switch opt.Name {
+		case "sort":
+			query.byResident = opt.StringValue() == "rss"
+			logger.Info("Sort by resident memory parameter:", query.byResident)
+		case "count":
+			var err error
+			if query.count, err = validateIntOption(opt, minMemoryCount, maxMemoryCount); err != nil {
+				sm.respondInvalidOption(s, i, err)
+				return
+			}
+			logger.Info("Process count parameter:", query.count)
+		}
+	}
+
+	if !sm.deferResponse(s, i, discordgo.InteractionResponseDeferredChannelMessageWithSource, 0) {
 		return
 	}
 
 	logger.Info("Getting memory usage data...")
-	processes, err := sm.memMonitor.GetTopProcesses()
+	processes, err := sm.memMonitor.GetTopProcessesRaw(query.count, raw)
 	if err != nil {
 		logger.Error("Failed to get memory usage:", err)
 		sm.sendError(s, i, "Failed to read memory usage", err)
@@ -191,6 +916,7 @@ func (sm *SystemMonitor) handleMemoryCommand(s *discordgo.Session, i *discordgo.
 		logger.Warn("No processes found")
 		_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
 			Content: "🔍 No processes found with memory usage",
+			Files:   rawOutputFiles("memory", raw),
 		})
 		if err != nil {
 			logger.Error("Failed to send no processes response:", err)
@@ -199,11 +925,13 @@ func (sm *SystemMonitor) handleMemoryCommand(s *discordgo.Session, i *discordgo.
 	}
 
 	logger.Info("Building memory embed for", len(processes), "processes")
-	embed := sm.embedBuilder.BuildMemory(processes)
+	embed := sm.embedBuilder.BuildMemory(processes, query.byResident, sm.memoryTotals(processes))
 
 	logger.Info("Sending memory response...")
 	_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
-		Embeds: []*discordgo.MessageEmbed{embed},
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: refreshComponents(query.kind()),
+		Files:      rawOutputFiles("memory", raw),
 	})
 	if err != nil {
 		logger.Error("Failed to send memory response:", err)
@@ -212,36 +940,230 @@ func (sm *SystemMonitor) handleMemoryCommand(s *discordgo.Session, i *discordgo.
 	}
 }
 
-func (sm *SystemMonitor) handleAlertsCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	logger.Info("Handling alerts command for user:", i.Member.User.Username)
-
-	action := i.ApplicationCommandData().Options[0].StringValue()
-	channelID := i.ChannelID
+// memoryQuery is what /memory lists: count processes, ordered by resident
+// size with byResident
+type memoryQuery struct {
+	byResident bool
+	count      int
+}
+
+// kind encodes the query as a refresh kind, e.g. "memory:rss:15"
+func (q memoryQuery) kind() string {
+	kind := "memory"
+	if q.byResident {
+		kind += ":rss"
+	}
+	return kind + ":" + strconv.Itoa(q.count)
+}
+
+// parseMemoryKind decodes a memory refresh kind, reporting false for any
+// other kind. Buttons from before the count was encoded list defaultCount
+// processes.
+func parseMemoryKind(kind string, defaultCount int) (memoryQuery, bool) {
+	q := memoryQuery{count: defaultCount}
+	rest, found := strings.CutPrefix(kind, "memory")
+	if !found {
+		return q, false
+	}
+	if rest, q.byResident = strings.CutPrefix(rest, ":rss"); rest == "" {
+		return q, true
+	}
+	value, found := strings.CutPrefix(rest, ":")
+	count, err := strconv.Atoi(value)
+	if !found || err != nil || count < int(minMemoryCount) || count > int(maxMemoryCount) {
+		return q, false
+	}
+	q.count = count
+	return q, true
+}
+
+func (sm *SystemMonitor) handleTopCommand(s discordSession, i *discordgo.InteractionCreate) {
+	logger.Info("Handling top command for user:", i.Member.User.Username)
+
+	if !sm.deferResponse(s, i, discordgo.InteractionResponseDeferredChannelMessageWithSource, 0) {
+		return
+	}
+
+	sortColumn := monitor.SortByCPU
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "sort" && opt.StringValue() == "memory" {
+			sortColumn = monitor.SortByMemory
+		}
+	}
+	logger.Info("Sort column parameter:", sortColumn)
+
+	processes, err := sm.memMonitor.GetTopProcessesBy(sortColumn, sm.config.Display.Limits.MaxProcesses, nil)
+	if err != nil {
+		logger.Error("Failed to get top processes:", err)
+		sm.sendError(s, i, "Failed to read processes", err)
+		return
+	}
+
+	refreshKind := "top:cpu"
+	if sortColumn == monitor.SortByMemory {
+		refreshKind = "top:memory"
+	}
+
+	_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Embeds:     []*discordgo.MessageEmbed{sm.embedBuilder.BuildTop(processes, sortColumn)},
+		Components: refreshComponents(refreshKind),
+	})
+	if err != nil {
+		logger.Error("Failed to send top response:", err)
+	} else {
+		logger.Info("Top command completed successfully for user:", i.Member.User.Username)
+	}
+}
+
+func (sm *SystemMonitor) handleCPUCommand(s discordSession, i *discordgo.InteractionCreate) {
+	logger.Info("Handling cpu command for user:", i.Member.User.Username)
+
+	if !sm.deferResponse(s, i, discordgo.InteractionResponseDeferredChannelMessageWithSource, 0) {
+		return
+	}
+
+	detailed := false
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "detailed" {
+			detailed = opt.BoolValue()
+			logger.Info("Detailed CPU view parameter:", detailed)
+		}
+	}
+
+	usage, err := sm.cpuMonitor.GetUsage()
+	if err != nil {
+		logger.Error("Failed to get CPU usage:", err)
+		sm.sendError(s, i, "Failed to read CPU usage", err)
+		return
+	}
+
+	var embed *discordgo.MessageEmbed
+	if detailed {
+		sensors, err := sm.tempMonitor.GetSensors()
+		if err != nil {
+			// Load is still worth showing without temperatures
+			logger.Warn("Could not read temperatures for detailed CPU view:", err)
+		}
+		embed = sm.embedBuilder.BuildCPUDetailed(usage, sensors)
+	} else {
+		embed = sm.embedBuilder.BuildCPU(usage)
+	}
+
+	logger.Info("Sending cpu response...")
+	_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{embed},
+	})
+	if err != nil {
+		logger.Error("Failed to send cpu response:", err)
+	} else {
+		logger.Info("CPU command completed successfully for user:", i.Member.User.Username)
+	}
+}
+
+func (sm *SystemMonitor) handleAlertsCommand(s discordSession, i *discordgo.InteractionCreate) {
+	logger.Info("Handling alerts command for user:", i.Member.User.Username)
+
+	action := i.ApplicationCommandData().Options[0].StringValue()
+	channelID := i.ChannelID
 
 	logger.Info("Alert action:", action, "for channel:", channelID)
 
 	var response string
-	if action == "enable" {
+	if action == "silence" || action == "unsilence" {
+		response = sm.handleSilenceAction(action, i.ApplicationCommandData().Options)
+	} else if action == "snooze" {
+		minutes := defaultSnoozeMinutes
+		for _, opt := range i.ApplicationCommandData().Options {
+			if opt.Name == "duration" {
+				var err error
+				if minutes, err = validateIntOption(opt, minSnoozeMinutes, maxSnoozeMinutes); err != nil {
+					sm.respondInvalidOption(s, i, err)
+					return
+				}
+			}
+		}
+		if !sm.isAlertChannel(channelID) {
+			logger.Info("Snooze requested for channel without alerts:", channelID)
+			response = "ℹ️ Temperature alerts are not enabled for this channel - nothing to snooze."
+		} else {
+			until := sm.snoozeAlerts(channelID, time.Duration(minutes)*time.Minute)
+			response = fmt.Sprintf("😴 **Temperature alerts snoozed** for this channel for %d minutes.\n\n"+
+				"🔔 Alerts resume automatically %s", minutes, sm.embedBuilder.FormatTime(until))
+		}
+	} else if action == "enable" {
+		if !sm.alertChannelAllowed(channelID) {
+			logger.Warn("Rejected alerts enable for channel not on the allowlist:", channelID)
+			sm.respondEphemeral(s, i, "🚫 Alerts cannot be enabled in this channel - it is not on this bot's list of allowed alert channels.")
+			return
+		}
 		logger.Info("Enabling alerts for channel:", channelID)
-		sm.alertChannels[channelID] = true
+		var alertConfig ChannelAlertConfig
+		for _, opt := range i.ApplicationCommandData().Options {
+			var err error
+			switch opt.Name {
+			case "warning":
+				alertConfig.Warning, err = validateNumberOption(opt, minChannelThreshold, maxChannelThreshold)
+			case "critical":
+				alertConfig.Critical, err = validateNumberOption(opt, minChannelThreshold, maxChannelThreshold)
+			}
+			if err != nil {
+				sm.respondInvalidOption(s, i, err)
+				return
+			}
+		}
+		if err := alertConfig.validate(sm.config.Thresholds); err != nil {
+			logger.Warn("Rejected thresholds for channel", channelID, "-", err)
+			sm.respondInvalidOption(s, i, err)
+			return
+		}
+
+		var webhookNote, threadNote string
+		for _, opt := range i.ApplicationCommandData().Options {
+			switch opt.Name {
+			case "webhook":
+				webhook, err := parseWebhookURL(opt.StringValue())
+				if err != nil {
+					logger.Warn("Rejected webhook for channel", channelID, "-", err)
+					sm.respondEphemeral(s, i, fmt.Sprintf("❌ %v", err))
+					return
+				}
+				logger.RegisterSecret(webhook.Token)
+				sm.setAlertWebhook(channelID, webhook)
+				webhookNote = "\n🪝 Webhook fallback configured"
+				logger.Info("Webhook fallback configured for channel:", channelID)
+			case "thread":
+				thread, err := startAlertThread(s, channelID, opt.StringValue())
+				if err != nil {
+					logger.Warn("Could not create alert thread in channel", channelID, "-", err)
+					sm.respondEphemeral(s, i, fmt.Sprintf("❌ %v", err))
+					return
+				}
+				sm.alertThreads.set(channelID, thread)
+				threadNote = fmt.Sprintf("\n🧵 Alerts are posted in <#%s>", thread.ID)
+			}
+		}
+		sm.enableAlertChannel(channelID, i.GuildID, alertConfig)
+		warning, critical := alertConfig.thresholds(sm.config.Thresholds)
+		thresholdNote := ""
+		if alertConfig.overrides() {
+			thresholdNote = " (this channel's own)"
+			logger.Info("Channel", channelID, "thresholds - Warning:", warning, "°C Critical:", critical, "°C")
+		}
 		response = fmt.Sprintf("✅ **Temperature alerts enabled** for this channel!\n\n"+
-			"🚨 Critical alerts: %.1f°C and above\n"+
-			"⚠️ Warning alerts: %.1f°C and above\n"+
-			"🔄 Check interval: %v",
-			sm.config.Thresholds.Critical, sm.config.Thresholds.Warning, sm.config.Monitor.Interval)
-		logger.Info("Alerts enabled successfully. Total alert channels:", len(sm.alertChannels))
+			"🚨 Critical alerts: %.1f°C and above%s\n"+
+			"⚠️ Warning alerts: %.1f°C and above%s\n"+
+			"🔄 Check interval: %v%s%s",
+			critical, thresholdNote, warning, thresholdNote, sm.config.Monitor.Interval, threadNote, webhookNote)
+		logger.Info("Alerts enabled successfully. Total alert channels:", sm.alertChannelCount())
 	} else {
 		logger.Info("Disabling alerts for channel:", channelID)
-		delete(sm.alertChannels, channelID)
+		sm.removeAlertChannel(channelID)
 		response = "❌ **Temperature alerts disabled** for this channel."
-		logger.Info("Alerts disabled successfully. Total alert channels:", len(sm.alertChannels))
+		logger.Info("Alerts disabled successfully. Total alert channels:", sm.alertChannelCount())
 	}
 
 	logger.Info("Sending alerts command response...")
-	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{Content: response},
-	})
+	err := sm.respond(s, i, &discordgo.InteractionResponseData{Content: response})
 	if err != nil {
 		logger.Error("Failed to send alerts response:", err)
 	} else {
@@ -249,7 +1171,77 @@ func (sm *SystemMonitor) handleAlertsCommand(s *discordgo.Session, i *discordgo.
 	}
 }
 
-func (sm *SystemMonitor) handleStatusCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+// alertChannelAllowed reports whether alerts may be enabled in a channel
+func (sm *SystemMonitor) alertChannelAllowed(channelID string) bool {
+	allowed := sm.config.Monitor.AllowedAlertChannels
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, id := range allowed {
+		if id == channelID {
+			return true
+		}
+	}
+	return false
+}
+
+// lastAlertField describes the last temperature alert for /status, and any
+// alert the cooldown is holding back
+func (sm *SystemMonitor) lastAlertField() *discordgo.MessageEmbedField {
+	sm.alertStateMu.Lock()
+	lastAlertTime, pendingLevel, pendingSince := sm.lastAlert, sm.pendingAlertLevel, sm.pendingAlertSince
+	sm.alertStateMu.Unlock()
+
+	lastAlert := "Never"
+	if !lastAlertTime.IsZero() {
+		lastAlert = sm.embedBuilder.FormatTime(lastAlertTime)
+	}
+	if pendingLevel != "" {
+		next := "at the next monitoring cycle"
+		if until := lastAlertTime.Add(sm.config.Monitor.AlertCooldown); until.After(time.Now()) {
+			next = sm.embedBuilder.FormatTime(until)
+		}
+		lastAlert += fmt.Sprintf("\n\n⏳ **%s** alert suppressed by cooldown since %s\nNext possible %s",
+			pendingLevel, sm.embedBuilder.FormatTime(pendingSince), next)
+	}
+	return &discordgo.MessageEmbedField{
+		Name:   "⏰ Last Alert",
+		Value:  lastAlert,
+		Inline: true,
+	}
+}
+
+// channelThresholdsField lists the alert channels with thresholds of their
+// own for /status, or returns nil when every channel uses the defaults
+func (sm *SystemMonitor) channelThresholdsField() *discordgo.MessageEmbedField {
+	overrides := sm.channelOverrides()
+	if len(overrides) == 0 {
+		return nil
+	}
+
+	channelIDs := make([]string, 0, len(overrides))
+	for channelID := range overrides {
+		channelIDs = append(channelIDs, channelID)
+	}
+	sort.Strings(channelIDs)
+
+	var lines []string
+	for idx, channelID := range channelIDs {
+		if idx == maxListedChannelThresholds {
+			lines = append(lines, fmt.Sprintf("…and %d more", len(channelIDs)-idx))
+			break
+		}
+		warning, critical := overrides[channelID].thresholds(sm.config.Thresholds)
+		lines = append(lines, fmt.Sprintf("<#%s> ⚠️ %.1f°C • 🚨 %.1f°C", channelID, warning, critical))
+	}
+	return &discordgo.MessageEmbedField{
+		Name:   "🎚️ Channel Thresholds",
+		Value:  strings.Join(lines, "\n"),
+		Inline: false,
+	}
+}
+
+func (sm *SystemMonitor) handleStatusCommand(s discordSession, i *discordgo.InteractionCreate) {
 	logger.Info("Handling status command for user:", i.Member.User.Username)
 
 	logger.Info("Building status embed...")
@@ -257,7 +1249,7 @@ func (sm *SystemMonitor) handleStatusCommand(s *discordgo.Session, i *discordgo.
 		Title:       "🖥️ System Monitor Status",
 		Description: "Real-time server monitoring with lm-sensors, network analysis, and memory tracking",
 		Color:       0x00ff00,
-		Timestamp:   time.Now().Format(time.RFC3339),
+		Timestamp:   sm.embedBuilder.Timestamp(),
 		Footer: &discordgo.MessageEmbedFooter{
 			Text: "System Monitor Bot",
 		},
@@ -272,42 +1264,146 @@ func (sm *SystemMonitor) handleStatusCommand(s *discordgo.Session, i *discordgo.
 
 	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
 		Name:   "💾 Memory Monitoring",
-		Value:  "**Interval**: 5s\n**Top Processes**: 10\n**Sort By**: %MEM\n**Auto Updates**: Enabled",
+		Value:  fmt.Sprintf("**Interval**: 5s\n**Top Processes**: %d\n**Sort By**: %%MEM\n**Auto Updates**: Enabled", sm.config.Display.Limits.MemoryTopN),
 		Inline: true,
 	})
 
 	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
 		Name:   "📢 Alert Channels",
-		Value:  fmt.Sprintf("%d channels configured", len(sm.alertChannels)),
+		Value:  fmt.Sprintf("%d channels configured\n%d posting to threads\n%d snoozed\n%d sensors silenced", sm.alertChannelCount(), sm.alertThreads.count(), sm.snoozedChannelCount(), sm.silences.count()),
 		Inline: true,
 	})
 
-	lastAlert := "Never"
-	if !sm.lastAlert.IsZero() {
-		lastAlert = fmt.Sprintf("<t:%d:R>", sm.lastAlert.Unix())
+	if field := sm.channelThresholdsField(); field != nil {
+		embed.Fields = append(embed.Fields, field)
 	}
+
 	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-		Name:   "⏰ Last Alert",
-		Value:  lastAlert,
+		Name: "📈 Delivery",
+		Value: fmt.Sprintf("%d alerts sent\n%d send failures\n%d channels removed\n%d Discord API errors",
+			metrics.AlertsSent.Value(), metrics.AlertSendFailures.Value(), metrics.ChannelsRemoved.Value(), metrics.DiscordAPIErrors.Value()),
+		Inline: true,
+	})
+
+	embed.Fields = append(embed.Fields, sm.lastAlertField())
+
+	// Add process and thread counts
+	if counts, err := sm.sysInfo.GetProcessCounts(); err != nil {
+		logger.Warn("Could not read process counts for status:", err)
+	} else {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "⚙️ Processes",
+			Value:  fmt.Sprintf("**Processes**: %d\n**Threads**: %d\n**Running**: %d", counts.Processes, counts.Threads, counts.Running),
+			Inline: true,
+		})
+	}
+
+	// Add pressure stall information; older kernels do not expose it
+	pressure := "Not supported by this kernel"
+	if pressures, err := sm.psiMonitor.GetPressure(); err == nil {
+		pressure = sm.embedBuilder.PressureSummary(pressures)
+	} else if !errors.Is(err, monitor.ErrPressureUnavailable) {
+		logger.Warn("Could not read pressure for status:", err)
+		pressure = "Unavailable"
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "📉 Pressure (10s / 60s / 5m)",
+		Value:  pressure,
 		Inline: true,
 	})
 
+	// Add current temperature status if available
+	if sensors := sm.sensorSnapshot(); len(sensors) > 0 {
+		var maxSensor monitor.TemperatureSensor
+		for _, sensor := range sensors {
+			if sensor.Temperature > maxSensor.Temperature {
+				maxSensor = sensor
+			}
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "🌡️ Hottest Sensor",
+			Value:  fmt.Sprintf("**%s**\n%.1f°C", maxSensor.Name, maxSensor.Temperature),
+			Inline: true,
+		})
+	} else {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "🌡️ Hottest Sensor",
+			Value:  "⏳ No data yet - collecting...",
+			Inline: true,
+		})
+	}
+
 	// Add current memory status if available
-	if len(sm.lastMemoryData) > 0 {
-		topProcess := sm.lastMemoryData[0]
+	processes := sm.memorySnapshot()
+	if len(processes) > 0 {
+		topProcess := processes[0]
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
 			Name:   "🔥 Top Memory Process",
 			Value:  fmt.Sprintf("**%s**\n%.1f%% memory", topProcess.Command, topProcess.MemoryPercent),
 			Inline: true,
 		})
+	} else {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "🔥 Top Memory Process",
+			Value:  "⏳ No data yet - collecting...",
+			Inline: true,
+		})
+	}
+
+	// Add the listening ports from the latest network cycle
+	if ports, ok := sm.portSnapshot(); ok {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "🔌 Listening Ports",
+			Value:  fmt.Sprintf("**%d** listening\n**Interval**: %v", len(ports), sm.config.Monitor.NetworkInterval),
+			Inline: true,
+		})
+	} else {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "🔌 Listening Ports",
+			Value:  "⏳ No data yet - collecting...",
+			Inline: true,
+		})
+	}
+
+	// Add file descriptor usage for the system and the top memory processes
+	var pids []string
+	for _, process := range processes {
+		pids = append(pids, process.PID)
+	}
+	if fdStats, err := sm.fdMonitor.GetFileDescriptorStats(pids); err != nil {
+		logger.Warn("Could not read file descriptor stats for status:", err)
+	} else {
+		fdValue := fmt.Sprintf("**System**: %d / %d (%.1f%%)", fdStats.Allocated, fdStats.Maximum, fdStats.UsagePercent())
+		commands := make(map[string]string)
+		for _, process := range processes {
+			commands[process.PID] = process.Command
+		}
+		for idx, proc := range fdStats.Processes {
+			if idx >= 5 {
+				break
+			}
+			fdValue += fmt.Sprintf("\n%s (PID %s): %d", commands[proc.PID], proc.PID, proc.Count)
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "📂 File Descriptors",
+			Value:  fdValue,
+			Inline: false,
+		})
 	}
 
+	usageValue := topCounts(metrics.CommandInvocations.Snapshot(), 5, "/")
+	if sm.config.Discord.TrackCommandUsers {
+		usageValue += "\n**Top users**\n" + topCounts(sm.commandUsers.Snapshot(), 3, "")
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "📊 Command Usage",
+		Value:  usageValue,
+		Inline: false,
+	})
+
 	logger.Info("Sending status response...")
-	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Embeds: []*discordgo.MessageEmbed{embed},
-		},
+	err := sm.respond(s, i, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{embed},
 	})
 	if err != nil {
 		logger.Error("Failed to send status response:", err)
@@ -315,3 +1411,339 @@ func (sm *SystemMonitor) handleStatusCommand(s *discordgo.Session, i *discordgo.
 		logger.Info("Status command completed successfully for user:", i.Member.User.Username)
 	}
 }
+
+// topCounts lists the n largest counts as "prefix+name: count" lines
+func topCounts(counts map[string]uint64, n int, prefix string) string {
+	if len(counts) == 0 {
+		return "No commands used yet"
+	}
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	var lines []string
+	for idx, name := range names {
+		if idx >= n {
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%s%s: %d", prefix, name, counts[name]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// requiredPermission is a channel permission the bot needs to post alerts
+type requiredPermission struct {
+	Name string
+	Bit  int64
+}
+
+var requiredPermissions = []requiredPermission{
+	{Name: "View Channel", Bit: discordgo.PermissionViewChannel},
+	{Name: "Send Messages", Bit: discordgo.PermissionSendMessages},
+	{Name: "Embed Links", Bit: discordgo.PermissionEmbedLinks},
+}
+
+func (sm *SystemMonitor) handleDiagnosticsCommand(s discordSession, i *discordgo.InteractionCreate) {
+	logger.Info("Handling diagnostics command for user:", i.Member.User.Username)
+
+	channelID := i.ChannelID
+	logger.Info("Checking bot permissions in channel:", channelID)
+
+	permissions, source, err := sm.botPermissions(s, channelID)
+	if err != nil {
+		// Fall back to the permissions Discord attaches to the interaction
+		logger.Warn("Could not compute permissions:", err, "- using interaction app permissions")
+		permissions = i.AppPermissions
+		source = "interaction"
+	}
+	logger.Info("Bot permissions in channel", channelID, ":", permissions, "source:", source)
+
+	var granted, missing []string
+	for _, perm := range requiredPermissions {
+		if permissions&perm.Bit == perm.Bit {
+			granted = append(granted, perm.Name)
+		} else {
+			missing = append(missing, perm.Name)
+		}
+	}
+
+	var response strings.Builder
+	response.WriteString("🩺 **Channel Diagnostics**\n\n")
+	for _, name := range granted {
+		response.WriteString(fmt.Sprintf("✅ %s\n", name))
+	}
+	for _, name := range missing {
+		response.WriteString(fmt.Sprintf("❌ %s\n", name))
+	}
+
+	if len(missing) > 0 {
+		logger.Warn("Bot is missing permissions in channel", channelID, ":", strings.Join(missing, ", "))
+		response.WriteString(fmt.Sprintf("\n⚠️ **Missing**: %s\nAlerts cannot be delivered to this channel until these are granted.", strings.Join(missing, ", ")))
+	} else {
+		response.WriteString("\n👍 All required permissions are granted.")
+	}
+
+	alertsEnabled := "disabled"
+	if sm.isAlertChannel(channelID) {
+		alertsEnabled = "enabled"
+	}
+	response.WriteString(fmt.Sprintf("\n📢 Alerts are **%s** for this channel.", alertsEnabled))
+
+	logger.Info("Sending diagnostics response...")
+	err = sm.respond(s, i, &discordgo.InteractionResponseData{
+		Content: response.String(),
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+	if err != nil {
+		logger.Error("Failed to send diagnostics response:", err)
+	} else {
+		logger.Info("Diagnostics command completed successfully for user:", i.Member.User.Username)
+	}
+}
+
+func (sm *SystemMonitor) handleDiskCommand(s discordSession, i *discordgo.InteractionCreate) {
+	logger.Info("Handling disk command for user:", i.Member.User.Username)
+
+	all := false
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "all" {
+			all = opt.BoolValue()
+			logger.Info("All filesystems parameter:", all)
+		}
+	}
+
+	if !sm.deferResponse(s, i, discordgo.InteractionResponseDeferredChannelMessageWithSource, 0) {
+		return
+	}
+
+	logger.Info("Getting filesystem usage...")
+	disks, err := sm.diskMonitor.GetFilesystems(all)
+	if err != nil {
+		logger.Error("Failed to get filesystem usage:", err)
+		sm.sendError(s, i, "Failed to read filesystem usage", err)
+		return
+	}
+
+	if len(disks) == 0 {
+		logger.Info("No filesystems found")
+		_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+			Content: "💽 No filesystems found",
+		})
+		if err != nil {
+			logger.Error("Failed to send no filesystems response:", err)
+		}
+		return
+	}
+
+	logger.Info("Building disk embed for", len(disks), "filesystems")
+	embed := sm.embedBuilder.BuildDisk(disks)
+
+	refreshKind := "disk"
+	if all {
+		refreshKind = "disk:all"
+	}
+
+	logger.Info("Sending disk response...")
+	_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: refreshComponents(refreshKind),
+	})
+	if err != nil {
+		logger.Error("Failed to send disk response:", err)
+	} else {
+		logger.Info("Disk command completed successfully for user:", i.Member.User.Username)
+	}
+}
+
+func (sm *SystemMonitor) handlePowerCommand(s discordSession, i *discordgo.InteractionCreate) {
+	logger.Info("Handling power command for user:", i.Member.User.Username)
+
+	if !sm.deferResponse(s, i, discordgo.InteractionResponseDeferredChannelMessageWithSource, 0) {
+		return
+	}
+
+	logger.Info("Getting power supplies...")
+	supplies, err := sm.powerMonitor.GetPowerSupplies()
+	if err != nil {
+		logger.Error("Failed to get power supplies:", err)
+		sm.sendError(s, i, "Failed to read power supplies", err)
+		return
+	}
+
+	if len(supplies) == 0 {
+		logger.Info("No power supplies found")
+		_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+			Content: "🔌 No battery or power supply information available on this system",
+		})
+		if err != nil {
+			logger.Error("Failed to send no power supplies response:", err)
+		}
+		return
+	}
+
+	logger.Info("Building power embed for", len(supplies), "supplies")
+	embed := sm.embedBuilder.BuildPower(supplies)
+
+	logger.Info("Sending power response...")
+	_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{embed},
+	})
+	if err != nil {
+		logger.Error("Failed to send power response:", err)
+	} else {
+		logger.Info("Power command completed successfully for user:", i.Member.User.Username)
+	}
+}
+
+// isAdmin reports whether the invoking member has administrator permission
+func isAdmin(i *discordgo.InteractionCreate) bool {
+	return i.Member != nil && i.Member.Permissions&discordgo.PermissionAdministrator != 0
+}
+
+// debugCapture reads the debug option and returns where to record raw
+// command output, nil when debug is off. Non-admins asking for it are told
+// the option is restricted and ok is false.
+func (sm *SystemMonitor) debugCapture(s discordSession, i *discordgo.InteractionCreate) (raw *monitor.RawOutput, ok bool) {
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name != "debug" || !opt.BoolValue() {
+			continue
+		}
+		if !isAdmin(i) {
+			logger.Warn("Non-admin user requested raw debug output:", i.Member.User.Username)
+			sm.respondEphemeral(s, i, "🔒 The `debug` option is restricted to server administrators.")
+			return nil, false
+		}
+		logger.Info("Debug parameter set - attaching raw command output")
+		return &monitor.RawOutput{}, true
+	}
+	return nil, true
+}
+
+// rawOutputFiles attaches the recorded command output as <name>-raw.txt,
+// or nothing when debug output was not requested
+func rawOutputFiles(name string, raw *monitor.RawOutput) []*discordgo.File {
+	if raw.Empty() {
+		return nil
+	}
+	return []*discordgo.File{
+		{
+			Name:        name + "-raw.txt",
+			ContentType: "text/plain",
+			Reader:      strings.NewReader(raw.String()),
+		},
+	}
+}
+
+// respondAdminOnly tells a non-admin that the command is restricted
+func (sm *SystemMonitor) respondAdminOnly(s discordSession, i *discordgo.InteractionCreate) {
+	sm.respondEphemeral(s, i, "🔒 This command is restricted to server administrators.")
+}
+
+// respondEphemeral answers an interaction with a message only the caller sees
+func (sm *SystemMonitor) respondEphemeral(s discordSession, i *discordgo.InteractionCreate, content string) {
+	err := sm.respond(s, i, &discordgo.InteractionResponseData{
+		Content: content,
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+	if err != nil {
+		logger.Error("Failed to send ephemeral response:", err)
+	}
+}
+
+func (sm *SystemMonitor) handleConfigCommand(s discordSession, i *discordgo.InteractionCreate) {
+	logger.Info("Handling config command for user:", i.Member.User.Username)
+
+	if !isAdmin(i) {
+		logger.Warn("Non-admin user attempted to read configuration:", i.Member.User.Username)
+		sm.respondAdminOnly(s, i)
+		return
+	}
+
+	err := sm.respond(s, i, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{sm.embedBuilder.BuildConfig(sm.config)},
+		Flags:  discordgo.MessageFlagsEphemeral,
+	})
+	if err != nil {
+		logger.Error("Failed to send config response:", err)
+	} else {
+		logger.Info("Config command completed successfully for user:", i.Member.User.Username)
+	}
+}
+
+func (sm *SystemMonitor) handleResetHistoryCommand(s discordSession, i *discordgo.InteractionCreate) {
+	logger.Info("Handling reset-history command for user:", i.Member.User.Username)
+
+	if !isAdmin(i) {
+		logger.Warn("Non-admin user attempted to reset history:", i.Member.User.Username)
+		sm.respondAdminOnly(s, i)
+		return
+	}
+
+	sensors := sm.trends.reset()
+	filesystems := sm.resetDiskHistory()
+	logger.Info("History reset - cleared trends for", sensors, "sensors and usage samples for", filesystems, "filesystems")
+
+	sm.respondEphemeral(s, i, fmt.Sprintf("🧹 **History cleared**\n\n"+
+		"🌡️ Temperature trends: %d sensors\n"+
+		"💽 Disk usage samples: %d filesystems\n\n"+
+		"Trends and fill projections rebuild from the next monitoring cycles.", sensors, filesystems))
+}
+
+func (sm *SystemMonitor) handleLogsCommand(s discordSession, i *discordgo.InteractionCreate) {
+	logger.Info("Handling logs command for user:", i.Member.User.Username)
+
+	if !isAdmin(i) {
+		logger.Warn("Non-admin user attempted to read logs:", i.Member.User.Username)
+		sm.respondAdminOnly(s, i)
+		return
+	}
+
+	lines := defaultLogLines
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "lines" {
+			var err error
+			if lines, err = validateIntOption(opt, minLogLines, float64(logger.RecentCapacity)); err != nil {
+				sm.respondInvalidOption(s, i, err)
+				return
+			}
+		}
+	}
+
+	logger.Info("Collecting", lines, "recent log lines")
+	recent := logger.Recent(lines)
+	content := strings.Join(recent, "\n")
+
+	data := &discordgo.InteractionResponseData{
+		Flags: discordgo.MessageFlagsEphemeral,
+	}
+	header := fmt.Sprintf("📜 **Last %d log lines**", len(recent))
+	if len(content)+len(header)+10 <= 2000 {
+		data.Content = fmt.Sprintf("%s\n```\n%s\n```", header, content)
+	} else {
+		logger.Info("Log output too long for a message - attaching as file")
+		data.Content = header
+		data.Files = []*discordgo.File{
+			{
+				Name:        "system-monitor-bot.log",
+				ContentType: "text/plain",
+				Reader:      strings.NewReader(content),
+			},
+		}
+	}
+
+	logger.Info("Sending logs response...")
+	err := sm.respond(s, i, data)
+	if err != nil {
+		logger.Error("Failed to send logs response:", err)
+	} else {
+		logger.Info("Logs command completed successfully for user:", i.Member.User.Username)
+	}
+}
@@ -0,0 +1,151 @@
+package bot
+
+import (
+	"errors"
+	"strings"
+	"system-monitor-bot/internal/monitor"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// embedText flattens an embed's title, description and fields for matching
+func embedText(embed *discordgo.MessageEmbed) string {
+	parts := []string{embed.Title, embed.Description}
+	for _, field := range embed.Fields {
+		parts = append(parts, field.Name, field.Value)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// onlyFollowup returns the single followup a command sent
+func onlyFollowup(t *testing.T, session *stubSession) *discordgo.WebhookParams {
+	t.Helper()
+	followups := session.sentFollowups()
+	if len(followups) != 1 {
+		t.Fatalf("got %d followups, want 1", len(followups))
+	}
+	return followups[0]
+}
+
+func TestHandleTemperatureCommand(t *testing.T) {
+	sensors := fakeSensors{sensors: []monitor.TemperatureSensor{
+		{ID: "coretemp_package_id_0", Name: "CPU Package", Temperature: 48, Category: monitor.CategoryCPU},
+		{ID: "nvme_composite", Name: "NVMe Composite", Temperature: 39, Category: monitor.CategoryStorage},
+	}}
+	session := newStubSession()
+	sm := newTestMonitor(t, session, sensors, nil, nil)
+
+	sm.handleTemperatureCommand(session, commandInteraction("channel", "temp"))
+
+	responses := session.sentResponses()
+	if len(responses) != 1 || responses[0].Type != discordgo.InteractionResponseDeferredChannelMessageWithSource {
+		t.Fatalf("responses = %+v, want one deferral", responses)
+	}
+	followup := onlyFollowup(t, session)
+	if len(followup.Embeds) != 1 {
+		t.Fatalf("got %d embeds, want 1", len(followup.Embeds))
+	}
+	text := embedText(followup.Embeds[0])
+	for _, want := range []string{"CPU Package", "48.0°C", "NVMe Composite"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("temperature embed is missing %q:\n%s", want, text)
+		}
+	}
+	if len(followup.Components) == 0 {
+		t.Error("temperature response has no refresh button")
+	}
+}
+
+func TestHandleTemperatureCommandErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		sensors fakeSensors
+		want    string
+	}{
+		{name: "not installed", sensors: fakeSensors{err: monitor.ErrSensorsNotInstalled}, want: "lm-sensors is not installed"},
+		{name: "no chips", sensors: fakeSensors{err: monitor.ErrNoSensorChips}, want: "No sensor chips detected"},
+		{name: "read failure", sensors: fakeSensors{err: errors.New("exit status 1")}, want: "Failed to read temperature sensors"},
+		{name: "no sensors", sensors: fakeSensors{}, want: "No temperature sensors found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := newStubSession()
+			sm := newTestMonitor(t, session, tt.sensors, nil, nil)
+
+			sm.handleTemperatureCommand(session, commandInteraction("channel", "temp"))
+
+			if content := onlyFollowup(t, session).Content; !strings.Contains(content, tt.want) {
+				t.Errorf("error followup = %q, want it to contain %q", content, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlePortsCommand(t *testing.T) {
+	ports := fakePorts{ports: []monitor.NetworkPort{
+		{
+			Protocol: "TCP", Address: "0.0.0.0", Port: "80", State: "LISTEN", ProcessName: "nginx",
+			Processes: []monitor.ProcessRef{{Name: "nginx", PID: "10"}, {Name: "nginx", PID: "11"}, {Name: "nginx", PID: "12"}},
+		},
+		{
+			Protocol: "TCP", Address: "127.0.0.1", Port: "5432", State: "LISTEN", ProcessName: "postgres",
+			Processes: []monitor.ProcessRef{{Name: "postgres", PID: "20"}},
+		},
+	}}
+
+	t.Run("listening", func(t *testing.T) {
+		session := newStubSession()
+		sm := newTestMonitor(t, session, nil, ports, nil)
+
+		sm.handlePortsCommand(session, commandInteraction("channel", "ports"))
+
+		followup := onlyFollowup(t, session)
+		text := embedText(followup.Embeds[0])
+		for _, want := range []string{"Nginx ×3", "PostgreSQL", "5432"} {
+			if !strings.Contains(text, want) {
+				t.Errorf("ports embed is missing %q:\n%s", want, text)
+			}
+		}
+		if strings.Contains(text, "PID") {
+			t.Errorf("ports embed lists PIDs without all:\n%s", text)
+		}
+	})
+
+	t.Run("all", func(t *testing.T) {
+		session := newStubSession()
+		sm := newTestMonitor(t, session, nil, ports, nil)
+
+		sm.handlePortsCommand(session, commandInteraction("channel", "ports", boolOption("all", true)))
+
+		text := embedText(onlyFollowup(t, session).Embeds[0])
+		for _, want := range []string{"Nginx ×3 `PIDs 10, 11, 12`", "PostgreSQL `PID 20`"} {
+			if !strings.Contains(text, want) {
+				t.Errorf("ports embed is missing %q:\n%s", want, text)
+			}
+		}
+	})
+
+	t.Run("process filter", func(t *testing.T) {
+		session := newStubSession()
+		sm := newTestMonitor(t, session, nil, ports, nil)
+
+		sm.handlePortsCommand(session, commandInteraction("channel", "ports", stringOption("process", "postgres")))
+
+		text := embedText(onlyFollowup(t, session).Embeds[0])
+		if strings.Contains(text, "Nginx") {
+			t.Errorf("filtered ports embed still shows nginx:\n%s", text)
+		}
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		session := newStubSession()
+		sm := newTestMonitor(t, session, nil, fakePorts{err: errors.New("ss not found")}, nil)
+
+		sm.handlePortsCommand(session, commandInteraction("channel", "ports"))
+
+		if content := onlyFollowup(t, session).Content; !strings.Contains(content, "Failed to read network ports") {
+			t.Errorf("error followup = %q", content)
+		}
+	})
+}
@@ -0,0 +1,237 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"system-monitor-bot/internal/monitor"
+	"system-monitor-bot/pkg/logger"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// refreshCooldown limits how often a single user can refresh an embed
+const refreshCooldown = 5 * time.Second
+
+// refreshTimes records each user's last refresh. Interactions are handled
+// concurrently, so the cooldown check and update happen under one lock.
+type refreshTimes struct {
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+func newRefreshTimes() *refreshTimes {
+	return &refreshTimes{last: make(map[string]time.Time)}
+}
+
+// allow records a refresh by userID, or returns how long the user must
+// still wait when the cooldown is active
+func (rt *refreshTimes) allow(userID string) (time.Duration, bool) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if last, exists := rt.last[userID]; exists && time.Since(last) < refreshCooldown {
+		return refreshCooldown - time.Since(last), false
+	}
+	rt.last[userID] = time.Now()
+	return 0, true
+}
+
+// Refresh button CustomIDs encode the embed to rebuild, e.g. "refresh:ports:all"
+const refreshPrefix = "refresh:"
+
+// refreshComponents returns an action row holding a refresh button that
+// rebuilds the given embed kind when clicked
+func refreshComponents(kind string) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Refresh",
+					Style:    discordgo.SecondaryButton,
+					Emoji:    &discordgo.ComponentEmoji{Name: "🔄"},
+					CustomID: refreshPrefix + kind,
+				},
+			},
+		},
+	}
+}
+
+// Sensor list page buttons encode the page to show, e.g. "sensors:page:2"
+const sensorsPagePrefix = "sensors:page:"
+
+// sensorPageComponents returns previous/next buttons for a sensor list page
+func sensorPageComponents(page, totalPages int) []discordgo.MessageComponent {
+	if totalPages <= 1 {
+		return nil
+	}
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Previous",
+					Style:    discordgo.SecondaryButton,
+					Emoji:    &discordgo.ComponentEmoji{Name: "◀️"},
+					CustomID: fmt.Sprintf("%s%d", sensorsPagePrefix, page-1),
+					Disabled: page <= 0,
+				},
+				discordgo.Button{
+					Label:    "Next",
+					Style:    discordgo.SecondaryButton,
+					Emoji:    &discordgo.ComponentEmoji{Name: "▶️"},
+					CustomID: fmt.Sprintf("%s%d", sensorsPagePrefix, page+1),
+					Disabled: page >= totalPages-1,
+				},
+			},
+		},
+	}
+}
+
+func (sm *SystemMonitor) onComponent(s discordSession, i *discordgo.InteractionCreate) {
+	customID := i.MessageComponentData().CustomID
+	userName := i.Member.User.Username
+	logger.Info("Received component interaction:", customID, "from user", userName)
+
+	switch {
+	case strings.HasPrefix(customID, refreshPrefix):
+		sm.handleRefresh(s, i, strings.TrimPrefix(customID, refreshPrefix))
+	case strings.HasPrefix(customID, sensorsPagePrefix):
+		sm.handleSensorsPage(s, i, strings.TrimPrefix(customID, sensorsPagePrefix))
+	default:
+		logger.Warn("Unknown component received:", customID, "from user:", userName)
+	}
+}
+
+// handleSensorsPage re-reads the sensors and shows the requested list page
+func (sm *SystemMonitor) handleSensorsPage(s discordSession, i *discordgo.InteractionCreate, pageID string) {
+	page, err := strconv.Atoi(pageID)
+	if err != nil {
+		logger.Warn("Invalid sensor list page:", pageID)
+		return
+	}
+	logger.Info("Handling sensor list page", page+1, "for user:", i.Member.User.Username)
+
+	if !sm.deferResponse(s, i, discordgo.InteractionResponseDeferredMessageUpdate, 0) {
+		return
+	}
+
+	sensors, err := sm.tempMonitor.GetSensors()
+	if err != nil {
+		logger.Error("Failed to get temperature sensors:", err)
+		sm.sendError(s, i, "Failed to read temperature sensors", err)
+		return
+	}
+
+	embed, totalPages := sm.embedBuilder.BuildSensorList(sensors, page)
+	page = min(max(page, 0), totalPages-1)
+	embeds := []*discordgo.MessageEmbed{embed}
+	components := sensorPageComponents(page, totalPages)
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds:     &embeds,
+		Components: &components,
+	})
+	if err != nil {
+		logger.Error("Failed to edit sensor list message:", err)
+	}
+}
+
+func (sm *SystemMonitor) handleRefresh(s discordSession, i *discordgo.InteractionCreate, kind string) {
+	userID := i.Member.User.ID
+	logger.Info("Handling refresh of", kind, "for user:", i.Member.User.Username)
+
+	if wait, ok := sm.lastRefresh.allow(userID); !ok {
+		logger.Info("Refresh suppressed for user", userID, "- cooldown active for", wait)
+		err := sm.respond(s, i, &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("⏳ Please wait %.0f seconds before refreshing again.", wait.Seconds()+0.5),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		if err != nil {
+			logger.Error("Failed to send refresh cooldown response:", err)
+		}
+		return
+	}
+
+	if !sm.deferResponse(s, i, discordgo.InteractionResponseDeferredMessageUpdate, 0) {
+		return
+	}
+
+	embed, err := sm.buildRefreshEmbed(kind)
+	if err != nil {
+		logger.Error("Failed to rebuild", kind, "embed:", err)
+		sm.sendError(s, i, "Failed to refresh", err)
+		return
+	}
+
+	logger.Info("Editing message with refreshed", kind, "embed...")
+	embeds := []*discordgo.MessageEmbed{embed}
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &embeds,
+	})
+	if err != nil {
+		logger.Error("Failed to edit refreshed message:", err)
+	} else {
+		logger.Info("Refresh of", kind, "completed successfully for user:", i.Member.User.Username)
+	}
+}
+
+// buildRefreshEmbed re-collects data and rebuilds the embed for a refresh kind
+func (sm *SystemMonitor) buildRefreshEmbed(kind string) (*discordgo.MessageEmbed, error) {
+	switch kind {
+	case "temp", "temp:compact":
+		sensors, err := sm.tempMonitor.GetSensors()
+		if err != nil {
+			return nil, err
+		}
+		if len(sensors) == 0 {
+			return nil, fmt.Errorf("no temperature sensors found")
+		}
+		sm.trends.annotate(sensors)
+		embed := sm.embedBuilder.BuildTemperature(sensors, kind == "temp:compact")
+		sm.addTopProcess(embed, sensors)
+		return embed, nil
+	case "disktemp":
+		sensors, err := sm.tempMonitor.GetSensors()
+		if err != nil {
+			return nil, err
+		}
+		storage := monitor.StorageSensors(sensors)
+		if len(storage) == 0 {
+			return nil, fmt.Errorf("no storage temperature sensors found")
+		}
+		sm.trends.annotate(storage)
+		return sm.embedBuilder.BuildDiskTemperature(storage), nil
+	case "disk", "disk:all":
+		disks, err := sm.diskMonitor.GetFilesystems(kind == "disk:all")
+		if err != nil {
+			return nil, err
+		}
+		return sm.embedBuilder.BuildDisk(disks), nil
+	case "top:cpu", "top:memory":
+		sortColumn := monitor.SortByCPU
+		if kind == "top:memory" {
+			sortColumn = monitor.SortByMemory
+		}
+		processes, err := sm.memMonitor.GetTopProcessesBy(sortColumn, sm.config.Display.Limits.MaxProcesses, nil)
+		if err != nil {
+			return nil, err
+		}
+		return sm.embedBuilder.BuildTop(processes, sortColumn), nil
+	}
+
+	if query, ok := parseMemoryKind(kind, sm.config.Display.Limits.MemoryTopN); ok {
+		processes, err := sm.memMonitor.GetTopProcesses(query.count)
+		if err != nil {
+			return nil, err
+		}
+		return sm.embedBuilder.BuildMemory(processes, query.byResident, sm.memoryTotals(processes)), nil
+	}
+	if query, ok := parsePortsKind(kind); ok {
+		ports, err := sm.readPorts(query, nil)
+		if err != nil {
+			return nil, err
+		}
+		return sm.embedBuilder.BuildPorts(ports, query.showAll, query.filter), nil
+	}
+	return nil, fmt.Errorf("unknown refresh target %q", kind)
+}
@@ -0,0 +1,161 @@
+package bot
+
+import (
+	"system-monitor-bot/internal/monitor"
+	"system-monitor-bot/pkg/logger"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// diskHistorySize is how many usage samples are kept per filesystem for the
+// growth rate estimate
+const diskHistorySize = 10
+
+// minDiskSamples is the fewest samples needed before projecting time-to-full
+const minDiskSamples = 3
+
+type diskSample struct {
+	at   time.Time
+	used uint64
+}
+
+// diskHistory keeps the most recent usage samples of one filesystem
+type diskHistory struct {
+	samples []diskSample
+	alerted bool
+}
+
+func (h *diskHistory) add(sample diskSample) {
+	h.samples = append(h.samples, sample)
+	if len(h.samples) > diskHistorySize {
+		h.samples = h.samples[len(h.samples)-diskHistorySize:]
+	}
+}
+
+// growthRate returns the least-squares growth of used space in bytes per
+// second over the recorded samples
+func (h *diskHistory) growthRate() float64 {
+	n := float64(len(h.samples))
+	origin := h.samples[0].at
+	var sumX, sumY, sumXY, sumXX float64
+	for _, sample := range h.samples {
+		x := sample.at.Sub(origin).Seconds()
+		y := float64(sample.used)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+func (sm *SystemMonitor) startDiskMonitoring() {
+	logger.Info("Disk monitoring goroutine started")
+
+	logger.Info("Running initial disk collection...")
+	runCycle("disk", sm.collectDisk)
+
+	logger.Info("Creating disk ticker with interval:", sm.config.Monitor.Interval)
+	ticker := time.NewTicker(sm.config.Monitor.Interval)
+	defer func() {
+		logger.Info("Stopping disk monitoring ticker")
+		ticker.Stop()
+	}()
+
+	for {
+		select {
+		case <-sm.done:
+			return
+		case <-ticker.C:
+			logger.Info("Disk monitoring cycle started")
+			runCycle("disk", sm.collectDisk)
+		}
+	}
+}
+
+// collectDisk records filesystem usage and alerts when linear extrapolation
+// of the recent growth rate projects a filesystem to fill within the window
+func (sm *SystemMonitor) collectDisk() {
+	disks, err := sm.diskMonitor.GetDiskUsage()
+	if err != nil {
+		logger.Error("Disk monitoring failed:", err)
+		return
+	}
+
+	// Alerts go out after the history is unlocked, so a slow Discord does
+	// not hold up /disk and /reset-history
+	for _, alert := range sm.recordDiskUsage(disks) {
+		sm.broadcastAlert(alert)
+	}
+}
+
+// recordDiskUsage adds a usage sample per filesystem and returns the fill
+// alerts to send, marking them as alerted
+func (sm *SystemMonitor) recordDiskUsage(disks []monitor.DiskUsage) []*discordgo.MessageEmbed {
+	sm.diskHistoryMu.Lock()
+	defer sm.diskHistoryMu.Unlock()
+
+	var alerts []*discordgo.MessageEmbed
+	now := time.Now()
+	window := sm.config.Thresholds.DiskFillWindow
+	for _, disk := range disks {
+		history, exists := sm.diskHistory[disk.Mountpoint]
+		if !exists {
+			history = &diskHistory{}
+			sm.diskHistory[disk.Mountpoint] = history
+		}
+		history.add(diskSample{at: now, used: disk.Used})
+		if len(history.samples) < minDiskSamples {
+			continue
+		}
+
+		rate := history.growthRate()
+		if rate <= 0 {
+			if history.alerted {
+				logger.Info("Filesystem", disk.Mountpoint, "is no longer growing - clearing fill alert")
+				history.alerted = false
+			}
+			continue
+		}
+
+		timeToFull := time.Duration(float64(disk.Available) / rate * float64(time.Second))
+		logger.Info("Filesystem", disk.Mountpoint, "growing at", int64(rate), "bytes/s - projected full in", timeToFull.Round(time.Second))
+
+		if timeToFull > window {
+			if history.alerted {
+				logger.Info("Filesystem", disk.Mountpoint, "fill projection back outside window - clearing fill alert")
+				history.alerted = false
+			}
+			continue
+		}
+		if history.alerted {
+			logger.Info("Filesystem", disk.Mountpoint, "fill alert still active - already alerted")
+			continue
+		}
+
+		logger.Warn("Filesystem", disk.Mountpoint, "projected to be full in", timeToFull.Round(time.Second))
+		if !sm.hasAlertTargets() {
+			logger.Warn("No alert channels or sinks configured - disk fill alert not sent")
+			continue
+		}
+		alerts = append(alerts, sm.embedBuilder.BuildDiskFillAlert(disk, timeToFull, rate))
+		history.alerted = true
+	}
+	return alerts
+}
+
+// resetDiskHistory drops every filesystem's usage samples, returning how
+// many filesystems were tracked
+func (sm *SystemMonitor) resetDiskHistory() int {
+	sm.diskHistoryMu.Lock()
+	defer sm.diskHistoryMu.Unlock()
+
+	cleared := len(sm.diskHistory)
+	sm.diskHistory = make(map[string]*diskHistory)
+	return cleared
+}
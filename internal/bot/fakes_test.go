@@ -0,0 +1,245 @@
+package bot
+
+import (
+	"fmt"
+	"sync"
+	"system-monitor-bot/internal/config"
+	"system-monitor-bot/internal/monitor"
+	"system-monitor-bot/pkg/logger"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var initLogger sync.Once
+
+// newTestMonitor builds a SystemMonitor from the default configuration
+// around a stub session and the given readers
+func newTestMonitor(t *testing.T, session *stubSession, sensors monitor.SensorReader, ports monitor.PortReader, processes monitor.ProcessReader) *SystemMonitor {
+	t.Helper()
+	initLogger.Do(logger.Init)
+
+	t.Setenv("DISCORD_BOT_TOKEN", "test-token")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load() error = %v", err)
+	}
+	return newSystemMonitor(cfg, session, sensors, ports, processes)
+}
+
+// fakeSensors is a SensorReader returning fixed sensors or an error
+type fakeSensors struct {
+	sensors []monitor.TemperatureSensor
+	err     error
+}
+
+func (f fakeSensors) GetSensors() ([]monitor.TemperatureSensor, error) {
+	return f.GetSensorsRaw(nil)
+}
+
+func (f fakeSensors) GetSensorsRaw(raw *monitor.RawOutput) ([]monitor.TemperatureSensor, error) {
+	// Callers annotate and sort the sensors, so each gets its own copy
+	return append([]monitor.TemperatureSensor(nil), f.sensors...), f.err
+}
+
+// fakePorts is a PortReader returning fixed ports and no connections
+type fakePorts struct {
+	ports []monitor.NetworkPort
+	err   error
+}
+
+func (f fakePorts) GetPorts(showAll bool) ([]monitor.NetworkPort, error) {
+	return f.GetPortsRaw(showAll, nil)
+}
+
+func (f fakePorts) GetPortsRaw(showAll bool, raw *monitor.RawOutput) ([]monitor.NetworkPort, error) {
+	return append([]monitor.NetworkPort(nil), f.ports...), f.err
+}
+
+func (f fakePorts) GetUnixSocketsRaw(raw *monitor.RawOutput) ([]monitor.NetworkPort, error) {
+	return nil, f.err
+}
+
+func (f fakePorts) GetConnectionsByRemote() ([]monitor.RemoteConnections, error) {
+	return nil, f.err
+}
+
+func (f fakePorts) GetConnectionsByLocalPort() (map[string]int, error) {
+	return f.GetConnectionsByLocalPortRaw(nil)
+}
+
+func (f fakePorts) GetConnectionsByLocalPortRaw(raw *monitor.RawOutput) (map[string]int, error) {
+	return map[string]int{}, f.err
+}
+
+// fakeProcesses is a ProcessReader returning fixed processes
+type fakeProcesses struct {
+	processes []monitor.ProcessMemory
+	err       error
+}
+
+func (f fakeProcesses) GetTopProcesses(limit int) ([]monitor.ProcessMemory, error) {
+	return f.GetTopProcessesBy(monitor.SortByMemory, limit, nil)
+}
+
+func (f fakeProcesses) GetTopProcessesRaw(limit int, raw *monitor.RawOutput) ([]monitor.ProcessMemory, error) {
+	return f.GetTopProcessesBy(monitor.SortByMemory, limit, raw)
+}
+
+func (f fakeProcesses) GetTopProcessesBy(sortColumn string, limit int, raw *monitor.RawOutput) ([]monitor.ProcessMemory, error) {
+	return append([]monitor.ProcessMemory(nil), f.processes[:min(limit, len(f.processes))]...), f.err
+}
+
+// stubSession records what the bot sends to Discord instead of sending it.
+// Like Discord, it refuses a second response to the same interaction.
+type stubSession struct {
+	mu           sync.Mutex
+	acknowledged map[string]bool // interaction ID -> responded
+	responses    []*discordgo.InteractionResponse
+	followups    []*discordgo.WebhookParams
+	edits        []*discordgo.WebhookEdit
+	messages     map[string][]*discordgo.MessageSend // channel ID -> sent messages
+	nextID       int
+}
+
+func newStubSession() *stubSession {
+	return &stubSession{
+		acknowledged: make(map[string]bool),
+		messages:     make(map[string][]*discordgo.MessageSend),
+	}
+}
+
+func (s *stubSession) AddHandler(handler interface{}) func() { return func() {} }
+func (s *stubSession) Open() error                           { return nil }
+func (s *stubSession) Close() error                          { return nil }
+
+func (s *stubSession) InteractionRespond(interaction *discordgo.Interaction, resp *discordgo.InteractionResponse, options ...discordgo.RequestOption) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.acknowledged[interaction.ID] {
+		return &discordgo.RESTError{Message: &discordgo.APIErrorMessage{
+			Code:    discordgo.ErrCodeInteractionHasAlreadyBeenAcknowledged,
+			Message: "Interaction has already been acknowledged.",
+		}}
+	}
+	s.acknowledged[interaction.ID] = true
+	s.responses = append(s.responses, resp)
+	return nil
+}
+
+func (s *stubSession) InteractionResponseEdit(interaction *discordgo.Interaction, newresp *discordgo.WebhookEdit, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.edits = append(s.edits, newresp)
+	return &discordgo.Message{}, nil
+}
+
+func (s *stubSession) FollowupMessageCreate(interaction *discordgo.Interaction, wait bool, data *discordgo.WebhookParams, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.followups = append(s.followups, data)
+	return &discordgo.Message{}, nil
+}
+
+func (s *stubSession) ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages[channelID] = append(s.messages[channelID], data)
+	s.nextID++
+	return &discordgo.Message{ID: fmt.Sprint(s.nextID), ChannelID: channelID}, nil
+}
+
+func (s *stubSession) ChannelMessageEditComplex(m *discordgo.MessageEdit, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return &discordgo.Message{ID: m.ID, ChannelID: m.Channel}, nil
+}
+
+func (s *stubSession) WebhookExecute(webhookID, token string, wait bool, data *discordgo.WebhookParams, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return nil, nil
+}
+
+func (s *stubSession) WebhookThreadExecute(webhookID, token string, wait bool, threadID string, data *discordgo.WebhookParams, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return nil, nil
+}
+
+func (s *stubSession) ThreadStart(channelID, name string, typ discordgo.ChannelType, archiveDuration int, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	return &discordgo.Channel{ID: channelID + "-thread", Name: name}, nil
+}
+
+func (s *stubSession) ChannelEdit(channelID string, data *discordgo.ChannelEdit, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	return &discordgo.Channel{ID: channelID}, nil
+}
+
+func (s *stubSession) Channel(channelID string, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	return &discordgo.Channel{ID: channelID}, nil
+}
+
+func (s *stubSession) UserChannelPermissions(userID, channelID string, fetchOptions ...discordgo.RequestOption) (int64, error) {
+	return discordgo.PermissionAll, nil
+}
+
+func (s *stubSession) UpdateGameStatus(idle int, name string) error { return nil }
+
+// sentTo returns the messages sent to a channel
+func (s *stubSession) sentTo(channelID string) []*discordgo.MessageSend {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*discordgo.MessageSend(nil), s.messages[channelID]...)
+}
+
+// sentFollowups returns the interaction followups sent so far
+func (s *stubSession) sentFollowups() []*discordgo.WebhookParams {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*discordgo.WebhookParams(nil), s.followups...)
+}
+
+// sentEdits returns the edits of interaction responses sent so far
+func (s *stubSession) sentEdits() []*discordgo.WebhookEdit {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*discordgo.WebhookEdit(nil), s.edits...)
+}
+
+// sentResponses returns the interaction responses sent so far
+func (s *stubSession) sentResponses() []*discordgo.InteractionResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*discordgo.InteractionResponse(nil), s.responses...)
+}
+
+// commandInteraction builds a slash command interaction from a guild member
+func commandInteraction(channelID, name string, options ...*discordgo.ApplicationCommandInteractionDataOption) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:        "interaction-" + name,
+		Type:      discordgo.InteractionApplicationCommand,
+		ChannelID: channelID,
+		GuildID:   "guild",
+		Member: &discordgo.Member{
+			User:        &discordgo.User{ID: "user", Username: "tester"},
+			Permissions: discordgo.PermissionAdministrator,
+		},
+		Data: discordgo.ApplicationCommandInteractionData{Name: name, Options: options},
+	}}
+}
+
+// componentInteraction builds a button click on a bot message
+func componentInteraction(channelID, customID string) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:        "interaction-" + customID,
+		Type:      discordgo.InteractionMessageComponent,
+		ChannelID: channelID,
+		GuildID:   "guild",
+		Member:    &discordgo.Member{User: &discordgo.User{ID: "user", Username: "tester"}},
+		Data:      discordgo.MessageComponentInteractionData{CustomID: customID, ComponentType: discordgo.ButtonComponent},
+	}}
+}
+
+// stringOption builds a string command option
+func stringOption(name, value string) *discordgo.ApplicationCommandInteractionDataOption {
+	return &discordgo.ApplicationCommandInteractionDataOption{Name: name, Type: discordgo.ApplicationCommandOptionString, Value: value}
+}
+
+// boolOption builds a boolean command option
+func boolOption(name string, value bool) *discordgo.ApplicationCommandInteractionDataOption {
+	return &discordgo.ApplicationCommandInteractionDataOption{Name: name, Type: discordgo.ApplicationCommandOptionBoolean, Value: value}
+}
@@ -0,0 +1,160 @@
+package bot
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"system-monitor-bot/internal/embed"
+	"system-monitor-bot/internal/monitor"
+	"system-monitor-bot/pkg/logger"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// snapshotPath is where instances serve their host snapshot
+const snapshotPath = "/snapshot"
+
+// fleetTimeout bounds each peer request so one unreachable peer cannot hold
+// up /fleet past Discord's follow-up window
+const fleetTimeout = 5 * time.Second
+
+// hostSnapshot summarizes this host for fleet comparisons
+func (sm *SystemMonitor) hostSnapshot() (*monitor.HostSnapshot, error) {
+	sensors, err := sm.tempMonitor.GetSensors()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sensors: %w", err)
+	}
+	sm.silences.apply(sensors)
+
+	memory, err := sm.sysInfo.GetMemoryUsage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read memory usage: %w", err)
+	}
+	counts, err := sm.sysInfo.GetProcessCounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read process counts: %w", err)
+	}
+
+	maxSensor := worstSensor(sensors)
+	return &monitor.HostSnapshot{
+		Name:              sm.config.Fleet.Name,
+		Time:              time.Now(),
+		MaxTemperature:    maxSensor.Temperature,
+		MaxSensor:         maxSensor.Name,
+		Status:            maxSensor.Status.String(),
+		MemoryUsedPercent: memory.UsedPercent(),
+		MemoryTotalBytes:  memory.TotalBytes,
+		Processes:         counts.Processes,
+	}, nil
+}
+
+// snapshotHandler serves the host snapshot as JSON, requiring the bearer
+// token when one is configured
+func (sm *SystemMonitor) snapshotHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if token := sm.config.Fleet.Token; token != "" {
+			given := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+				logger.Warn("Rejected snapshot request without a valid token from:", r.RemoteAddr)
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+
+		snapshot, err := sm.hostSnapshot()
+		if err != nil {
+			logger.Error("Failed to build host snapshot:", err)
+			http.Error(w, "snapshot unavailable", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+			logger.Error("Failed to write host snapshot:", err)
+		}
+	})
+}
+
+// fetchPeerSnapshot reads the snapshot served by the instance at peer
+func (sm *SystemMonitor) fetchPeerSnapshot(peer string) (*monitor.HostSnapshot, error) {
+	req, err := http.NewRequest(http.MethodGet, peer+snapshotPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if sm.config.Fleet.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+sm.config.Fleet.Token)
+	}
+
+	resp, err := sm.fleetClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("returned %s", resp.Status)
+	}
+	var snapshot monitor.HostSnapshot
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// fleetMembers collects this host and every peer concurrently, keeping
+// the configured order; a failed peer carries its error instead
+func (sm *SystemMonitor) fleetMembers() []embed.FleetMember {
+	members := make([]embed.FleetMember, len(sm.config.Fleet.Peers)+1)
+
+	members[0].Name = sm.config.Fleet.Name
+	members[0].Snapshot, members[0].Err = sm.hostSnapshot()
+
+	var wg sync.WaitGroup
+	for idx, peer := range sm.config.Fleet.Peers {
+		wg.Add(1)
+		go func(member *embed.FleetMember, peer string) {
+			defer wg.Done()
+			member.Name = peer
+			member.Snapshot, member.Err = sm.fetchPeerSnapshot(peer)
+			if member.Err != nil {
+				logger.Warn("Failed to fetch snapshot from peer", peer, "error:", member.Err)
+			}
+		}(&members[idx+1], peer)
+	}
+	wg.Wait()
+	return members
+}
+
+func (sm *SystemMonitor) handleFleetCommand(s discordSession, i *discordgo.InteractionCreate) {
+	logger.Info("Handling fleet command for user:", i.Member.User.Username)
+
+	// Registered commands can outlive a PEERS setting
+	if len(sm.config.Fleet.Peers) == 0 {
+		sm.respondEphemeral(s, i, "ℹ️ No peers configured - set `PEERS` on this instance to compare hosts.")
+		return
+	}
+
+	if !sm.deferResponse(s, i, discordgo.InteractionResponseDeferredChannelMessageWithSource, 0) {
+		return
+	}
+
+	logger.Info("Collecting snapshots from", len(sm.config.Fleet.Peers), "peers...")
+	embed := sm.embedBuilder.BuildFleet(sm.fleetMembers())
+
+	_, err := s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{embed},
+	})
+	if err != nil {
+		logger.Error("Failed to send fleet response:", err)
+		return
+	}
+	logger.Info("Fleet command completed successfully for user:", i.Member.User.Username)
+}
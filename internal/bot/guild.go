@@ -0,0 +1,107 @@
+package bot
+
+import (
+	"sync"
+	"system-monitor-bot/internal/metrics"
+	"system-monitor-bot/pkg/logger"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// commandGuilds is the set of guilds that have the bot's commands in
+// per-guild mode. Guilds become available again after gateway reconnects
+// and outages, which must not register their commands a second time.
+type commandGuilds struct {
+	mu         sync.Mutex
+	registered map[string]bool
+}
+
+func newCommandGuilds() *commandGuilds {
+	return &commandGuilds{registered: make(map[string]bool)}
+}
+
+// add records guildID, reporting false when it was already registered
+func (cg *commandGuilds) add(guildID string) bool {
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+	if cg.registered[guildID] {
+		return false
+	}
+	cg.registered[guildID] = true
+	return true
+}
+
+func (cg *commandGuilds) remove(guildID string) {
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+	delete(cg.registered, guildID)
+}
+
+func (sm *SystemMonitor) setBotUserID(userID string) {
+	sm.botUserMu.Lock()
+	defer sm.botUserMu.Unlock()
+	sm.botUserID = userID
+}
+
+func (sm *SystemMonitor) botID() string {
+	sm.botUserMu.RLock()
+	defer sm.botUserMu.RUnlock()
+	return sm.botUserID
+}
+
+// botPermissions returns the bot's permissions in a channel and where they
+// came from: the cached guild state when it has the channel, otherwise the
+// REST API
+func (sm *SystemMonitor) botPermissions(s discordSession, channelID string) (int64, string, error) {
+	if sm.state != nil {
+		if permissions, err := sm.state.UserChannelPermissions(sm.botID(), channelID); err == nil {
+			return permissions, "guild state", nil
+		}
+	}
+	permissions, err := s.UserChannelPermissions(sm.botID(), channelID)
+	return permissions, "REST API", err
+}
+
+// onGuildCreate registers the commands in a guild once it is available,
+// both for the guilds listed at startup and for guilds joined while running
+func (sm *SystemMonitor) onGuildCreate(s *discordgo.Session, event *discordgo.GuildCreate) {
+	if !sm.config.Discord.PerGuildCommands {
+		return
+	}
+	if !sm.commandGuilds.add(event.ID) {
+		logger.Info("Guild", event.Name, "("+event.ID+") available again - commands already registered")
+		return
+	}
+
+	commands := sm.registrableCommands()
+	logger.Info("Registering", len(commands), "slash commands in guild", event.Name, "("+event.ID+")")
+	if _, err := s.ApplicationCommandBulkOverwrite(s.State.User.ID, event.ID, commands); err != nil {
+		logger.Error("Failed to register commands in guild", event.ID, "error:", err)
+		metrics.DiscordAPIErrors.Inc()
+		// Try again the next time the guild becomes available
+		sm.commandGuilds.remove(event.ID)
+		return
+	}
+	logger.Info("Commands registered in guild:", event.ID)
+}
+
+// onGuildDelete removes the commands from a guild the bot has left. An
+// unavailable guild is only in an outage and keeps its commands.
+func (sm *SystemMonitor) onGuildDelete(s *discordgo.Session, event *discordgo.GuildDelete) {
+	if event.Unavailable {
+		logger.Warn("Guild", event.ID, "became unavailable - keeping its commands")
+		return
+	}
+	logger.Info("Bot was removed from guild:", event.ID)
+	if !sm.config.Discord.PerGuildCommands {
+		return
+	}
+
+	sm.commandGuilds.remove(event.ID)
+	if _, err := s.ApplicationCommandBulkOverwrite(s.State.User.ID, event.ID, []*discordgo.ApplicationCommand{}); err != nil {
+		// Removing the bot usually revokes access to the guild's commands
+		logger.Warn("Could not remove commands from guild", event.ID, "error:", err)
+		return
+	}
+	logger.Info("Commands removed from guild:", event.ID)
+}
@@ -4,6 +4,7 @@ package bot
 
 import (
 	"fmt"
+	"system-monitor-bot/internal/metrics"
 	"system-monitor-bot/pkg/logger"
 
 	"github.com/bwmarrin/discordgo"
@@ -13,17 +14,26 @@ func (sm *SystemMonitor) onReady(s *discordgo.Session, event *discordgo.Ready) {
 	logger.Info("Discord connection established successfully")
 	logger.Info("Bot ready! Logged in as:", s.State.User.Username)
 	logger.Info("Bot ID:", s.State.User.ID)
+	sm.setBotUserID(s.State.User.ID)
 	logger.Info("Connected to", len(s.State.Guilds), "guilds")
 
 	// Set bot status
-	logger.Info("Setting bot status to: System Monitor Active")
-	err := s.UpdateGameStatus(0, "⚡ System Monitor Active")
+	logger.Info("Setting bot status to:", sm.config.Discord.Status)
+	err := s.UpdateGameStatus(0, sm.config.Discord.Status)
 	if err != nil {
 		logger.Error("Failed to set bot status:", err)
+		metrics.DiscordAPIErrors.Inc()
 	} else {
 		logger.Info("Bot status set successfully")
 	}
 
+	// Let the next monitoring cycle replace the static status
+	if sm.config.Discord.DynamicStatus {
+		sm.alertStateMu.Lock()
+		sm.lastPresence = ""
+		sm.alertStateMu.Unlock()
+	}
+
 	// Register slash commands
 	logger.Info("Starting slash command registration")
 	sm.registerSlashCommands(s)
@@ -31,6 +41,25 @@ func (sm *SystemMonitor) onReady(s *discordgo.Session, event *discordgo.Ready) {
 }
 
 func (sm *SystemMonitor) onInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	sm.dispatchInteraction(countErrors(s), i)
+}
+
+// dispatchInteraction routes an interaction to its handler; it takes the
+// session interface so interactions can be driven without a gateway
+func (sm *SystemMonitor) dispatchInteraction(s discordSession, i *discordgo.InteractionCreate) {
+	defer sm.recoverInteraction(s, i)
+
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		sm.onCommand(s, i)
+	case discordgo.InteractionMessageComponent:
+		sm.onComponent(s, i)
+	default:
+		logger.Warn("Unhandled interaction type:", i.Type.String())
+	}
+}
+
+func (sm *SystemMonitor) onCommand(s discordSession, i *discordgo.InteractionCreate) {
 	commandName := i.ApplicationCommandData().Name
 	userName := i.Member.User.Username
 	userID := i.Member.User.ID
@@ -40,30 +69,87 @@ func (sm *SystemMonitor) onInteraction(s *discordgo.Session, i *discordgo.Intera
 	logger.Info("Received command:", commandName, "from user", userName, "("+userID+")")
 	logger.Info("Command executed in channel:", channelID, "guild:", guildID)
 
+	metrics.CommandInvocations.Inc(commandName)
+	if sm.config.Discord.TrackCommandUsers {
+		sm.commandUsers.Inc(userName)
+	}
+
+	if definition, found := lookupCommand(commandName); found {
+		watchdog := sm.watchDefer(s, i, definition)
+		defer watchdog.stop()
+		s = watchdog
+	}
+
+	// Commands registered before read-only mode was enabled may still be invoked
+	if sm.config.Discord.ReadOnly && isMutatingCommand(commandName) {
+		logger.Warn("Read-only mode - refusing mutating command:", commandName, "from user:", userName)
+		sm.respondEphemeral(s, i, "🔒 This bot is running in read-only mode and does not accept configuration changes.")
+		return
+	}
+
 	switch commandName {
 	case "temp":
 		logger.Info("Processing temperature command for user:", userName)
 		sm.handleTemperatureCommand(s, i)
+	case "sensors":
+		logger.Info("Processing sensors command for user:", userName)
+		sm.handleSensorsCommand(s, i)
+	case "disktemp":
+		logger.Info("Processing disktemp command for user:", userName)
+		sm.handleDiskTempCommand(s, i)
 	case "ports":
 		logger.Info("Processing ports command for user:", userName)
 		sm.handlePortsCommand(s, i)
+	case "connections":
+		logger.Info("Processing connections command for user:", userName)
+		sm.handleConnectionsCommand(s, i)
 	case "memory":
 		logger.Info("Processing memory command for user:", userName)
 		sm.handleMemoryCommand(s, i)
+	case "top":
+		logger.Info("Processing top command for user:", userName)
+		sm.handleTopCommand(s, i)
+	case "cpu":
+		logger.Info("Processing cpu command for user:", userName)
+		sm.handleCPUCommand(s, i)
 	case "alerts":
 		logger.Info("Processing alerts command for user:", userName)
 		sm.handleAlertsCommand(s, i)
 	case "status":
 		logger.Info("Processing status command for user:", userName)
 		sm.handleStatusCommand(s, i)
+	case "disk":
+		logger.Info("Processing disk command for user:", userName)
+		sm.handleDiskCommand(s, i)
+	case "power":
+		logger.Info("Processing power command for user:", userName)
+		sm.handlePowerCommand(s, i)
+	case "logs":
+		logger.Info("Processing logs command for user:", userName)
+		sm.handleLogsCommand(s, i)
+	case "diagnostics":
+		logger.Info("Processing diagnostics command for user:", userName)
+		sm.handleDiagnosticsCommand(s, i)
+	case "fleet":
+		logger.Info("Processing fleet command for user:", userName)
+		sm.handleFleetCommand(s, i)
+	case "config":
+		logger.Info("Processing config command for user:", userName)
+		sm.handleConfigCommand(s, i)
+	case "reset-history":
+		logger.Info("Processing reset-history command for user:", userName)
+		sm.handleResetHistoryCommand(s, i)
+	case "selftest":
+		logger.Info("Processing selftest command for user:", userName)
+		sm.handleSelfTestCommand(s, i)
 	default:
 		logger.Warn("Unknown command received:", commandName, "from user:", userName)
 	}
 }
 
-func (sm *SystemMonitor) sendError(s *discordgo.Session, i *discordgo.InteractionCreate, title string, err error) {
+func (sm *SystemMonitor) sendError(s discordSession, i *discordgo.InteractionCreate, title string, err error) {
 	logger.Error("Sending error response to user:", i.Member.User.Username, "- Title:", title, "Error:", err)
-	errorMsg := fmt.Sprintf("❌ **%s**\n```\n%v\n```", title, err)
+	errorMsg := logger.Redact(fmt.Sprintf("❌ **%s**\n```\n%v\n```", title, err))
 	_, followupErr := s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
 		Content: errorMsg,
 	})
@@ -0,0 +1,83 @@
+package bot
+
+import (
+	"strings"
+	"system-monitor-bot/internal/monitor"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// panickingSensors is a SensorReader that panics on every read
+type panickingSensors struct{}
+
+func (panickingSensors) GetSensors() ([]monitor.TemperatureSensor, error) {
+	panic("sensor reader exploded")
+}
+
+func (panickingSensors) GetSensorsRaw(raw *monitor.RawOutput) ([]monitor.TemperatureSensor, error) {
+	panic("sensor reader exploded")
+}
+
+var testSensors = fakeSensors{sensors: []monitor.TemperatureSensor{
+	{ID: "coretemp_package_id_0", Name: "CPU Package", Temperature: 52, Category: monitor.CategoryCPU},
+}}
+
+func TestDispatchInteraction(t *testing.T) {
+	t.Run("read command", func(t *testing.T) {
+		session := newStubSession()
+		sm := newTestMonitor(t, session, testSensors, nil, nil)
+
+		sm.dispatchInteraction(session, commandInteraction("channel", "temp"))
+
+		followup := onlyFollowup(t, session)
+		if len(followup.Embeds) != 1 || !strings.Contains(embedText(followup.Embeds[0]), "CPU Package") {
+			t.Errorf("temp followup = %+v, want the temperature embed", followup)
+		}
+	})
+
+	t.Run("refresh button", func(t *testing.T) {
+		session := newStubSession()
+		sm := newTestMonitor(t, session, testSensors, nil, nil)
+
+		sm.dispatchInteraction(session, componentInteraction("channel", refreshPrefix+"temp"))
+
+		responses := session.sentResponses()
+		if len(responses) != 1 || responses[0].Type != discordgo.InteractionResponseDeferredMessageUpdate {
+			t.Fatalf("responses = %+v, want one deferred update", responses)
+		}
+		edits := session.sentEdits()
+		if len(edits) != 1 || edits[0].Embeds == nil || len(*edits[0].Embeds) != 1 {
+			t.Fatalf("edits = %+v, want the message edited with one embed", edits)
+		}
+		if text := embedText((*edits[0].Embeds)[0]); !strings.Contains(text, "CPU Package") {
+			t.Errorf("refreshed embed is missing the sensor:\n%s", text)
+		}
+	})
+
+	t.Run("handler panic", func(t *testing.T) {
+		session := newStubSession()
+		sm := newTestMonitor(t, session, panickingSensors{}, nil, nil)
+
+		sm.dispatchInteraction(session, commandInteraction("channel", "temp"))
+
+		// /temp deferred before reading, so the error goes out as a followup
+		followup := onlyFollowup(t, session)
+		if !strings.Contains(followup.Content, "Internal error") || followup.Flags != discordgo.MessageFlagsEphemeral {
+			t.Errorf("panic followup = %+v, want an ephemeral internal error", followup)
+		}
+	})
+
+	t.Run("mutating command in read-only mode", func(t *testing.T) {
+		session := newStubSession()
+		sm := newTestMonitor(t, session, testSensors, nil, nil)
+		sm.config.Discord.ReadOnly = true
+
+		sm.dispatchInteraction(session, commandInteraction("channel", "reset-history"))
+
+		responses := session.sentResponses()
+		if len(responses) != 1 || !strings.Contains(responses[0].Data.Content, "read-only") {
+			t.Errorf("responses = %+v, want a read-only refusal", responses)
+		}
+	})
+}
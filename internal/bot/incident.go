@@ -0,0 +1,136 @@
+package bot
+
+import (
+	"fmt"
+	"sync"
+	"system-monitor-bot/internal/monitor"
+	"system-monitor-bot/pkg/logger"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// incident tracks a sustained alert condition delivered as one evolving
+// message per channel
+type incident struct {
+	started  time.Time
+	maxTemp  float64
+	mu       sync.Mutex
+	messages map[string]string // channel ID -> alert message ID
+}
+
+// updateIncident posts the first alert message of an incident to each
+// channel and edits it in place on every following cycle
+func (sm *SystemMonitor) updateIncident(level string, sensors []monitor.TemperatureSensor, message string, maxSensor monitor.TemperatureSensor) {
+	isNew := sm.incident == nil
+	if isNew {
+		logger.Info("Starting new alert incident")
+		sm.incident = &incident{
+			started:  time.Now(),
+			messages: make(map[string]string),
+		}
+	}
+	inc := sm.incident
+	if maxSensor.Temperature > inc.maxTemp {
+		inc.maxTemp = maxSensor.Temperature
+	}
+
+	duration := time.Since(inc.started).Round(time.Second)
+	description := fmt.Sprintf("%s\n\n🌡️ Current max: **%.1f°C** (%s) • Peak: **%.1f°C**\n⏱️ Ongoing for **%s** since %s",
+		message, maxSensor.Temperature, maxSensor.Name, inc.maxTemp, duration, sm.embedBuilder.FormatTime(inc.started))
+
+	logger.Info("Updating incident alert - Level:", level, "Duration:", duration)
+	embed := sm.embedBuilder.BuildAlert(level, sensors, description)
+	text := sm.embedBuilder.BuildAlertText(level, sensors, description)
+	content, embeds := sm.alertContent(embed, text)
+
+	// Sinks cannot edit messages, so they only hear when an incident starts
+	if isNew {
+		sm.notifySinks(embed, sm.embedBuilder.BuildAlertText(level, sensors, message))
+	}
+
+	sm.fanOutAlert(func(channelID string) error {
+		inc.mu.Lock()
+		messageID, exists := inc.messages[channelID]
+		inc.mu.Unlock()
+
+		if exists {
+			logger.Info("Editing incident message", messageID, "in channel:", channelID)
+			_, err := sm.discord.ChannelMessageEditComplex(&discordgo.MessageEdit{
+				ID:      messageID,
+				Channel: sm.alertDestination(channelID),
+				Content: &content,
+				Embeds:  &embeds,
+			})
+			if err == nil {
+				return nil
+			}
+			logger.Warn("Failed to edit incident message - posting a new one:", err)
+		}
+
+		logger.Info("Posting incident message to channel:", channelID)
+		msg, err := sm.sendAlertMessage(channelID, embed, text)
+		if err != nil {
+			return err
+		}
+		if msg == nil {
+			// Webhook messages cannot be edited; the next cycle posts anew
+			return nil
+		}
+
+		inc.mu.Lock()
+		inc.messages[channelID] = msg.ID
+		inc.mu.Unlock()
+		return nil
+	})
+
+	sm.alertStateMu.Lock()
+	sm.lastAlert = time.Now()
+	sm.alertStateMu.Unlock()
+}
+
+// resolveIncident ends the current incident and posts a recovery message to
+// the channels that received it, linking back to each channel's alert
+func (sm *SystemMonitor) resolveIncident(maxSensor monitor.TemperatureSensor) {
+	inc := sm.incident
+	sm.incident = nil
+
+	duration := time.Since(inc.started).Round(time.Second)
+	logger.Info("Alert incident resolved after", duration, "- peak:", inc.maxTemp, "°C")
+
+	embed := sm.embedBuilder.BuildRecovery(maxSensor, inc.maxTemp, duration)
+	sm.notifySinks(embed, sm.embedBuilder.PlainText(embed))
+	sm.fanOutAlert(func(channelID string) error {
+		inc.mu.Lock()
+		messageID, notified := inc.messages[channelID]
+		inc.mu.Unlock()
+		if !notified {
+			return errAlertSkipped
+		}
+
+		recovery := embed
+		if guildID := sm.alertGuild(channelID); guildID != "" {
+			recovery = withAlertLink(embed, messageLink(guildID, sm.alertDestination(channelID), messageID))
+		}
+
+		logger.Info("Posting recovery message to channel:", channelID)
+		return sm.sendAlert(channelID, recovery, sm.embedBuilder.PlainText(recovery))
+	})
+}
+
+// messageLink is the Discord jump link to a message in a guild channel
+func messageLink(guildID, channelID, messageID string) string {
+	return fmt.Sprintf("https://discord.com/channels/%s/%s/%s", guildID, channelID, messageID)
+}
+
+// withAlertLink returns a copy of a recovery embed with a field linking to
+// the alert message it resolves; the shared embed is left untouched
+func withAlertLink(embed *discordgo.MessageEmbed, link string) *discordgo.MessageEmbed {
+	linked := *embed
+	linked.Fields = append(append([]*discordgo.MessageEmbedField(nil), embed.Fields...), &discordgo.MessageEmbedField{
+		Name:   "🔗 Original Alert",
+		Value:  fmt.Sprintf("[Jump to the alert](%s)", link),
+		Inline: false,
+	})
+	return &linked
+}
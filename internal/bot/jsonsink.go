@@ -0,0 +1,89 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"syscall"
+	"system-monitor-bot/internal/config"
+	"system-monitor-bot/pkg/logger"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// jsonAlertRecord is one alert as written by the JSON sink, one per line
+type jsonAlertRecord struct {
+	Time        time.Time        `json:"time"`
+	Title       string           `json:"title"`
+	Description string           `json:"description,omitempty"`
+	Fields      []jsonAlertField `json:"fields,omitempty"`
+	Text        string           `json:"text"`
+}
+
+type jsonAlertField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// jsonSink writes alerts as JSON lines to stdout or a file for external
+// scripts to pick up
+type jsonSink struct {
+	mu   sync.Mutex // keeps concurrent records on separate lines
+	path string     // empty for stdout
+}
+
+func newJSONSink(output string) *jsonSink {
+	if output == config.AlertOutputStdout {
+		logger.Info("Creating JSON alert sink on stdout")
+		return &jsonSink{}
+	}
+	logger.Info("Creating JSON alert sink for:", output)
+	return &jsonSink{path: output}
+}
+
+func (js *jsonSink) Name() string {
+	return "JSON"
+}
+
+// Send appends the alert as a single JSON line
+func (js *jsonSink) Send(embed *discordgo.MessageEmbed, text string) error {
+	record := jsonAlertRecord{Time: time.Now().UTC(), Text: text}
+	if embed != nil {
+		record.Title = embed.Title
+		record.Description = embed.Description
+		for _, field := range embed.Fields {
+			record.Fields = append(record.Fields, jsonAlertField{Name: field.Name, Value: field.Value})
+		}
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode alert record: %w", err)
+	}
+	line = append(line, '\n')
+
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	if js.path == "" {
+		return writeRecord(os.Stdout, line)
+	}
+
+	// The file is opened per record so a rotated file or a named pipe whose
+	// reader restarted is picked up. O_NONBLOCK fails fast on a pipe nobody
+	// is reading rather than blocking the sink.
+	file, err := os.OpenFile(js.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND|syscall.O_NONBLOCK, 0o640)
+	if err != nil {
+		return fmt.Errorf("failed to open alert output: %w", err)
+	}
+	defer file.Close()
+	return writeRecord(file, line)
+}
+
+func writeRecord(w io.Writer, line []byte) error {
+	if _, err := w.Write(line); err != nil {
+		return fmt.Errorf("failed to write alert record: %w", err)
+	}
+	return nil
+}
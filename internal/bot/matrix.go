@@ -0,0 +1,137 @@
+package bot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"system-monitor-bot/internal/config"
+	"system-monitor-bot/internal/httpclient"
+	"system-monitor-bot/pkg/logger"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// discordTimestampRegex matches Discord <t:unix:style> markup, which Matrix
+// clients would show verbatim
+var discordTimestampRegex = regexp.MustCompile(`<t:(\d+)(?::[a-zA-Z])?>`)
+
+// discordBoldRegex matches **bold** markdown
+var discordBoldRegex = regexp.MustCompile(`\*\*(.+?)\*\*`)
+
+// matrixSink posts alerts to a Matrix room through the client-server API
+type matrixSink struct {
+	client     *http.Client
+	homeserver string
+	token      string
+	roomID     string
+	txnCounter atomic.Uint64
+}
+
+func newMatrixSink(cfg config.MatrixConfig, proxyURL string, timeout time.Duration) (*matrixSink, error) {
+	logger.Info("Creating Matrix sink for room:", cfg.RoomID)
+	client, err := httpclient.New(proxyURL, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Matrix HTTP client: %w", err)
+	}
+	logger.RegisterSecret(cfg.Token)
+
+	return &matrixSink{
+		client:     client,
+		homeserver: cfg.Homeserver,
+		token:      cfg.Token,
+		roomID:     cfg.RoomID,
+	}, nil
+}
+
+func (ms *matrixSink) Name() string {
+	return "Matrix"
+}
+
+// Send posts the alert as an m.text message with an HTML formatted body
+func (ms *matrixSink) Send(embed *discordgo.MessageEmbed, text string) error {
+	plain := matrixTimestamps(text)
+	content := map[string]string{
+		"msgtype":        "m.text",
+		"body":           strings.ReplaceAll(plain, "**", ""),
+		"format":         "org.matrix.custom.html",
+		"formatted_body": matrixHTML(embed, plain),
+	}
+	body, err := json.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("failed to encode Matrix message: %w", err)
+	}
+
+	// The transaction ID makes a retried PUT idempotent on the homeserver
+	txnID := fmt.Sprintf("sysmon-%d-%d", time.Now().UnixNano(), ms.txnCounter.Add(1))
+	endpoint := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+		ms.homeserver, url.PathEscape(ms.roomID), url.PathEscape(txnID))
+
+	req, err := http.NewRequest(http.MethodPut, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Matrix request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+ms.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := ms.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("matrix request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("matrix homeserver returned %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// matrixHTML renders an embed as HTML for Matrix clients, falling back to
+// the plain text when there is no embed
+func matrixHTML(embed *discordgo.MessageEmbed, plain string) string {
+	if embed == nil {
+		return matrixInline(plain)
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "<h4>%s</h4>", html.EscapeString(embed.Title))
+	if embed.Description != "" {
+		fmt.Fprintf(&out, "<p>%s</p>", matrixInline(matrixTimestamps(embed.Description)))
+	}
+	if len(embed.Fields) > 0 {
+		out.WriteString("<ul>")
+		for _, field := range embed.Fields {
+			fmt.Fprintf(&out, "<li><strong>%s</strong>: %s</li>",
+				html.EscapeString(field.Name), matrixInline(matrixTimestamps(field.Value)))
+		}
+		out.WriteString("</ul>")
+	}
+	return out.String()
+}
+
+// matrixInline escapes text and converts bold markdown and line breaks
+func matrixInline(text string) string {
+	escaped := html.EscapeString(text)
+	escaped = discordBoldRegex.ReplaceAllString(escaped, "<strong>$1</strong>")
+	return strings.ReplaceAll(escaped, "\n", "<br>")
+}
+
+// matrixTimestamps replaces Discord timestamp markup with UTC times
+func matrixTimestamps(text string) string {
+	return discordTimestampRegex.ReplaceAllStringFunc(text, func(match string) string {
+		unix, err := strconv.ParseInt(discordTimestampRegex.FindStringSubmatch(match)[1], 10, 64)
+		if err != nil {
+			return match
+		}
+		return time.Unix(unix, 0).UTC().Format("2006-01-02 15:04:05 UTC")
+	})
+}
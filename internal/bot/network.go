@@ -0,0 +1,88 @@
+package bot
+
+import (
+	"system-monitor-bot/internal/metrics"
+	"system-monitor-bot/internal/monitor"
+	"system-monitor-bot/pkg/logger"
+	"time"
+)
+
+func (sm *SystemMonitor) startNetworkMonitoring() {
+	logger.Info("Network monitoring goroutine started")
+
+	logger.Info("Running initial network collection...")
+	runCycle("network", sm.collectNetwork)
+
+	logger.Info("Creating network ticker with interval:", sm.config.Monitor.NetworkInterval)
+	ticker := time.NewTicker(sm.config.Monitor.NetworkInterval)
+	defer func() {
+		logger.Info("Stopping network monitoring ticker")
+		ticker.Stop()
+	}()
+
+	for {
+		select {
+		case <-sm.done:
+			return
+		case <-ticker.C:
+			logger.Info("Network monitoring cycle started")
+			runCycle("network", sm.collectNetwork)
+		}
+	}
+}
+
+// collectNetwork runs a single network monitoring cycle, keeping the
+// listening ports for the features that work between commands
+func (sm *SystemMonitor) collectNetwork() {
+	ports, err := sm.netMonitor.GetPorts(false)
+	if err != nil {
+		// Keep the previous snapshot and leave watched ports as they were
+		logger.Error("Network monitoring failed:", err)
+		return
+	}
+
+	logger.Info("Collected", len(ports), "listening ports")
+	sm.snapshotMu.Lock()
+	sm.lastPortData = append(make([]monitor.NetworkPort, 0, len(ports)), ports...)
+	sm.snapshotMu.Unlock()
+	metrics.ListeningPorts.Set(int64(len(ports)))
+
+	sm.checkWatchedPorts(ports)
+}
+
+// checkWatchedPorts checks every watched port against the listening sockets
+// and alerts once for each port that stopped listening; a port that listens
+// again is re-armed
+func (sm *SystemMonitor) checkWatchedPorts(ports []monitor.NetworkPort) {
+	var newlyClosed []monitor.WatchedPort
+	for _, watched := range sm.config.Monitor.WatchedPorts {
+		listening := false
+		for _, port := range ports {
+			if watched.Matches(port) {
+				listening = true
+				break
+			}
+		}
+
+		switch {
+		case listening && sm.closedPorts[watched]:
+			logger.Info("Watched port", watched, "is listening again")
+			delete(sm.closedPorts, watched)
+		case !listening && !sm.closedPorts[watched]:
+			logger.Warn("Watched port", watched, "is not listening")
+			sm.closedPorts[watched] = true
+			newlyClosed = append(newlyClosed, watched)
+		case !listening:
+			logger.Info("Watched port", watched, "still closed - already alerted")
+		}
+	}
+
+	if len(newlyClosed) == 0 {
+		return
+	}
+	if !sm.hasAlertTargets() {
+		logger.Warn("No alert channels or sinks configured - port closed alert not sent")
+		return
+	}
+	sm.broadcastAlert(sm.embedBuilder.BuildPortClosedAlert(newlyClosed))
+}
@@ -0,0 +1,36 @@
+package bot
+
+import (
+	"fmt"
+	"system-monitor-bot/pkg/logger"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// validateIntOption reads an integer option and checks it against the
+// bounds its command registers. Discord only enforces the bounds of the
+// registration a client has seen, so stale command registrations and
+// hand-crafted interactions can still carry any value.
+func validateIntOption(opt *discordgo.ApplicationCommandInteractionDataOption, min, max float64) (int, error) {
+	value := opt.IntValue()
+	if float64(value) < min || float64(value) > max {
+		logger.Warn("Rejected out of range option", opt.Name+":", value)
+		return 0, fmt.Errorf("`%s` must be between %.0f and %.0f, got %d", opt.Name, min, max, value)
+	}
+	return int(value), nil
+}
+
+// validateNumberOption is validateIntOption for number options
+func validateNumberOption(opt *discordgo.ApplicationCommandInteractionDataOption, min, max float64) (float64, error) {
+	value := opt.FloatValue()
+	if value < min || value > max {
+		logger.Warn("Rejected out of range option", opt.Name+":", value)
+		return 0, fmt.Errorf("`%s` must be between %.0f and %.0f, got %g", opt.Name, min, max, value)
+	}
+	return value, nil
+}
+
+// respondInvalidOption tells the user which option value was rejected
+func (sm *SystemMonitor) respondInvalidOption(s discordSession, i *discordgo.InteractionCreate, err error) {
+	sm.respondEphemeral(s, i, fmt.Sprintf("❌ %v", err))
+}
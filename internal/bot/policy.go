@@ -0,0 +1,52 @@
+package bot
+
+import (
+	"fmt"
+	"system-monitor-bot/internal/config"
+	"system-monitor-bot/internal/monitor"
+	"system-monitor-bot/pkg/logger"
+)
+
+// defaultWarningMessage is the alert text when the hottest sensor is in warning
+const defaultWarningMessage = "🔥 System temperature elevated - monitor closely"
+
+// warningCondition applies the configured alert policy to sensors that are
+// not critical, returning whether a warning alert is due and its message
+func (sm *SystemMonitor) warningCondition(sensors []monitor.TemperatureSensor, maxSensor monitor.TemperatureSensor) (bool, string) {
+	switch sm.config.Monitor.AlertPolicy {
+	case config.AlertPolicyCount:
+		elevated := 0
+		for _, sensor := range sensors {
+			if !sensor.ExcludeFromAlerts && sensor.Status >= monitor.TempWarning {
+				elevated++
+			}
+		}
+		required := sm.config.Monitor.AlertMinSensors
+		logger.Info("Count alert policy -", elevated, "sensors elevated, alerting at", required)
+		if elevated < required {
+			return false, ""
+		}
+		return true, fmt.Sprintf("🔥 %d sensors are at warning level or above - monitor closely", elevated)
+
+	case config.AlertPolicyAverage:
+		total, count := 0.0, 0
+		for _, sensor := range sensors {
+			if !sensor.ExcludeFromAlerts {
+				total += sensor.Temperature
+				count++
+			}
+		}
+		if count == 0 {
+			return false, ""
+		}
+		average := total / float64(count)
+		logger.Info("Average alert policy - average of", count, "sensors:", fmt.Sprintf("%.1f°C", average))
+		if average < sm.config.Thresholds.Warning {
+			return false, ""
+		}
+		return true, fmt.Sprintf("🔥 Average temperature across %d sensors is %.1f°C - monitor closely", count, average)
+
+	default:
+		return maxSensor.Status >= monitor.TempWarning, defaultWarningMessage
+	}
+}
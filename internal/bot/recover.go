@@ -0,0 +1,31 @@
+package bot
+
+import (
+	"runtime/debug"
+	"system-monitor-bot/pkg/logger"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// recoverInteraction stops a panic in an interaction handler from taking
+// down the bot, logging it with its stack and telling the user the command
+// failed. It must be deferred directly.
+func (sm *SystemMonitor) recoverInteraction(s discordSession, i *discordgo.InteractionCreate) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	logger.Error("Panic while handling interaction", i.ID, "of type", i.Type.String()+":", r, "\n"+string(debug.Stack()))
+	sm.respondEphemeral(s, i, "❌ Internal error - this command failed unexpectedly. The details have been logged.")
+}
+
+// runCycle runs one monitoring cycle, recovering a panic so the monitoring
+// loop carries on with the next cycle
+func runCycle(name string, collect func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("Panic in", name, "monitoring cycle:", r, "\n"+string(debug.Stack()))
+		}
+	}()
+	collect()
+}
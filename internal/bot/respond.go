@@ -0,0 +1,135 @@
+package bot
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"system-monitor-bot/pkg/logger"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/gorilla/websocket"
+)
+
+// ErrInvalidToken is returned by Start when Discord rejects the bot token
+var ErrInvalidToken = errors.New("Discord rejected the bot token - check DISCORD_BOT_TOKEN")
+
+// gatewayAuthenticationFailed is the gateway close code for an invalid token
+const gatewayAuthenticationFailed = 4004
+
+// isInvalidTokenError reports whether err means the token was refused,
+// either by the REST API (401) or by the gateway when identifying
+func isInvalidTokenError(err error) bool {
+	var restErr *discordgo.RESTError
+	if errors.As(err, &restErr) && restErr.Response != nil && restErr.Response.StatusCode == http.StatusUnauthorized {
+		return true
+	}
+	var closeErr *websocket.CloseError
+	return errors.As(err, &closeErr) && closeErr.Code == gatewayAuthenticationFailed
+}
+
+// isDiscordError reports whether err is a Discord API error with the given code
+func isDiscordError(err error, code int) bool {
+	var restErr *discordgo.RESTError
+	return errors.As(err, &restErr) && restErr.Message != nil && restErr.Message.Code == code
+}
+
+// deferResponse acknowledges an interaction before any slow work so Discord's
+// three second window cannot lapse. responseType is a deferred message or a
+// deferred update. An interaction that was already acknowledged still accepts
+// follow-ups and edits, so that case counts as success; false means the
+// interaction is gone and the handler should stop.
+func (sm *SystemMonitor) deferResponse(s discordSession, i *discordgo.InteractionCreate, responseType discordgo.InteractionResponseType, flags discordgo.MessageFlags) bool {
+	logger.Info("Sending deferred response...")
+	response := &discordgo.InteractionResponse{Type: responseType}
+	if flags != 0 {
+		response.Data = &discordgo.InteractionResponseData{Flags: flags}
+	}
+
+	err := s.InteractionRespond(i.Interaction, response)
+	switch {
+	case err == nil:
+		return true
+	case isDiscordError(err, discordgo.ErrCodeInteractionHasAlreadyBeenAcknowledged):
+		logger.Warn("Interaction already acknowledged - continuing with follow-ups")
+		return true
+	default:
+		logger.Error("Failed to send deferred response:", err)
+		return false
+	}
+}
+
+// deferWatchdog is the session for one command interaction; it defers the
+// interaction once the handler has gone the defer threshold without
+// answering. Fast commands respond directly, but a loaded host can stretch
+// even in-memory work past Discord's three second window. A late answer
+// then goes out as a follow-up through respond, and deferResponse treats the
+// earlier acknowledgement as success.
+type deferWatchdog struct {
+	discordSession
+	mu           sync.Mutex
+	acknowledged bool
+	timer        *time.Timer
+}
+
+// watchDefer starts a watchdog for an invocation of cmd; the deferral keeps
+// ephemeral commands ephemeral. Call stop once the handler returns.
+func (sm *SystemMonitor) watchDefer(s discordSession, i *discordgo.InteractionCreate, cmd commandDefinition) *deferWatchdog {
+	threshold := sm.config.Discord.DeferThreshold
+	var flags discordgo.MessageFlags
+	if cmd.Ephemeral {
+		flags = discordgo.MessageFlagsEphemeral
+	}
+	w := &deferWatchdog{discordSession: s}
+	w.timer = time.AfterFunc(threshold, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if w.acknowledged {
+			return
+		}
+		if cmd.Deferred {
+			logger.Warn("Command", cmd.Name, "did not defer within", threshold, "- deferring the interaction for it")
+		} else {
+			logger.Warn("Command", cmd.Name, "has not responded within", threshold, "- deferring the interaction")
+		}
+		w.acknowledged = true
+		sm.deferResponse(w.discordSession, i, discordgo.InteractionResponseDeferredChannelMessageWithSource, flags)
+	})
+	return w
+}
+
+// InteractionRespond marks the interaction acknowledged; holding the lock
+// orders a handler's response after a deferral already in flight
+func (w *deferWatchdog) InteractionRespond(interaction *discordgo.Interaction, resp *discordgo.InteractionResponse, options ...discordgo.RequestOption) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.acknowledged = true
+	return w.discordSession.InteractionRespond(interaction, resp, options...)
+}
+
+// stop cancels a deferral that has not fired yet
+func (w *deferWatchdog) stop() {
+	w.timer.Stop()
+}
+
+// respond answers an interaction immediately, sending the response as a
+// follow-up instead when the interaction was already acknowledged
+func (sm *SystemMonitor) respond(s discordSession, i *discordgo.InteractionCreate, data *discordgo.InteractionResponseData) error {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	})
+	if !isDiscordError(err, discordgo.ErrCodeInteractionHasAlreadyBeenAcknowledged) {
+		return err
+	}
+
+	logger.Warn("Interaction already acknowledged - sending response as a follow-up")
+	_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Content:    data.Content,
+		Embeds:     data.Embeds,
+		Components: data.Components,
+		Files:      data.Files,
+		Flags:      data.Flags,
+	})
+	return err
+}
@@ -0,0 +1,156 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"system-monitor-bot/internal/embed"
+	"system-monitor-bot/internal/monitor"
+	"system-monitor-bot/pkg/logger"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// runSelfTest runs every monitor once, checks the configuration and the bot's
+// permissions in each alert channel, and returns one result per check
+func (sm *SystemMonitor) runSelfTest(s discordSession) []embed.CheckResult {
+	logger.Info("Running self-test...")
+	var results []embed.CheckResult
+
+	check := func(name string, run func() (string, error)) {
+		detail, err := run()
+		if err != nil {
+			logger.Warn("Self-test check", name, "failed:", err)
+			results = append(results, embed.CheckResult{Name: name, Detail: logger.Redact(err.Error())})
+			return
+		}
+		logger.Info("Self-test check", name, "passed:", detail)
+		results = append(results, embed.CheckResult{Name: name, Passed: true, Detail: detail})
+	}
+
+	check("Configuration", func() (string, error) {
+		if err := sm.config.Display.Limits.Validate(); err != nil {
+			return "", err
+		}
+		if sm.config.Thresholds.Warning >= sm.config.Thresholds.Critical {
+			return "", fmt.Errorf("warning threshold %.1f°C is not below critical %.1f°C", sm.config.Thresholds.Warning, sm.config.Thresholds.Critical)
+		}
+		return fmt.Sprintf("Warning %.1f°C • Critical %.1f°C • Interval %s", sm.config.Thresholds.Warning, sm.config.Thresholds.Critical, sm.config.Monitor.Interval), nil
+	})
+	check("Temperature sensors", func() (string, error) {
+		sensors, err := sm.tempMonitor.GetSensors()
+		if err != nil {
+			return "", err
+		}
+		if len(sensors) == 0 {
+			return "", fmt.Errorf("no temperature sensors found")
+		}
+		return fmt.Sprintf("%d sensors", len(sensors)), nil
+	})
+	check("Network ports", func() (string, error) {
+		ports, err := sm.netMonitor.GetPorts(false)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d listening ports", len(ports)), nil
+	})
+	check("Memory processes", func() (string, error) {
+		processes, err := sm.memMonitor.GetTopProcesses(sm.config.Display.Limits.MemoryTopN)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d processes", len(processes)), nil
+	})
+	check("File descriptors", func() (string, error) {
+		stats, err := sm.fdMonitor.GetFileDescriptorStats(nil)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%.1f%% used", stats.UsagePercent()), nil
+	})
+	check("System info", func() (string, error) {
+		counts, err := sm.sysInfo.GetProcessCounts()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d processes, %d threads", counts.Processes, counts.Threads), nil
+	})
+	check("Power supplies", func() (string, error) {
+		supplies, err := sm.powerMonitor.GetPowerSupplies()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d supplies", len(supplies)), nil
+	})
+	check("Disk usage", func() (string, error) {
+		disks, err := sm.diskMonitor.GetDiskUsage()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d filesystems", len(disks)), nil
+	})
+	check("Pressure stall info", func() (string, error) {
+		pressures, err := sm.psiMonitor.GetPressure()
+		// Without PSI only the memory pressure alert is lost
+		if errors.Is(err, monitor.ErrPressureUnavailable) && sm.config.Thresholds.MemoryPressurePercent <= 0 {
+			return "Not supported by this kernel", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d resources", len(pressures)), nil
+	})
+
+	channelIDs := sm.alertChannelIDs()
+	if len(channelIDs) == 0 {
+		results = append(results, embed.CheckResult{Name: "Alert channels", Detail: "No alert channels configured - use /alerts enable"})
+	}
+	for _, channelID := range channelIDs {
+		check("Alert channel "+channelID, func() (string, error) {
+			permissions, _, err := sm.botPermissions(s, channelID)
+			if err != nil {
+				return "", fmt.Errorf("could not read permissions: %v", err)
+			}
+			var missing []string
+			for _, perm := range requiredPermissions {
+				if permissions&perm.Bit != perm.Bit {
+					missing = append(missing, perm.Name)
+				}
+			}
+			if len(missing) > 0 {
+				return "", fmt.Errorf("missing %s", strings.Join(missing, ", "))
+			}
+			return "All required permissions granted", nil
+		})
+	}
+
+	return results
+}
+
+func (sm *SystemMonitor) handleSelfTestCommand(s discordSession, i *discordgo.InteractionCreate) {
+	logger.Info("Handling selftest command for user:", i.Member.User.Username)
+
+	if !isAdmin(i) {
+		logger.Warn("Non-admin user attempted to run self-test:", i.Member.User.Username)
+		sm.respondAdminOnly(s, i)
+		return
+	}
+
+	if !sm.deferResponse(s, i, discordgo.InteractionResponseDeferredChannelMessageWithSource, discordgo.MessageFlagsEphemeral) {
+		return
+	}
+
+	results := sm.runSelfTest(s)
+	embed := sm.embedBuilder.BuildSelfTest(results)
+
+	logger.Info("Sending selftest response...")
+	_, err := s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{embed},
+		Flags:  discordgo.MessageFlagsEphemeral,
+	})
+	if err != nil {
+		logger.Error("Failed to send selftest response:", err)
+	} else {
+		logger.Info("Selftest command completed successfully for user:", i.Member.User.Username)
+	}
+}
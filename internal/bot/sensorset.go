@@ -0,0 +1,85 @@
+package bot
+
+import (
+	"sort"
+	"system-monitor-bot/internal/monitor"
+	"system-monitor-bot/pkg/logger"
+)
+
+// sensorPresence tracks which sensors reported in previous cycles so
+// disappearing and newly appearing sensors can be noticed
+type sensorPresence struct {
+	seen    map[string]string // sensor ID -> name
+	missing map[string]int    // sensor ID -> consecutive cycles absent
+	alerted map[string]bool   // sensor IDs already reported missing
+}
+
+// trackSensorPresence compares this cycle's sensors with the previously seen
+// set, alerting on sensors absent for the configured number of cycles
+func (sm *SystemMonitor) trackSensorPresence(sensors []monitor.TemperatureSensor) {
+	current := make(map[string]monitor.TemperatureSensor, len(sensors))
+	for _, sensor := range sensors {
+		current[sensor.ID] = sensor
+	}
+
+	if sm.sensorPresence == nil {
+		logger.Info("Recording initial sensor set of", len(current), "sensors")
+		sm.sensorPresence = &sensorPresence{
+			seen:    make(map[string]string, len(current)),
+			missing: make(map[string]int),
+			alerted: make(map[string]bool),
+		}
+		for id, sensor := range current {
+			sm.sensorPresence.seen[id] = sensor.Name
+		}
+		return
+	}
+	presence := sm.sensorPresence
+
+	var added []monitor.TemperatureSensor
+	for id, sensor := range current {
+		if _, known := presence.seen[id]; !known {
+			logger.Info("New sensor appeared:", sensor.Name, "("+id+")")
+			added = append(added, sensor)
+		}
+		if cycles, wasMissing := presence.missing[id]; wasMissing {
+			logger.Info("Sensor", sensor.Name, "is reporting again after", cycles, "missing cycles")
+			delete(presence.missing, id)
+			delete(presence.alerted, id)
+		}
+		presence.seen[id] = sensor.Name
+	}
+
+	threshold := sm.config.Monitor.MissingSensorCycles
+	var newlyMissing []string
+	for id, name := range presence.seen {
+		if _, present := current[id]; present {
+			continue
+		}
+		presence.missing[id]++
+		logger.Warn("Sensor", name, "("+id+") missing for", presence.missing[id], "cycles")
+		if threshold > 0 && presence.missing[id] >= threshold && !presence.alerted[id] {
+			presence.alerted[id] = true
+			newlyMissing = append(newlyMissing, name)
+		}
+	}
+
+	if len(newlyMissing) > 0 {
+		sort.Strings(newlyMissing)
+		logger.Warn("Sensors missing for", threshold, "cycles:", newlyMissing)
+		if !sm.hasAlertTargets() {
+			logger.Warn("No alert channels or sinks configured - missing sensor alert not sent")
+		} else {
+			sm.broadcastAlert(sm.embedBuilder.BuildSensorMissingAlert(newlyMissing, threshold))
+		}
+	}
+
+	if len(added) > 0 && sm.config.Monitor.AnnounceNewSensors {
+		if !sm.hasAlertTargets() {
+			logger.Warn("No alert channels or sinks configured - new sensor notice not sent")
+			return
+		}
+		sort.Slice(added, func(i, j int) bool { return added[i].Name < added[j].Name })
+		sm.broadcastAlert(sm.embedBuilder.BuildSensorAddedNotice(added))
+	}
+}
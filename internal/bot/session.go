@@ -0,0 +1,112 @@
+package bot
+
+import (
+	"system-monitor-bot/internal/metrics"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// discordSession is the subset of *discordgo.Session used by the bot, so a
+// stub can stand in for the real gateway connection
+type discordSession interface {
+	AddHandler(handler interface{}) func()
+	Open() error
+	Close() error
+
+	InteractionRespond(interaction *discordgo.Interaction, resp *discordgo.InteractionResponse, options ...discordgo.RequestOption) error
+	InteractionResponseEdit(interaction *discordgo.Interaction, newresp *discordgo.WebhookEdit, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	FollowupMessageCreate(interaction *discordgo.Interaction, wait bool, data *discordgo.WebhookParams, options ...discordgo.RequestOption) (*discordgo.Message, error)
+
+	ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessageEditComplex(m *discordgo.MessageEdit, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	WebhookExecute(webhookID, token string, wait bool, data *discordgo.WebhookParams, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	WebhookThreadExecute(webhookID, token string, wait bool, threadID string, data *discordgo.WebhookParams, options ...discordgo.RequestOption) (*discordgo.Message, error)
+
+	ThreadStart(channelID, name string, typ discordgo.ChannelType, archiveDuration int, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+	ChannelEdit(channelID string, data *discordgo.ChannelEdit, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+	Channel(channelID string, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+
+	UserChannelPermissions(userID, channelID string, fetchOptions ...discordgo.RequestOption) (int64, error)
+	UpdateGameStatus(idle int, name string) error
+}
+
+// Compile-time check that the real session satisfies discordSession
+var _ discordSession = (*discordgo.Session)(nil)
+
+// countingSession wraps a discordSession and counts failed Discord API calls
+type countingSession struct {
+	discordSession
+}
+
+// countErrors wraps session so every failed API call is counted
+func countErrors(session discordSession) discordSession {
+	if _, wrapped := session.(countingSession); wrapped {
+		return session
+	}
+	return countingSession{session}
+}
+
+func count(err error) error {
+	if err != nil {
+		metrics.DiscordAPIErrors.Inc()
+	}
+	return err
+}
+
+func (c countingSession) InteractionRespond(interaction *discordgo.Interaction, resp *discordgo.InteractionResponse, options ...discordgo.RequestOption) error {
+	return count(c.discordSession.InteractionRespond(interaction, resp, options...))
+}
+
+func (c countingSession) InteractionResponseEdit(interaction *discordgo.Interaction, newresp *discordgo.WebhookEdit, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	msg, err := c.discordSession.InteractionResponseEdit(interaction, newresp, options...)
+	return msg, count(err)
+}
+
+func (c countingSession) FollowupMessageCreate(interaction *discordgo.Interaction, wait bool, data *discordgo.WebhookParams, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	msg, err := c.discordSession.FollowupMessageCreate(interaction, wait, data, options...)
+	return msg, count(err)
+}
+
+func (c countingSession) ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	msg, err := c.discordSession.ChannelMessageSendComplex(channelID, data, options...)
+	return msg, count(err)
+}
+
+func (c countingSession) ChannelMessageEditComplex(m *discordgo.MessageEdit, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	msg, err := c.discordSession.ChannelMessageEditComplex(m, options...)
+	return msg, count(err)
+}
+
+func (c countingSession) WebhookExecute(webhookID, token string, wait bool, data *discordgo.WebhookParams, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	msg, err := c.discordSession.WebhookExecute(webhookID, token, wait, data, options...)
+	return msg, count(err)
+}
+
+func (c countingSession) WebhookThreadExecute(webhookID, token string, wait bool, threadID string, data *discordgo.WebhookParams, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	msg, err := c.discordSession.WebhookThreadExecute(webhookID, token, wait, threadID, data, options...)
+	return msg, count(err)
+}
+
+func (c countingSession) ThreadStart(channelID, name string, typ discordgo.ChannelType, archiveDuration int, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	channel, err := c.discordSession.ThreadStart(channelID, name, typ, archiveDuration, options...)
+	return channel, count(err)
+}
+
+func (c countingSession) ChannelEdit(channelID string, data *discordgo.ChannelEdit, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	channel, err := c.discordSession.ChannelEdit(channelID, data, options...)
+	return channel, count(err)
+}
+
+func (c countingSession) Channel(channelID string, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	channel, err := c.discordSession.Channel(channelID, options...)
+	return channel, count(err)
+}
+
+func (c countingSession) UserChannelPermissions(userID, channelID string, fetchOptions ...discordgo.RequestOption) (int64, error) {
+	perms, err := c.discordSession.UserChannelPermissions(userID, channelID, fetchOptions...)
+	return perms, count(err)
+}
+
+func (c countingSession) UpdateGameStatus(idle int, name string) error {
+	return count(c.discordSession.UpdateGameStatus(idle, name))
+}
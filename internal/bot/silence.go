@@ -0,0 +1,211 @@
+package bot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"system-monitor-bot/internal/monitor"
+	"system-monitor-bot/pkg/logger"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// silencedSensor is one entry of the silenced sensors file; a nil Until
+// silences the sensor until it is unsilenced
+type silencedSensor struct {
+	ID    string     `json:"id"`
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// sensorSilences is the runtime set of sensors excluded from alerting,
+// saved to path (when set) so silences survive a restart
+type sensorSilences struct {
+	mu    sync.Mutex
+	path  string
+	until map[string]time.Time // sensor ID -> expiry; zero means indefinite
+}
+
+// loadSensorSilences reads the silenced sensors file at path; a missing file
+// starts an empty set
+func loadSensorSilences(path string) (*sensorSilences, error) {
+	silences := &sensorSilences{path: path, until: make(map[string]time.Time)}
+	if path == "" {
+		logger.Info("No silenced sensors file configured - silences will not persist")
+		return silences, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		logger.Info("Silenced sensors file", path, "does not exist yet - starting empty")
+		return silences, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read silenced sensors file: %w", err)
+	}
+
+	var entries []silencedSensor
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid silenced sensors file %s: %w", path, err)
+	}
+	for _, entry := range entries {
+		var until time.Time
+		if entry.Until != nil {
+			until = *entry.Until
+		}
+		silences.until[entry.ID] = until
+	}
+
+	logger.Info("Loaded", len(entries), "silenced sensors from", path)
+	return silences, nil
+}
+
+// silence excludes a sensor from alerting for duration, or indefinitely
+// when duration is zero
+func (ss *sensorSilences) silence(id string, duration time.Duration) time.Time {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	var until time.Time
+	if duration > 0 {
+		until = time.Now().Add(duration)
+	}
+	ss.until[id] = until
+	logger.Info("Silenced sensor", id, "until:", until)
+	ss.save()
+	return until
+}
+
+// unsilence returns a sensor to alerting, reporting whether it was silenced
+func (ss *sensorSilences) unsilence(id string) bool {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if _, exists := ss.until[id]; !exists {
+		return false
+	}
+	delete(ss.until, id)
+	logger.Info("Unsilenced sensor:", id)
+	ss.save()
+	return true
+}
+
+// apply marks silenced sensors as excluded from alerts, dropping silences
+// that have expired
+func (ss *sensorSilences) apply(sensors []monitor.TemperatureSensor) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	expired := false
+	for id, until := range ss.until {
+		if !until.IsZero() && time.Now().After(until) {
+			logger.Info("Silence expired for sensor:", id)
+			delete(ss.until, id)
+			expired = true
+		}
+	}
+	if expired {
+		ss.save()
+	}
+
+	for idx := range sensors {
+		if _, silenced := ss.until[sensors[idx].ID]; silenced {
+			sensors[idx].ExcludeFromAlerts = true
+		}
+	}
+}
+
+// count returns how many sensors are currently silenced
+func (ss *sensorSilences) count() int {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return len(ss.until)
+}
+
+// save writes the set to the silenced sensors file; callers hold mu. A
+// failed write is logged so the in-memory silence still takes effect.
+func (ss *sensorSilences) save() {
+	if ss.path == "" {
+		return
+	}
+
+	entries := make([]silencedSensor, 0, len(ss.until))
+	for id, until := range ss.until {
+		entry := silencedSensor{ID: id}
+		if !until.IsZero() {
+			expiry := until
+			entry.Until = &expiry
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		logger.Error("Failed to encode silenced sensors:", err)
+		return
+	}
+	// Write then rename so a crash never leaves a truncated file
+	tmp := ss.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		logger.Error("Failed to write silenced sensors file:", err)
+		return
+	}
+	if err := os.Rename(tmp, ss.path); err != nil {
+		logger.Error("Failed to replace silenced sensors file:", err)
+		return
+	}
+	logger.Info("Saved", len(entries), "silenced sensors to", ss.path)
+}
+
+// handleSilenceAction silences or unsilences the sensor named in the
+// /alerts options and returns the response text
+func (sm *SystemMonitor) handleSilenceAction(action string, options []*discordgo.ApplicationCommandInteractionDataOption) string {
+	var sensorID string
+	minutes := 0
+	for _, opt := range options {
+		switch opt.Name {
+		case "sensor":
+			sensorID = strings.TrimSpace(opt.StringValue())
+		case "duration":
+			var err error
+			if minutes, err = validateIntOption(opt, minSnoozeMinutes, maxSnoozeMinutes); err != nil {
+				return fmt.Sprintf("❌ %v", err)
+			}
+		}
+	}
+	if sensorID == "" {
+		return fmt.Sprintf("ℹ️ Specify the sensor to %s with the `sensor` option - IDs are shown by `/sensors list`.", action)
+	}
+
+	if action == "unsilence" {
+		if !sm.silences.unsilence(sensorID) {
+			return fmt.Sprintf("ℹ️ Sensor `%s` is not silenced.", sensorID)
+		}
+		return fmt.Sprintf("🔔 **Sensor `%s` unsilenced** - it counts toward alerts again.", sensorID)
+	}
+
+	sensors := sm.sensorSnapshot()
+	name, known := sensorID, len(sensors) == 0
+	for _, sensor := range sensors {
+		if sensor.ID == sensorID {
+			name, known = sensor.Name, true
+			break
+		}
+	}
+	if !known {
+		logger.Info("Silence requested for unknown sensor:", sensorID)
+		return fmt.Sprintf("❌ No sensor with ID `%s` - IDs are shown by `/sensors list`.", sensorID)
+	}
+
+	until := sm.silences.silence(sensorID, time.Duration(minutes)*time.Minute)
+	if until.IsZero() {
+		return fmt.Sprintf("🔕 **%s** (`%s`) silenced in every channel until `/alerts unsilence`.", name, sensorID)
+	}
+	return fmt.Sprintf("🔕 **%s** (`%s`) silenced in every channel.\n\n🔔 It counts toward alerts again %s",
+		name, sensorID, sm.embedBuilder.FormatTime(until))
+}
@@ -0,0 +1,36 @@
+package bot
+
+import (
+	"system-monitor-bot/internal/metrics"
+	"system-monitor-bot/pkg/logger"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// alertSink delivers alerts somewhere other than the Discord alert channels
+type alertSink interface {
+	Name() string
+	Send(embed *discordgo.MessageEmbed, text string) error
+}
+
+// hasAlertTargets reports whether any alert channel or sink would receive
+// an alert
+func (sm *SystemMonitor) hasAlertTargets() bool {
+	return sm.alertChannelCount() > 0 || len(sm.sinks) > 0
+}
+
+// notifySinks hands an alert to every configured sink in the background so
+// a slow or unreachable sink never holds up Discord delivery
+func (sm *SystemMonitor) notifySinks(embed *discordgo.MessageEmbed, text string) {
+	for _, sink := range sm.sinks {
+		go func(sink alertSink) {
+			logger.Info("Sending alert to", sink.Name(), "sink")
+			if err := sink.Send(embed, text); err != nil {
+				logger.Error("Failed to send alert to", sink.Name(), "sink:", err)
+				metrics.SinkSendFailures.Inc()
+				return
+			}
+			logger.Info("Alert sent successfully to", sink.Name(), "sink")
+		}(sink)
+	}
+}
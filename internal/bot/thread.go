@@ -0,0 +1,127 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"system-monitor-bot/pkg/logger"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// alertThreadArchiveMinutes is how long an alert thread stays open without
+// messages; Discord only accepts 60, 1440, 4320 or 10080
+const alertThreadArchiveMinutes = 10080
+
+// maxThreadNameLength is Discord's limit on thread names
+const maxThreadNameLength = 100
+
+// alertThread is a thread that receives a channel's alerts in place of the
+// channel itself; Name is kept so the thread can be recreated
+type alertThread struct {
+	ID   string
+	Name string
+}
+
+// alertThreads maps alert channels to their delivery thread. Alerts are
+// sent from several workers at once and may recreate a thread, so access
+// is locked.
+type alertThreads struct {
+	mu      sync.Mutex
+	threads map[string]alertThread // parent channel ID -> thread
+}
+
+func newAlertThreads() *alertThreads {
+	return &alertThreads{threads: make(map[string]alertThread)}
+}
+
+func (at *alertThreads) get(channelID string) (alertThread, bool) {
+	at.mu.Lock()
+	defer at.mu.Unlock()
+	thread, ok := at.threads[channelID]
+	return thread, ok
+}
+
+func (at *alertThreads) set(channelID string, thread alertThread) {
+	at.mu.Lock()
+	defer at.mu.Unlock()
+	at.threads[channelID] = thread
+}
+
+func (at *alertThreads) remove(channelID string) {
+	at.mu.Lock()
+	defer at.mu.Unlock()
+	delete(at.threads, channelID)
+}
+
+func (at *alertThreads) count() int {
+	at.mu.Lock()
+	defer at.mu.Unlock()
+	return len(at.threads)
+}
+
+// startAlertThread creates a public thread in channelID for its alerts
+func startAlertThread(s discordSession, channelID, name string) (alertThread, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return alertThread{}, fmt.Errorf("thread name must not be empty")
+	}
+	if len(name) > maxThreadNameLength {
+		return alertThread{}, fmt.Errorf("thread name must be at most %d characters", maxThreadNameLength)
+	}
+
+	logger.Info("Creating alert thread", name, "in channel:", channelID)
+	thread, err := s.ThreadStart(channelID, name, discordgo.ChannelTypeGuildPublicThread, alertThreadArchiveMinutes)
+	if err != nil {
+		return alertThread{}, fmt.Errorf("failed to create thread: %w", err)
+	}
+	logger.Info("Created alert thread", thread.ID, "in channel:", channelID)
+	return alertThread{ID: thread.ID, Name: name}, nil
+}
+
+// alertDestination is where channelID's alerts are posted: its alert
+// thread when it has one, else the channel itself
+func (sm *SystemMonitor) alertDestination(channelID string) string {
+	if thread, ok := sm.alertThreads.get(channelID); ok {
+		return thread.ID
+	}
+	return channelID
+}
+
+// postAlert sends message to channelID's alert destination
+func (sm *SystemMonitor) postAlert(channelID string, message *discordgo.MessageSend) (*discordgo.Message, error) {
+	if thread, ok := sm.alertThreads.get(channelID); ok {
+		return sm.sendToAlertThread(channelID, thread, message)
+	}
+	return sm.discord.ChannelMessageSendComplex(channelID, message)
+}
+
+// sendToAlertThread posts an alert into the channel's thread. An archived
+// thread is unarchived and a deleted or locked one recreated, after which
+// the send is retried once.
+func (sm *SystemMonitor) sendToAlertThread(channelID string, thread alertThread, message *discordgo.MessageSend) (*discordgo.Message, error) {
+	msg, err := sm.discord.ChannelMessageSendComplex(thread.ID, message)
+	switch {
+	case err == nil:
+		return msg, nil
+	case isDiscordError(err, discordgo.ErrCodePerformedOperationOnArchivedThread):
+		logger.Info("Alert thread", thread.ID, "is archived - unarchiving")
+		archived := false
+		_, editErr := sm.discord.ChannelEdit(thread.ID, &discordgo.ChannelEdit{Archived: &archived})
+		if editErr == nil {
+			return sm.discord.ChannelMessageSendComplex(thread.ID, message)
+		}
+		logger.Warn("Failed to unarchive alert thread", thread.ID, "error:", editErr, "- recreating it")
+	case isDiscordError(err, discordgo.ErrCodeUnknownChannel), isDiscordError(err, discordgo.ErrCodeThreadIsLocked):
+		logger.Warn("Alert thread", thread.ID, "is gone or locked - recreating it")
+	default:
+		return nil, err
+	}
+
+	replacement, startErr := startAlertThread(sm.discord, channelID, thread.Name)
+	if startErr != nil {
+		return nil, fmt.Errorf("alert thread unavailable (%v) and could not be recreated: %w", err, startErr)
+	}
+	sm.alertThreads.set(channelID, replacement)
+	return sm.discord.ChannelMessageSendComplex(replacement.ID, message)
+}
@@ -0,0 +1,62 @@
+package bot
+
+import (
+	"sync"
+	"system-monitor-bot/internal/monitor"
+	"system-monitor-bot/pkg/logger"
+)
+
+// sensorTrends keeps each sensor's temperature from the last monitoring
+// cycle so command output can show which way it has moved since. The
+// monitoring loop records while command handlers annotate, so access is
+// guarded by mu.
+type sensorTrends struct {
+	mu       sync.RWMutex
+	previous map[string]float64 // sensor ID -> °C
+}
+
+func newSensorTrends() *sensorTrends {
+	return &sensorTrends{previous: make(map[string]float64)}
+}
+
+// record replaces the stored readings with this cycle's
+func (st *sensorTrends) record(sensors []monitor.TemperatureSensor) {
+	readings := make(map[string]float64, len(sensors))
+	for _, sensor := range sensors {
+		readings[sensor.ID] = sensor.Temperature
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.previous = readings
+}
+
+// reset drops the stored readings, returning how many sensors had one; the
+// next recorded cycle starts the trends afresh
+func (st *sensorTrends) reset() int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	cleared := len(st.previous)
+	st.previous = make(map[string]float64)
+	return cleared
+}
+
+// annotate sets each sensor's delta from the last recorded cycle; sensors
+// without an earlier reading, as on the first cycle, are left without one
+func (st *sensorTrends) annotate(sensors []monitor.TemperatureSensor) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	annotated := 0
+	for idx := range sensors {
+		previous, exists := st.previous[sensors[idx].ID]
+		if !exists {
+			continue
+		}
+		sensors[idx].Delta = sensors[idx].Temperature - previous
+		sensors[idx].HasDelta = true
+		annotated++
+	}
+	logger.Info("Annotated", annotated, "of", len(sensors), "sensors with their change since the last cycle")
+}
@@ -0,0 +1,92 @@
+package bot
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"system-monitor-bot/internal/config"
+	"system-monitor-bot/pkg/logger"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// alertWebhook is a Discord webhook used as a fallback alert delivery path
+type alertWebhook struct {
+	ID    string
+	Token string
+}
+
+// parseWebhookURL extracts the ID and token from a Discord webhook URL such
+// as https://discord.com/api/webhooks/<id>/<token>
+func parseWebhookURL(raw string) (alertWebhook, error) {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return alertWebhook{}, fmt.Errorf("invalid webhook URL: %v", err)
+	}
+	host := strings.TrimPrefix(parsed.Hostname(), "canary.")
+	host = strings.TrimPrefix(host, "ptb.")
+	if parsed.Scheme != "https" || (host != "discord.com" && host != "discordapp.com") {
+		return alertWebhook{}, fmt.Errorf("webhook URL must be an https://discord.com/api/webhooks/ URL")
+	}
+
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) != 4 || parts[0] != "api" || parts[1] != "webhooks" || parts[2] == "" || parts[3] == "" {
+		return alertWebhook{}, fmt.Errorf("webhook URL must look like https://discord.com/api/webhooks/<id>/<token>")
+	}
+	return alertWebhook{ID: parts[2], Token: parts[3]}, nil
+}
+
+// sendAlert posts an alert to a channel, or its alert thread, in the
+// configured format, retrying through the channel's webhook when the bot
+// session cannot deliver it
+func (sm *SystemMonitor) sendAlert(channelID string, embed *discordgo.MessageEmbed, text string) error {
+	_, err := sm.sendAlertMessage(channelID, embed, text)
+	return err
+}
+
+// alertContent returns the content and embeds of an alert message in the
+// configured format
+func (sm *SystemMonitor) alertContent(embed *discordgo.MessageEmbed, text string) (string, []*discordgo.MessageEmbed) {
+	switch sm.config.Monitor.AlertFormat {
+	case config.AlertFormatText:
+		return text, []*discordgo.MessageEmbed{}
+	case config.AlertFormatBoth:
+		return text, []*discordgo.MessageEmbed{embed}
+	default:
+		return "", []*discordgo.MessageEmbed{embed}
+	}
+}
+
+// sendAlertMessage is sendAlert returning the posted message, which is nil
+// when the webhook fallback delivered it
+func (sm *SystemMonitor) sendAlertMessage(channelID string, embed *discordgo.MessageEmbed, text string) (*discordgo.Message, error) {
+	message := &discordgo.MessageSend{}
+	message.Content, message.Embeds = sm.alertContent(embed, text)
+
+	msg, err := sm.postAlert(channelID, message)
+	if err == nil {
+		return msg, nil
+	}
+
+	webhook, exists := sm.alertWebhook(channelID)
+	if !exists {
+		return nil, err
+	}
+
+	logger.Warn("Alert send to channel", channelID, "failed:", err, "- retrying via webhook")
+	params := &discordgo.WebhookParams{
+		Content: message.Content,
+		Embeds:  message.Embeds,
+	}
+	var webhookErr error
+	if thread, inThread := sm.alertThreads.get(channelID); inThread {
+		_, webhookErr = sm.discord.WebhookThreadExecute(webhook.ID, webhook.Token, false, thread.ID, params)
+	} else {
+		_, webhookErr = sm.discord.WebhookExecute(webhook.ID, webhook.Token, false, params)
+	}
+	if webhookErr != nil {
+		return nil, fmt.Errorf("channel send failed (%v) and webhook fallback failed: %w", err, webhookErr)
+	}
+	logger.Info("Alert delivered to channel", channelID, "via webhook fallback")
+	return nil, nil
+}
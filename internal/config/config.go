@@ -1,8 +1,16 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"net"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"system-monitor-bot/internal/monitor"
 	"system-monitor-bot/pkg/logger"
 	"time"
 )
@@ -11,31 +19,434 @@ type Config struct {
 	Discord    DiscordConfig
 	Monitor    MonitorConfig
 	Thresholds ThresholdConfig
+	Display    DisplayConfig
+	Matrix     MatrixConfig
+	Log        LogConfig
+	Fleet      FleetConfig
+	Remote     RemoteConfig
 }
 
 type DiscordConfig struct {
-	Token   string
-	GuildID string
+	Token       string
+	GuildID     string
+	ProxyURL    string
+	HTTPTimeout time.Duration
+
+	// PerGuildCommands registers commands in every guild the bot is in,
+	// including guilds joined while running, instead of globally
+	PerGuildCommands bool
+
+	// Status is the presence text; with DynamicStatus it is replaced by
+	// the current max temperature each monitoring cycle
+	Status        string
+	DynamicStatus bool
+
+	// ReadOnly registers only commands that do not change bot state
+	ReadOnly bool
+
+	// TrackCommandUsers also counts command invocations per user
+	TrackCommandUsers bool
+
+	// DeferThreshold is how long a command may run without answering
+	// before the bot defers it, keeping Discord's three second window
+	DeferThreshold time.Duration
+}
+
+const defaultBotStatus = "⚡ System Monitor Active"
+
+// Bounds for MONITOR_INTERVAL; shorter intervals would run sensors and top
+// back to back
+const (
+	defaultMonitorInterval = 30 * time.Second
+	minMonitorInterval     = time.Second
+	maxMonitorInterval     = time.Hour
+)
+
+// Bounds for ALERT_COOLDOWN; zero sends every alert
+const (
+	defaultAlertCooldown = 5 * time.Minute
+	maxAlertCooldown     = 24 * time.Hour
+)
+
+// Bounds for SAMPLE_WINDOW; very short windows give noisy readings and long
+// ones hold up command responses
+const (
+	defaultSampleWindow = 500 * time.Millisecond
+	minSampleWindow     = 100 * time.Millisecond
+	maxSampleWindow     = 10 * time.Second
+)
+
+// Bounds for NETWORK_INTERVAL
+const (
+	defaultNetworkInterval = 2 * time.Minute
+	minNetworkInterval     = 10 * time.Second
+	maxNetworkInterval     = time.Hour
+)
+
+// Bounds for PROC_SCAN_WORKERS
+const (
+	defaultProcScanWorkers = 16
+	maxProcScanWorkers     = 256
+)
+
+// Bounds for RECOVERY_CYCLES; 1 declares recovery on the first normal cycle
+const maxRecoveryCycles = 100
+
+// Bounds for INTERACTION_DEFER_THRESHOLD; the deferral itself needs time to
+// reach Discord before the three second interaction window closes
+const (
+	defaultDeferThreshold = 1500 * time.Millisecond
+	minDeferThreshold     = 100 * time.Millisecond
+	maxDeferThreshold     = 2500 * time.Millisecond
+)
+
+// MatrixConfig is the optional Matrix room that also receives alerts; an
+// empty Homeserver disables it
+type MatrixConfig struct {
+	Homeserver string
+	Token      string
+	RoomID     string
 }
 
+// Enabled reports whether a Matrix room is configured
+func (mc MatrixConfig) Enabled() bool {
+	return mc.Homeserver != ""
+}
+
+// FleetConfig federates bot instances: each may serve a JSON snapshot of
+// its host next to the metrics endpoint, and an aggregator lists the peers
+// whose snapshots /fleet compares
+type FleetConfig struct {
+	// Name identifies this host in fleet comparisons; defaults to the hostname
+	Name string
+
+	// ServeSnapshot exposes /snapshot on METRICS_ADDR
+	ServeSnapshot bool
+
+	// Peers are the base URLs of other instances, e.g. http://db1:9100
+	Peers []string
+
+	// Token, when set, is required by /snapshot and sent to peers
+	Token string
+}
+
+// RemoteConfig is the optional host whose sensors, ports and processes are
+// read over SSH with key-based auth; an empty Host reads this machine
+type RemoteConfig struct {
+	// Host is host:port, the port defaulting to 22
+	Host    string
+	User    string
+	KeyFile string
+
+	// KnownHostsFile verifies the host key; defaults to ~/.ssh/known_hosts
+	KnownHostsFile string
+}
+
+// Enabled reports whether a remote host is configured
+func (rc RemoteConfig) Enabled() bool {
+	return rc.Host != ""
+}
+
+// LogConfig is the optional log file; an empty File logs to the console only
+type LogConfig struct {
+	File string
+
+	// MaxSizeMB is the size at which the file is rotated and MaxFiles how
+	// many rotated copies are kept
+	MaxSizeMB int
+	MaxFiles  int
+
+	// FileOnly stops console output once the log file is open
+	FileOnly bool
+}
+
+// Bounds for LOG_MAX_SIZE_MB and LOG_MAX_FILES
+const (
+	maxLogSizeMB = 1024
+	maxLogFiles  = 100
+)
+
 type MonitorConfig struct {
-	Interval      time.Duration
-	AlertCooldown time.Duration
+	Interval       time.Duration
+	AlertCooldown  time.Duration
+	AlertOnBattery bool
+	AlertMode      string
+
+	// SampleWindow is how long rate-based readings such as CPU load sample
+	// for. Longer windows smooth out bursts and give steadier figures, but
+	// every command that takes a sample responds that much later.
+	SampleWindow time.Duration
+
+	// NetworkInterval is how often listening ports are collected in the
+	// background; ports change far less often than temperatures
+	NetworkInterval time.Duration
+
+	// ProcScanWorkers bounds how many /proc/<pid> entries are read at once
+	// when collecting per-process memory and file descriptors
+	ProcScanWorkers int
+
+	// MissingSensorCycles is how many consecutive cycles a previously seen
+	// sensor must be absent before alerting; 0 disables the alert
+	MissingSensorCycles int
+	AnnounceNewSensors  bool
+
+	// AllowedAlertChannels restricts /alerts enable to these channel IDs;
+	// empty allows any channel
+	AllowedAlertChannels []string
+
+	// DefaultAlertChannel has alerts enabled at startup without running
+	// /alerts enable; empty enables none
+	DefaultAlertChannel string
+
+	// AlertPolicy decides when warning alerts fire; AlertMinSensors is the
+	// sensor count the count policy needs
+	AlertPolicy     string
+	AlertMinSensors int
+
+	// AlertFormat selects embeds, plain text or both for broadcast alerts
+	AlertFormat string
+
+	// CooldownPolicy decides whether AlertCooldown also delays the first
+	// alert of a new temperature condition
+	CooldownPolicy string
+
+	// RecoveryCycles is how many consecutive normal cycles an alert
+	// condition must see before it clears and, in evolving mode, its
+	// recovery is announced
+	RecoveryCycles int
+
+	// SilencedSensorsFile persists sensors silenced with /alerts silence;
+	// empty keeps silences in memory only
+	SilencedSensorsFile string
+
+	// SudoCommands lists external commands to run via `sudo -n`
+	SudoCommands []string
+
+	// MetricsAddr is the listen address for the Prometheus endpoint; empty disables it
+	MetricsAddr string
+
+	// AlertOutput receives alerts as JSON lines: AlertOutputStdout or a
+	// file or named pipe path. Empty disables it.
+	AlertOutput string
+
+	// WatchedPorts must stay listening; each one that closes raises an
+	// alert. Empty disables port monitoring.
+	WatchedPorts []monitor.WatchedPort
+
+	// TempBackend reads temperatures: monitor.TempBackendAuto,
+	// TempBackendSensors or TempBackendGopsutil
+	TempBackend string
+}
+
+// Alert delivery modes
+const (
+	// AlertModeRepeat posts a new message for every alert
+	AlertModeRepeat = "repeat"
+
+	// AlertModeEvolving edits a single message per channel while an alert
+	// condition lasts and posts a new one on recovery
+	AlertModeEvolving = "evolving"
+)
+
+// Warning alert policies; a critical sensor always alerts on its own
+const (
+	// AlertPolicyMax alerts when the hottest sensor reaches warning
+	AlertPolicyMax = "max"
+
+	// AlertPolicyCount alerts when at least AlertMinSensors sensors are at
+	// warning or above
+	AlertPolicyCount = "count"
+
+	// AlertPolicyAverage alerts when the average of the alerting sensors
+	// reaches warning
+	AlertPolicyAverage = "average"
+)
+
+// Cooldown policies for temperature alerts
+const (
+	// CooldownPolicyAlways holds back any alert sent within the cooldown
+	// of the previous one
+	CooldownPolicyAlways = "always"
+
+	// CooldownPolicyRepeats sends the first alert of a new condition at
+	// once and only throttles updates while that condition lasts
+	CooldownPolicyRepeats = "repeats"
+)
+
+// AlertOutputStdout writes JSON alert records to stdout
+const AlertOutputStdout = "stdout"
+
+// Alert message formats
+const (
+	AlertFormatEmbed = "embed"
+
+	// AlertFormatText posts alerts as plain text for tooling that cannot
+	// read embeds
+	AlertFormatText = "text"
+
+	// AlertFormatBoth posts the plain text alongside the embed
+	AlertFormatBoth = "both"
+)
+
+type DisplayConfig struct {
+	Location *time.Location
+	Limits   DisplayLimits
+
+	// ProcessAliasesFile is an optional JSON file of extra process names
+	ProcessAliasesFile string
+
+	// ExcludeSelf hides the bot's own process and sockets from views
+	ExcludeSelf bool
+
+	// ShowTopProcess adds the top CPU-consuming process to temperature
+	// views, to show what is heating the CPU
+	ShowTopProcess bool
+
+	// TimeStyle selects absolute, relative or both for times shown in embeds
+	TimeStyle string
+
+	// CategoryEmoji maps sensor categories to custom server emoji used in
+	// the hardware overview, e.g. "CPU" -> "<:cpu:1234567890>"
+	CategoryEmoji map[string]string
+
+	// RateUnit shows network throughput in bits (Mbps) or bytes (MB/s) per
+	// second, with RatePrecision decimals
+	RateUnit      string
+	RatePrecision int
+}
+
+// Network throughput display units
+const (
+	RateUnitBits  = "bits"
+	RateUnitBytes = "bytes"
+)
+
+// maxRatePrecision bounds DISPLAY_RATE_PRECISION
+const maxRatePrecision = 3
+
+// customEmojiRegex matches a Discord custom emoji reference, static or animated
+var customEmojiRegex = regexp.MustCompile(`^<a?:[A-Za-z0-9_]{2,32}:\d{17,20}>$`)
+
+// Embed time display styles
+const (
+	// TimeStyleAbsolute shows the date and time in the display time zone
+	TimeStyleAbsolute = "absolute"
+
+	// TimeStyleRelative shows a Discord relative timestamp ("5 minutes ago")
+	TimeStyleRelative = "relative"
+
+	// TimeStyleBoth shows the absolute time followed by the relative one
+	TimeStyleBoth = "both"
+)
+
+// Discord hard limits for embeds
+const (
+	DiscordMaxEmbedFields      = 25
+	DiscordMaxFieldNameChars   = 256
+	DiscordMaxFieldValueChars  = 1024
+	DiscordMaxDescriptionChars = 4096
+	DiscordMaxMessageChars     = 2000
+	DiscordMaxEmbedChars       = 6000
+)
+
+// DisplayLimits controls how many items are shown in embeds
+type DisplayLimits struct {
+	MaxSensorFields  int // Individual sensor fields in /temp
+	MaxPortFields    int // Port list fields in /ports
+	MaxPortsPerField int // Ports listed in a single /ports field
+	MaxProcesses     int // Processes shown in /top
+	MemoryTopN       int // Processes shown in /memory unless its count option is set
+	MaxAlertSensors  int // Sensors listed in an alert embed
+	MaxPortMessages  int // Messages /ports may spread its list over; 1 truncates
+}
+
+// MaxMemoryTopN is the most processes /memory lists, leaving a field for
+// the memory totals
+const MaxMemoryTopN = DiscordMaxEmbedFields - 1
+
+// DefaultDisplayLimits returns the limits used when none are configured
+func DefaultDisplayLimits() DisplayLimits {
+	return DisplayLimits{
+		MaxSensorFields:  23,
+		MaxPortFields:    12,
+		MaxPortsPerField: 6,
+		MaxProcesses:     10,
+		MemoryTopN:       10,
+		MaxAlertSensors:  15,
+		MaxPortMessages:  1,
+	}
+}
+
+// Validate ensures the limits stay within Discord's hard limits, leaving
+// room for the overview, summary and truncation fields each embed adds
+func (dl DisplayLimits) Validate() error {
+	checks := []struct {
+		name  string
+		value int
+		max   int
+	}{
+		{"DISPLAY_MAX_SENSORS", dl.MaxSensorFields, DiscordMaxEmbedFields - 2},
+		{"DISPLAY_MAX_PORT_FIELDS", dl.MaxPortFields, DiscordMaxEmbedFields - 2},
+		{"DISPLAY_MAX_PORTS_PER_FIELD", dl.MaxPortsPerField, 15},
+		{"DISPLAY_MAX_PROCESSES", dl.MaxProcesses, DiscordMaxEmbedFields - 1},
+		{"MEMORY_TOP_N", dl.MemoryTopN, MaxMemoryTopN},
+		{"DISPLAY_MAX_ALERT_SENSORS", dl.MaxAlertSensors, 20},
+		{"DISPLAY_MAX_PORT_MESSAGES", dl.MaxPortMessages, 10},
+	}
+
+	for _, check := range checks {
+		if check.value < 1 || check.value > check.max {
+			return fmt.Errorf("%s must be between 1 and %d, got %d", check.name, check.max, check.value)
+		}
+	}
+	return nil
 }
 
 type ThresholdConfig struct {
 	Critical float64
 	Warning  float64
+
+	// Levels are the named severities in ascending threshold order; the
+	// Warning and Critical entries match the thresholds above
+	Levels []monitor.SeverityLevel
+
+	// Sensors overrides Warning and Critical for individual sensor IDs
+	Sensors map[string]monitor.SensorThresholds
+
+	// Hysteresis is how far in °C a sensor must drop below the warning or
+	// critical threshold it crossed before it counts as recovered
+	Hysteresis float64
+
+	// FileDescriptorPercent alerts when system-wide fd usage exceeds this
+	// percentage of the maximum; zero disables the alert
+	FileDescriptorPercent float64
+
+	// MemoryPressurePercent alerts when tasks stalled on memory for more
+	// than this share of the last minute (PSI "some" avg60); zero disables
+	// the alert
+	MemoryPressurePercent float64
+
+	// LowBatteryPercent alerts when a battery or UPS drops below this
+	// charge percentage; zero disables the alert
+	LowBatteryPercent float64
+
+	// DiskFillWindow alerts when a filesystem is projected to fill up within
+	// this duration at its current growth rate; zero disables the alert
+	DiskFillWindow time.Duration
 }
 
 func Load() (*Config, error) {
 	logger.Info("Loading configuration from environment variables...")
 
 	logger.Info("Reading DISCORD_BOT_TOKEN...")
-	botToken := os.Getenv("DISCORD_BOT_TOKEN")
+	botToken, err := getEnvSecret("DISCORD_BOT_TOKEN")
+	if err != nil {
+		logger.Error("Failed to read Discord bot token:", err)
+		return nil, err
+	}
 	if botToken == "" {
-		logger.Error("DISCORD_BOT_TOKEN environment variable is not set")
-		return nil, fmt.Errorf("DISCORD_BOT_TOKEN environment variable is required")
+		logger.Error("Neither DISCORD_BOT_TOKEN nor DISCORD_BOT_TOKEN_FILE is set")
+		return nil, fmt.Errorf("DISCORD_BOT_TOKEN or DISCORD_BOT_TOKEN_FILE is required")
 	}
 	logger.Info("Discord bot token loaded successfully (length:", len(botToken), "characters)")
 
@@ -47,26 +458,1051 @@ func Load() (*Config, error) {
 		logger.Info("No guild ID specified - commands will be global")
 	}
 
+	logger.Info("Reading DISCORD_PER_GUILD_COMMANDS...")
+	perGuildCommands, err := getEnvBool("DISCORD_PER_GUILD_COMMANDS", false)
+	if err != nil {
+		logger.Error("Invalid DISCORD_PER_GUILD_COMMANDS value:", err)
+		return nil, err
+	}
+	if perGuildCommands && guildID != "" {
+		logger.Error("DISCORD_PER_GUILD_COMMANDS set together with DISCORD_GUILD_ID")
+		return nil, fmt.Errorf("DISCORD_PER_GUILD_COMMANDS registers commands in every guild and cannot be combined with DISCORD_GUILD_ID")
+	}
+
+	logger.Info("Reading DISCORD_PROXY...")
+	proxyURL, err := getEnvSecret("DISCORD_PROXY")
+	if err != nil {
+		logger.Error("Failed to read Discord proxy:", err)
+		return nil, err
+	}
+	if proxyURL != "" {
+		logger.Info("Discord proxy override configured")
+	} else {
+		logger.Info("No proxy override specified - HTTPS_PROXY will be honored if set")
+	}
+
+	readOnly, err := getEnvBool("READ_ONLY", false)
+	if err != nil {
+		logger.Error("Invalid READ_ONLY value:", err)
+		return nil, err
+	}
+
+	trackCommandUsers, err := getEnvBool("COMMAND_STATS_PER_USER", false)
+	if err != nil {
+		logger.Error("Invalid COMMAND_STATS_PER_USER value:", err)
+		return nil, err
+	}
+
+	logger.Info("Reading BOT_STATUS...")
+	botStatus := os.Getenv("BOT_STATUS")
+	if botStatus == "" {
+		botStatus = defaultBotStatus
+		logger.Info("No bot status specified - using default:", botStatus)
+	} else {
+		logger.Info("Bot status loaded:", botStatus)
+	}
+	dynamicStatus, err := getEnvBool("BOT_STATUS_DYNAMIC", false)
+	if err != nil {
+		logger.Error("Invalid BOT_STATUS_DYNAMIC value:", err)
+		return nil, err
+	}
+
+	logger.Info("Reading DISPLAY_TZ...")
+	location := time.Local
+	if tz := os.Getenv("DISPLAY_TZ"); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			logger.Error("Invalid DISPLAY_TZ value:", tz, "error:", err)
+			return nil, fmt.Errorf("invalid DISPLAY_TZ %q: %w", tz, err)
+		}
+		location = loc
+		logger.Info("Display time zone loaded:", location)
+	} else {
+		logger.Info("No display time zone specified - using server local time")
+	}
+
+	logger.Info("Reading DISPLAY_TIME_STYLE...")
+	timeStyle := os.Getenv("DISPLAY_TIME_STYLE")
+	switch timeStyle {
+	case "":
+		timeStyle = TimeStyleBoth
+		logger.Info("No time style specified - using default:", timeStyle)
+	case TimeStyleAbsolute, TimeStyleRelative, TimeStyleBoth:
+		logger.Info("Time style loaded:", timeStyle)
+	default:
+		logger.Error("Invalid DISPLAY_TIME_STYLE value:", timeStyle)
+		return nil, fmt.Errorf("DISPLAY_TIME_STYLE must be %q, %q or %q, got %q", TimeStyleAbsolute, TimeStyleRelative, TimeStyleBoth, timeStyle)
+	}
+
+	logger.Info("Reading DISPLAY_RATE_UNIT...")
+	rateUnit := os.Getenv("DISPLAY_RATE_UNIT")
+	switch rateUnit {
+	case "":
+		rateUnit = RateUnitBits
+		logger.Info("No rate unit specified - using default:", rateUnit)
+	case RateUnitBits, RateUnitBytes:
+		logger.Info("Rate unit loaded:", rateUnit)
+	default:
+		logger.Error("Invalid DISPLAY_RATE_UNIT value:", rateUnit)
+		return nil, fmt.Errorf("DISPLAY_RATE_UNIT must be %q or %q, got %q", RateUnitBits, RateUnitBytes, rateUnit)
+	}
+
+	logger.Info("Reading DISPLAY_RATE_PRECISION...")
+	ratePrecision, err := getEnvInt("DISPLAY_RATE_PRECISION", 1)
+	if err != nil {
+		logger.Error("Invalid DISPLAY_RATE_PRECISION value:", err)
+		return nil, err
+	}
+	if ratePrecision < 0 || ratePrecision > maxRatePrecision {
+		logger.Error("DISPLAY_RATE_PRECISION out of range:", ratePrecision)
+		return nil, fmt.Errorf("DISPLAY_RATE_PRECISION must be between 0 and %d, got %d", maxRatePrecision, ratePrecision)
+	}
+
+	categoryEmoji, err := parseCategoryEmoji(os.Getenv("CATEGORY_EMOJI"))
+	if err != nil {
+		logger.Error("Invalid CATEGORY_EMOJI value:", err)
+		return nil, err
+	}
+
+	logger.Info("Reading temperature thresholds...")
+	critical, err := getEnvTemperature("TEMP_CRITICAL", 80.0)
+	if err != nil {
+		logger.Error("Invalid TEMP_CRITICAL value:", err)
+		return nil, err
+	}
+	warning, err := getEnvTemperature("TEMP_WARNING", 70.0)
+	if err != nil {
+		logger.Error("Invalid TEMP_WARNING value:", err)
+		return nil, err
+	}
+	if warning >= critical {
+		logger.Error("Warning threshold", warning, "must be below critical threshold", critical)
+		return nil, fmt.Errorf("TEMP_WARNING (%.1f°C) must be below TEMP_CRITICAL (%.1f°C)", warning, critical)
+	}
+
+	levels := monitor.DefaultSeverityLevels(warning, critical)
+	if levelsFile := os.Getenv("SEVERITY_LEVELS_FILE"); levelsFile != "" {
+		// The file defines every threshold; also setting the env thresholds
+		// is rejected rather than silently ignoring one of them
+		if os.Getenv("TEMP_WARNING") != "" || os.Getenv("TEMP_CRITICAL") != "" {
+			logger.Error("SEVERITY_LEVELS_FILE set together with TEMP_WARNING or TEMP_CRITICAL")
+			return nil, fmt.Errorf("set warning and critical thresholds in SEVERITY_LEVELS_FILE or in TEMP_WARNING/TEMP_CRITICAL, not both")
+		}
+		if levels, err = loadSeverityLevels(levelsFile); err != nil {
+			logger.Error("Failed to load severity levels:", err)
+			return nil, err
+		}
+		for _, level := range levels {
+			switch {
+			case strings.EqualFold(level.Name, monitor.SeverityWarning):
+				warning = level.Threshold
+			case strings.EqualFold(level.Name, monitor.SeverityCritical):
+				critical = level.Threshold
+			}
+		}
+	}
+
+	sensorThresholds, err := parseSensorThresholds(os.Getenv("SENSOR_THRESHOLDS"))
+	if err != nil {
+		logger.Error("Invalid SENSOR_THRESHOLDS value:", err)
+		return nil, err
+	}
+
+	logger.Info("Reading TEMP_HYSTERESIS...")
+	hysteresis, err := getEnvFloat("TEMP_HYSTERESIS", 0)
+	if err != nil {
+		logger.Error("Invalid TEMP_HYSTERESIS value:", err)
+		return nil, err
+	}
+	if hysteresis < 0 || hysteresis > 20 {
+		logger.Error("TEMP_HYSTERESIS out of range:", hysteresis)
+		return nil, fmt.Errorf("TEMP_HYSTERESIS must be between 0 and 20°C, got %v", hysteresis)
+	}
+
+	logger.Info("Reading FD_ALERT_THRESHOLD...")
+	fdThreshold, err := getEnvFloat("FD_ALERT_THRESHOLD", 0)
+	if err != nil {
+		logger.Error("Invalid FD_ALERT_THRESHOLD value:", err)
+		return nil, err
+	}
+	if fdThreshold < 0 || fdThreshold > 100 {
+		logger.Error("FD_ALERT_THRESHOLD out of range:", fdThreshold)
+		return nil, fmt.Errorf("FD_ALERT_THRESHOLD must be between 0 and 100, got %v", fdThreshold)
+	}
+
+	logger.Info("Reading MEMORY_PRESSURE_THRESHOLD...")
+	pressureThreshold, err := getEnvFloat("MEMORY_PRESSURE_THRESHOLD", 0)
+	if err != nil {
+		logger.Error("Invalid MEMORY_PRESSURE_THRESHOLD value:", err)
+		return nil, err
+	}
+	if pressureThreshold < 0 || pressureThreshold > 100 {
+		logger.Error("MEMORY_PRESSURE_THRESHOLD out of range:", pressureThreshold)
+		return nil, fmt.Errorf("MEMORY_PRESSURE_THRESHOLD must be between 0 and 100, got %v", pressureThreshold)
+	}
+
+	logger.Info("Reading power alert settings...")
+	alertOnBattery, err := getEnvBool("POWER_ALERT_ON_BATTERY", false)
+	if err != nil {
+		logger.Error("Invalid POWER_ALERT_ON_BATTERY value:", err)
+		return nil, err
+	}
+	lowBattery, err := getEnvFloat("POWER_LOW_CHARGE_THRESHOLD", 0)
+	if err != nil {
+		logger.Error("Invalid POWER_LOW_CHARGE_THRESHOLD value:", err)
+		return nil, err
+	}
+	if lowBattery < 0 || lowBattery > 100 {
+		logger.Error("POWER_LOW_CHARGE_THRESHOLD out of range:", lowBattery)
+		return nil, fmt.Errorf("POWER_LOW_CHARGE_THRESHOLD must be between 0 and 100, got %v", lowBattery)
+	}
+
+	logger.Info("Reading DISK_FILL_ALERT_WINDOW...")
+	diskFillWindow, err := getEnvDuration("DISK_FILL_ALERT_WINDOW", 0)
+	if err != nil {
+		logger.Error("Invalid DISK_FILL_ALERT_WINDOW value:", err)
+		return nil, err
+	}
+	if diskFillWindow < 0 {
+		logger.Error("DISK_FILL_ALERT_WINDOW out of range:", diskFillWindow)
+		return nil, fmt.Errorf("DISK_FILL_ALERT_WINDOW must not be negative, got %v", diskFillWindow)
+	}
+
+	logger.Info("Reading MONITOR_INTERVAL...")
+	monitorInterval, err := getEnvDuration("MONITOR_INTERVAL", defaultMonitorInterval)
+	if err != nil {
+		logger.Error("Invalid MONITOR_INTERVAL value:", err)
+		return nil, err
+	}
+	if monitorInterval < minMonitorInterval || monitorInterval > maxMonitorInterval {
+		logger.Error("MONITOR_INTERVAL out of range:", monitorInterval)
+		return nil, fmt.Errorf("MONITOR_INTERVAL must be between %v and %v, got %v", minMonitorInterval, maxMonitorInterval, monitorInterval)
+	}
+
+	logger.Info("Reading ALERT_COOLDOWN...")
+	alertCooldown, err := getEnvDuration("ALERT_COOLDOWN", defaultAlertCooldown)
+	if err != nil {
+		logger.Error("Invalid ALERT_COOLDOWN value:", err)
+		return nil, err
+	}
+	if alertCooldown < 0 || alertCooldown > maxAlertCooldown {
+		logger.Error("ALERT_COOLDOWN out of range:", alertCooldown)
+		return nil, fmt.Errorf("ALERT_COOLDOWN must be between 0s and %v, got %v", maxAlertCooldown, alertCooldown)
+	}
+
+	logger.Info("Reading SAMPLE_WINDOW...")
+	sampleWindow, err := getEnvDuration("SAMPLE_WINDOW", defaultSampleWindow)
+	if err != nil {
+		logger.Error("Invalid SAMPLE_WINDOW value:", err)
+		return nil, err
+	}
+	if sampleWindow < minSampleWindow || sampleWindow > maxSampleWindow {
+		logger.Error("SAMPLE_WINDOW out of range:", sampleWindow)
+		return nil, fmt.Errorf("SAMPLE_WINDOW must be between %v and %v, got %v", minSampleWindow, maxSampleWindow, sampleWindow)
+	}
+
+	logger.Info("Reading NETWORK_INTERVAL...")
+	networkInterval, err := getEnvDuration("NETWORK_INTERVAL", defaultNetworkInterval)
+	if err != nil {
+		logger.Error("Invalid NETWORK_INTERVAL value:", err)
+		return nil, err
+	}
+	if networkInterval < minNetworkInterval || networkInterval > maxNetworkInterval {
+		logger.Error("NETWORK_INTERVAL out of range:", networkInterval)
+		return nil, fmt.Errorf("NETWORK_INTERVAL must be between %v and %v, got %v", minNetworkInterval, maxNetworkInterval, networkInterval)
+	}
+
+	logger.Info("Reading PROC_SCAN_WORKERS...")
+	procScanWorkers, err := getEnvInt("PROC_SCAN_WORKERS", defaultProcScanWorkers)
+	if err != nil {
+		logger.Error("Invalid PROC_SCAN_WORKERS value:", err)
+		return nil, err
+	}
+	if procScanWorkers < 1 || procScanWorkers > maxProcScanWorkers {
+		logger.Error("PROC_SCAN_WORKERS out of range:", procScanWorkers)
+		return nil, fmt.Errorf("PROC_SCAN_WORKERS must be between 1 and %d, got %d", maxProcScanWorkers, procScanWorkers)
+	}
+
+	logger.Info("Reading INTERACTION_DEFER_THRESHOLD...")
+	deferThreshold, err := getEnvDuration("INTERACTION_DEFER_THRESHOLD", defaultDeferThreshold)
+	if err != nil {
+		logger.Error("Invalid INTERACTION_DEFER_THRESHOLD value:", err)
+		return nil, err
+	}
+	if deferThreshold < minDeferThreshold || deferThreshold > maxDeferThreshold {
+		logger.Error("INTERACTION_DEFER_THRESHOLD out of range:", deferThreshold)
+		return nil, fmt.Errorf("INTERACTION_DEFER_THRESHOLD must be between %v and %v, got %v", minDeferThreshold, maxDeferThreshold, deferThreshold)
+	}
+
+	logger.Info("Reading ALERT_MODE...")
+	alertMode := os.Getenv("ALERT_MODE")
+	switch alertMode {
+	case "":
+		alertMode = AlertModeRepeat
+		logger.Info("No alert mode specified - using default:", alertMode)
+	case AlertModeRepeat, AlertModeEvolving:
+		logger.Info("Alert mode loaded:", alertMode)
+	default:
+		logger.Error("Invalid ALERT_MODE value:", alertMode)
+		return nil, fmt.Errorf("ALERT_MODE must be %q or %q, got %q", AlertModeRepeat, AlertModeEvolving, alertMode)
+	}
+
+	logger.Info("Reading ALERT_POLICY...")
+	alertPolicy := os.Getenv("ALERT_POLICY")
+	switch alertPolicy {
+	case "":
+		alertPolicy = AlertPolicyMax
+		logger.Info("No alert policy specified - using default:", alertPolicy)
+	case AlertPolicyMax, AlertPolicyCount, AlertPolicyAverage:
+		logger.Info("Alert policy loaded:", alertPolicy)
+	default:
+		logger.Error("Invalid ALERT_POLICY value:", alertPolicy)
+		return nil, fmt.Errorf("ALERT_POLICY must be %q, %q or %q, got %q", AlertPolicyMax, AlertPolicyCount, AlertPolicyAverage, alertPolicy)
+	}
+	alertMinSensors, err := getEnvInt("ALERT_MIN_SENSORS", 3)
+	if err != nil {
+		logger.Error("Invalid ALERT_MIN_SENSORS value:", err)
+		return nil, err
+	}
+	if alertMinSensors < 1 {
+		logger.Error("ALERT_MIN_SENSORS out of range:", alertMinSensors)
+		return nil, fmt.Errorf("ALERT_MIN_SENSORS must be at least 1, got %d", alertMinSensors)
+	}
+
+	logger.Info("Reading ALERT_FORMAT...")
+	alertFormat := os.Getenv("ALERT_FORMAT")
+	switch alertFormat {
+	case "":
+		alertFormat = AlertFormatEmbed
+		logger.Info("No alert format specified - using default:", alertFormat)
+	case AlertFormatEmbed, AlertFormatText, AlertFormatBoth:
+		logger.Info("Alert format loaded:", alertFormat)
+	default:
+		logger.Error("Invalid ALERT_FORMAT value:", alertFormat)
+		return nil, fmt.Errorf("ALERT_FORMAT must be %q, %q or %q, got %q", AlertFormatEmbed, AlertFormatText, AlertFormatBoth, alertFormat)
+	}
+
+	logger.Info("Reading ALERT_COOLDOWN_POLICY...")
+	cooldownPolicy := os.Getenv("ALERT_COOLDOWN_POLICY")
+	switch cooldownPolicy {
+	case "":
+		cooldownPolicy = CooldownPolicyAlways
+		logger.Info("No cooldown policy specified - using default:", cooldownPolicy)
+	case CooldownPolicyAlways, CooldownPolicyRepeats:
+		logger.Info("Cooldown policy loaded:", cooldownPolicy)
+	default:
+		logger.Error("Invalid ALERT_COOLDOWN_POLICY value:", cooldownPolicy)
+		return nil, fmt.Errorf("ALERT_COOLDOWN_POLICY must be %q or %q, got %q", CooldownPolicyAlways, CooldownPolicyRepeats, cooldownPolicy)
+	}
+
+	logger.Info("Reading RECOVERY_CYCLES...")
+	recoveryCycles, err := getEnvInt("RECOVERY_CYCLES", 1)
+	if err != nil {
+		logger.Error("Invalid RECOVERY_CYCLES value:", err)
+		return nil, err
+	}
+	if recoveryCycles < 1 || recoveryCycles > maxRecoveryCycles {
+		logger.Error("RECOVERY_CYCLES out of range:", recoveryCycles)
+		return nil, fmt.Errorf("RECOVERY_CYCLES must be between 1 and %d, got %d", maxRecoveryCycles, recoveryCycles)
+	}
+
+	logger.Info("Reading sensor presence settings...")
+	missingCycles, err := getEnvInt("SENSOR_MISSING_CYCLES", 3)
+	if err != nil {
+		logger.Error("Invalid SENSOR_MISSING_CYCLES value:", err)
+		return nil, err
+	}
+	if missingCycles < 0 {
+		logger.Error("SENSOR_MISSING_CYCLES out of range:", missingCycles)
+		return nil, fmt.Errorf("SENSOR_MISSING_CYCLES must not be negative, got %d", missingCycles)
+	}
+	announceNew, err := getEnvBool("SENSOR_ANNOUNCE_NEW", false)
+	if err != nil {
+		logger.Error("Invalid SENSOR_ANNOUNCE_NEW value:", err)
+		return nil, err
+	}
+
+	logger.Info("Reading ALLOWED_ALERT_CHANNELS...")
+	var allowedChannels []string
+	for _, id := range strings.Split(os.Getenv("ALLOWED_ALERT_CHANNELS"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			allowedChannels = append(allowedChannels, id)
+		}
+	}
+	if len(allowedChannels) > 0 {
+		logger.Info("Alerts may only be enabled in channels:", allowedChannels)
+	} else {
+		logger.Info("No alert channel allowlist specified - alerts may be enabled in any channel")
+	}
+
+	logger.Info("Reading DEFAULT_ALERT_CHANNEL...")
+	defaultAlertChannel := strings.TrimSpace(os.Getenv("DEFAULT_ALERT_CHANNEL"))
+	if defaultAlertChannel != "" {
+		if _, err := strconv.ParseUint(defaultAlertChannel, 10, 64); err != nil {
+			logger.Error("Invalid DEFAULT_ALERT_CHANNEL value:", defaultAlertChannel)
+			return nil, fmt.Errorf("DEFAULT_ALERT_CHANNEL must be a channel ID, got %q", defaultAlertChannel)
+		}
+		if len(allowedChannels) > 0 && !slices.Contains(allowedChannels, defaultAlertChannel) {
+			logger.Error("DEFAULT_ALERT_CHANNEL is not in ALLOWED_ALERT_CHANNELS:", defaultAlertChannel)
+			return nil, fmt.Errorf("DEFAULT_ALERT_CHANNEL %s must be listed in ALLOWED_ALERT_CHANNELS", defaultAlertChannel)
+		}
+		logger.Info("Default alert channel configured:", defaultAlertChannel)
+	} else {
+		logger.Info("No default alert channel specified - alerts start disabled until /alerts enable")
+	}
+
+	watchedPorts, err := parseWatchedPorts(os.Getenv("WATCHED_PORTS"))
+	if err != nil {
+		logger.Error("Invalid WATCHED_PORTS value:", err)
+		return nil, err
+	}
+
+	logger.Info("Reading SILENCED_SENSORS_FILE...")
+	silencedFile := os.Getenv("SILENCED_SENSORS_FILE")
+	if silencedFile != "" {
+		logger.Info("Silenced sensors file configured:", silencedFile)
+	} else {
+		logger.Info("No silenced sensors file specified - silences will not persist")
+	}
+
+	logger.Info("Reading SUDO_COMMANDS...")
+	var sudoCommands []string
+	for _, name := range strings.Split(os.Getenv("SUDO_COMMANDS"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			sudoCommands = append(sudoCommands, name)
+		}
+	}
+	if len(sudoCommands) > 0 {
+		logger.Info("Commands to run via sudo:", sudoCommands)
+	} else {
+		logger.Info("No sudo commands specified - running all commands unprivileged")
+	}
+
+	logger.Info("Reading METRICS_ADDR...")
+	metricsAddr := os.Getenv("METRICS_ADDR")
+	if metricsAddr != "" {
+		logger.Info("Metrics endpoint configured on:", metricsAddr)
+	} else {
+		logger.Info("No metrics address specified - Prometheus endpoint disabled")
+	}
+
+	logger.Info("Reading fleet settings...")
+	fleet := FleetConfig{Name: os.Getenv("FLEET_NAME")}
+	if fleet.Name == "" {
+		if fleet.Name, err = os.Hostname(); err != nil {
+			logger.Error("Failed to read hostname:", err)
+			return nil, fmt.Errorf("FLEET_NAME is unset and the hostname is unavailable: %w", err)
+		}
+	}
+	fleet.ServeSnapshot, err = getEnvBool("SNAPSHOT_ENABLED", false)
+	if err != nil {
+		logger.Error("Invalid SNAPSHOT_ENABLED value:", err)
+		return nil, err
+	}
+	if fleet.ServeSnapshot && metricsAddr == "" {
+		logger.Error("SNAPSHOT_ENABLED set without METRICS_ADDR")
+		return nil, fmt.Errorf("SNAPSHOT_ENABLED requires METRICS_ADDR")
+	}
+	for _, peer := range strings.Split(os.Getenv("PEERS"), ",") {
+		peer = strings.TrimSuffix(strings.TrimSpace(peer), "/")
+		if peer == "" {
+			continue
+		}
+		if !strings.HasPrefix(peer, "https://") && !strings.HasPrefix(peer, "http://") {
+			logger.Error("Invalid PEERS entry:", peer)
+			return nil, fmt.Errorf("PEERS entries must be http(s) URLs, got %q", peer)
+		}
+		fleet.Peers = append(fleet.Peers, peer)
+	}
+	if fleet.Token, err = getEnvSecret("SNAPSHOT_TOKEN"); err != nil {
+		logger.Error("Failed to read snapshot token:", err)
+		return nil, err
+	}
+	logger.Info("Fleet name:", fleet.Name, "serve snapshot:", fleet.ServeSnapshot, "peers:", len(fleet.Peers))
+
+	logger.Info("Reading remote host settings...")
+	remote := RemoteConfig{
+		Host:           strings.TrimSpace(os.Getenv("REMOTE_HOST")),
+		User:           os.Getenv("REMOTE_USER"),
+		KeyFile:        os.Getenv("REMOTE_KEY_FILE"),
+		KnownHostsFile: os.Getenv("REMOTE_KNOWN_HOSTS"),
+	}
+	if remote.Enabled() {
+		if remote.User == "" || remote.KeyFile == "" {
+			logger.Error("Incomplete remote host settings")
+			return nil, fmt.Errorf("REMOTE_HOST requires REMOTE_USER and REMOTE_KEY_FILE")
+		}
+		if _, _, err := net.SplitHostPort(remote.Host); err != nil {
+			remote.Host = net.JoinHostPort(remote.Host, "22")
+		}
+		if remote.KnownHostsFile == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				logger.Error("Failed to find home directory:", err)
+				return nil, fmt.Errorf("REMOTE_KNOWN_HOSTS is unset and the home directory is unavailable: %w", err)
+			}
+			remote.KnownHostsFile = filepath.Join(home, ".ssh", "known_hosts")
+		}
+		logger.Info("Remote host configured:", remote.User+"@"+remote.Host, "known hosts:", remote.KnownHostsFile)
+	} else {
+		logger.Info("No remote host specified - reading the local machine")
+	}
+
+	logger.Info("Reading TEMP_BACKEND...")
+	tempBackend := os.Getenv("TEMP_BACKEND")
+	switch tempBackend {
+	case "":
+		tempBackend = monitor.TempBackendAuto
+		logger.Info("No temperature backend specified - using default:", tempBackend)
+	case monitor.TempBackendAuto, monitor.TempBackendSensors, monitor.TempBackendGopsutil:
+		logger.Info("Temperature backend loaded:", tempBackend)
+	default:
+		logger.Error("Invalid TEMP_BACKEND value:", tempBackend)
+		return nil, fmt.Errorf("TEMP_BACKEND must be %q, %q or %q, got %q",
+			monitor.TempBackendAuto, monitor.TempBackendSensors, monitor.TempBackendGopsutil, tempBackend)
+	}
+	if tempBackend == monitor.TempBackendGopsutil && remote.Enabled() {
+		// gopsutil reads the local /sys, which is not the remote host
+		logger.Error("TEMP_BACKEND gopsutil set together with REMOTE_HOST")
+		return nil, fmt.Errorf("TEMP_BACKEND %q only reads the local machine and cannot be used with REMOTE_HOST", monitor.TempBackendGopsutil)
+	}
+
+	logger.Info("Reading Matrix settings...")
+	matrixToken, err := getEnvSecret("MATRIX_TOKEN")
+	if err != nil {
+		logger.Error("Failed to read Matrix token:", err)
+		return nil, err
+	}
+	matrix := MatrixConfig{
+		Homeserver: strings.TrimSuffix(os.Getenv("MATRIX_HOMESERVER"), "/"),
+		Token:      matrixToken,
+		RoomID:     os.Getenv("MATRIX_ROOM"),
+	}
+	if matrix.Homeserver != "" || matrix.Token != "" || matrix.RoomID != "" {
+		if matrix.Homeserver == "" || matrix.Token == "" || matrix.RoomID == "" {
+			logger.Error("Incomplete Matrix settings")
+			return nil, fmt.Errorf("MATRIX_HOMESERVER, MATRIX_TOKEN and MATRIX_ROOM must be set together")
+		}
+		if !strings.HasPrefix(matrix.Homeserver, "https://") && !strings.HasPrefix(matrix.Homeserver, "http://") {
+			logger.Error("Invalid MATRIX_HOMESERVER value:", matrix.Homeserver)
+			return nil, fmt.Errorf("MATRIX_HOMESERVER must be an http(s) URL, got %q", matrix.Homeserver)
+		}
+		if !strings.HasPrefix(matrix.RoomID, "!") {
+			logger.Error("Invalid MATRIX_ROOM value:", matrix.RoomID)
+			return nil, fmt.Errorf("MATRIX_ROOM must be a room ID like !abc123:example.org, got %q", matrix.RoomID)
+		}
+		logger.Info("Matrix alerts configured for room", matrix.RoomID, "on", matrix.Homeserver)
+	} else {
+		logger.Info("No Matrix settings specified - Matrix alerts disabled")
+	}
+
+	logger.Info("Reading ALERT_OUTPUT...")
+	alertOutput := strings.TrimSpace(os.Getenv("ALERT_OUTPUT"))
+	if alertOutput != "" {
+		logger.Info("JSON alert records will be written to:", alertOutput)
+	} else {
+		logger.Info("No alert output specified - JSON alert records disabled")
+	}
+
+	logger.Info("Reading display limits...")
+	limits := DefaultDisplayLimits()
+	limitVars := []struct {
+		key    string
+		target *int
+	}{
+		{"DISPLAY_MAX_SENSORS", &limits.MaxSensorFields},
+		{"DISPLAY_MAX_PORT_FIELDS", &limits.MaxPortFields},
+		{"DISPLAY_MAX_PORTS_PER_FIELD", &limits.MaxPortsPerField},
+		{"DISPLAY_MAX_PROCESSES", &limits.MaxProcesses},
+		{"MEMORY_TOP_N", &limits.MemoryTopN},
+		{"DISPLAY_MAX_ALERT_SENSORS", &limits.MaxAlertSensors},
+		{"DISPLAY_MAX_PORT_MESSAGES", &limits.MaxPortMessages},
+	}
+	for _, v := range limitVars {
+		value, err := getEnvInt(v.key, *v.target)
+		if err != nil {
+			logger.Error("Invalid display limit:", err)
+			return nil, err
+		}
+		*v.target = value
+	}
+	if err := limits.Validate(); err != nil {
+		logger.Error("Display limits out of range:", err)
+		return nil, err
+	}
+
+	logger.Info("Reading PROCESS_ALIASES_FILE...")
+	aliasesFile := os.Getenv("PROCESS_ALIASES_FILE")
+	if aliasesFile != "" {
+		logger.Info("Process aliases file configured:", aliasesFile)
+	} else {
+		logger.Info("No process aliases file specified - using built-in names")
+	}
+
+	excludeSelf, err := getEnvBool("EXCLUDE_SELF_PROCESS", false)
+	if err != nil {
+		logger.Error("Invalid EXCLUDE_SELF_PROCESS value:", err)
+		return nil, err
+	}
+
+	logger.Info("Reading DISPLAY_TOP_PROCESS...")
+	showTopProcess, err := getEnvBool("DISPLAY_TOP_PROCESS", false)
+	if err != nil {
+		logger.Error("Invalid DISPLAY_TOP_PROCESS value:", err)
+		return nil, err
+	}
+
+	logger.Info("Reading LOG_FILE...")
+	logConfig := LogConfig{File: os.Getenv("LOG_FILE")}
+	if logConfig.File != "" {
+		logger.Info("Log file configured:", logConfig.File)
+	} else {
+		logger.Info("No log file specified - logging to the console only")
+	}
+	logConfig.MaxSizeMB, err = getEnvInt("LOG_MAX_SIZE_MB", 10)
+	if err != nil {
+		logger.Error("Invalid LOG_MAX_SIZE_MB value:", err)
+		return nil, err
+	}
+	if logConfig.MaxSizeMB < 1 || logConfig.MaxSizeMB > maxLogSizeMB {
+		logger.Error("LOG_MAX_SIZE_MB out of range:", logConfig.MaxSizeMB)
+		return nil, fmt.Errorf("LOG_MAX_SIZE_MB must be between 1 and %d, got %d", maxLogSizeMB, logConfig.MaxSizeMB)
+	}
+	logConfig.MaxFiles, err = getEnvInt("LOG_MAX_FILES", 3)
+	if err != nil {
+		logger.Error("Invalid LOG_MAX_FILES value:", err)
+		return nil, err
+	}
+	if logConfig.MaxFiles < 1 || logConfig.MaxFiles > maxLogFiles {
+		logger.Error("LOG_MAX_FILES out of range:", logConfig.MaxFiles)
+		return nil, fmt.Errorf("LOG_MAX_FILES must be between 1 and %d, got %d", maxLogFiles, logConfig.MaxFiles)
+	}
+	logConfig.FileOnly, err = getEnvBool("LOG_FILE_ONLY", false)
+	if err != nil {
+		logger.Error("Invalid LOG_FILE_ONLY value:", err)
+		return nil, err
+	}
+	if logConfig.FileOnly && logConfig.File == "" {
+		logger.Error("LOG_FILE_ONLY set without LOG_FILE")
+		return nil, fmt.Errorf("LOG_FILE_ONLY requires LOG_FILE")
+	}
+
 	config := &Config{
 		Discord: DiscordConfig{
-			Token:   botToken,
-			GuildID: guildID,
+			Token:       botToken,
+			GuildID:     guildID,
+			ProxyURL:    proxyURL,
+			HTTPTimeout: 20 * time.Second,
+
+			PerGuildCommands: perGuildCommands,
+
+			Status:        botStatus,
+			DynamicStatus: dynamicStatus,
+			ReadOnly:      readOnly,
+
+			TrackCommandUsers: trackCommandUsers,
+			DeferThreshold:    deferThreshold,
 		},
 		Monitor: MonitorConfig{
-			Interval:      30 * time.Second,
-			AlertCooldown: 5 * time.Minute,
+			Interval:       monitorInterval,
+			AlertCooldown:  alertCooldown,
+			SampleWindow:   sampleWindow,
+			AlertOnBattery: alertOnBattery,
+			AlertMode:      alertMode,
+			AlertFormat:    alertFormat,
+			CooldownPolicy: cooldownPolicy,
+			RecoveryCycles: recoveryCycles,
+
+			AllowedAlertChannels: allowedChannels,
+			DefaultAlertChannel:  defaultAlertChannel,
+			AlertPolicy:          alertPolicy,
+			AlertMinSensors:      alertMinSensors,
+
+			MissingSensorCycles: missingCycles,
+			AnnounceNewSensors:  announceNew,
+			SilencedSensorsFile: silencedFile,
+			SudoCommands:        sudoCommands,
+			MetricsAddr:         metricsAddr,
+			AlertOutput:         alertOutput,
+			WatchedPorts:        watchedPorts,
+			TempBackend:         tempBackend,
+
+			NetworkInterval: networkInterval,
+			ProcScanWorkers: procScanWorkers,
 		},
 		Thresholds: ThresholdConfig{
-			Critical: 80.0,
-			Warning:  70.0,
+			Critical:   critical,
+			Warning:    warning,
+			Levels:     levels,
+			Sensors:    sensorThresholds,
+			Hysteresis: hysteresis,
+
+			FileDescriptorPercent: fdThreshold,
+			MemoryPressurePercent: pressureThreshold,
+			LowBatteryPercent:     lowBattery,
+			DiskFillWindow:        diskFillWindow,
+		},
+		Display: DisplayConfig{
+			Location: location,
+			Limits:   limits,
+
+			ProcessAliasesFile: aliasesFile,
+			ExcludeSelf:        excludeSelf,
+			ShowTopProcess:     showTopProcess,
+			TimeStyle:          timeStyle,
+			CategoryEmoji:      categoryEmoji,
+			RateUnit:           rateUnit,
+			RatePrecision:      ratePrecision,
 		},
+		Matrix: matrix,
+		Log:    logConfig,
+		Fleet:  fleet,
+		Remote: remote,
 	}
 
 	logger.Info("Configuration created with defaults:")
+	logger.Info("- HTTP timeout:", config.Discord.HTTPTimeout)
+	logger.Info("- Read-only mode:", config.Discord.ReadOnly)
+	logger.Info("- Per-guild commands:", config.Discord.PerGuildCommands)
+	logger.Info("- Dynamic status:", config.Discord.DynamicStatus)
+	logger.Info("- Per-user command stats:", config.Discord.TrackCommandUsers)
+	logger.Info("- Interaction defer threshold:", config.Discord.DeferThreshold)
 	logger.Info("- Monitor interval:", config.Monitor.Interval)
-	logger.Info("- Alert cooldown:", config.Monitor.AlertCooldown)
+	logger.Info("- Alert cooldown:", config.Monitor.AlertCooldown, "policy:", config.Monitor.CooldownPolicy)
+	logger.Info("- Sample window:", config.Monitor.SampleWindow)
+	logger.Info("- Network interval:", config.Monitor.NetworkInterval)
+	logger.Info("- /proc scan workers:", config.Monitor.ProcScanWorkers)
 	logger.Info("- Critical threshold:", config.Thresholds.Critical, "°C")
 	logger.Info("- Warning threshold:", config.Thresholds.Warning, "°C")
+	logger.Info("- Severity levels:", len(config.Thresholds.Levels))
+	logger.Info("- Per-sensor thresholds:", len(config.Thresholds.Sensors))
+	logger.Info("- Hysteresis:", config.Thresholds.Hysteresis, "°C")
+	logger.Info("- File descriptor threshold:", config.Thresholds.FileDescriptorPercent, "%")
+	logger.Info("- Memory pressure threshold:", config.Thresholds.MemoryPressurePercent, "%")
+	logger.Info("- Alert mode:", config.Monitor.AlertMode)
+	logger.Info("- Alert format:", config.Monitor.AlertFormat)
+	logger.Info("- Recovery cycles:", config.Monitor.RecoveryCycles)
+	logger.Info("- Alert policy:", config.Monitor.AlertPolicy, "min sensors:", config.Monitor.AlertMinSensors)
+	logger.Info("- Alert on battery:", config.Monitor.AlertOnBattery)
+	logger.Info("- Default alert channel:", config.Monitor.DefaultAlertChannel != "")
+	logger.Info("- Watched ports:", len(config.Monitor.WatchedPorts))
+	logger.Info("- Missing sensor cycles:", config.Monitor.MissingSensorCycles)
+	logger.Info("- Announce new sensors:", config.Monitor.AnnounceNewSensors)
+	logger.Info("- Low battery threshold:", config.Thresholds.LowBatteryPercent, "%")
+	logger.Info("- Disk fill alert window:", config.Thresholds.DiskFillWindow)
+	logger.Info("- Display time zone:", config.Display.Location)
+	logger.Info("- Display time style:", config.Display.TimeStyle)
+	logger.Info("- Display rate unit:", config.Display.RateUnit, "precision:", config.Display.RatePrecision)
+	logger.Info("- Custom category emoji:", len(config.Display.CategoryEmoji))
+	logger.Info("- Exclude own process:", config.Display.ExcludeSelf)
+	logger.Info("- Top process in temperature views:", config.Display.ShowTopProcess)
+	logger.Info("- Matrix alerts:", config.Matrix.Enabled())
+	logger.Info("- JSON alert output:", config.Monitor.AlertOutput != "")
+	logger.Info("- Fleet peers:", len(config.Fleet.Peers), "serve snapshot:", config.Fleet.ServeSnapshot)
+	logger.Info("- Remote host:", config.Remote.Enabled())
+	logger.Info("- Temperature backend:", config.Monitor.TempBackend)
+	logger.Info("- Log file:", config.Log.File, "max size:", config.Log.MaxSizeMB, "MB, files kept:", config.Log.MaxFiles)
+	logger.Info("- Display limits:", fmt.Sprintf("%+v", config.Display.Limits))
 
 	return config, nil
 }
+
+// severityLevelEntry is one level in the severity levels file
+type severityLevelEntry struct {
+	Name      string  `json:"name"`
+	Threshold float64 `json:"threshold"`
+	Color     string  `json:"color"`
+	Icon      string  `json:"icon"`
+}
+
+// loadSeverityLevels reads a JSON list of levels above Normal, lowest first:
+//
+//	[{"name": "Elevated", "threshold": 60, "color": "#3498db", "icon": "🌡️"},
+//	 {"name": "Warning", "threshold": 70, "color": "#ff8800", "icon": "⚠️"},
+//	 {"name": "Critical", "threshold": 80, "color": "#ff0000", "icon": "🚨"},
+//	 {"name": "Emergency", "threshold": 90, "color": "#8b0000", "icon": "🆘"}]
+func loadSeverityLevels(path string) ([]monitor.SeverityLevel, error) {
+	logger.Info("Loading severity levels from", path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read severity levels file: %w", err)
+	}
+
+	var entries []severityLevelEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid severity levels file %s: %w", path, err)
+	}
+
+	levels := make([]monitor.SeverityLevel, 0, len(entries))
+	for _, entry := range entries {
+		color, err := strconv.ParseUint(strings.TrimPrefix(entry.Color, "#"), 16, 32)
+		if err != nil || len(strings.TrimPrefix(entry.Color, "#")) != 6 {
+			return nil, fmt.Errorf("severity level %q color must be like #ff8800, got %q", entry.Name, entry.Color)
+		}
+		levels = append(levels, monitor.SeverityLevel{
+			Name:      strings.TrimSpace(entry.Name),
+			Threshold: entry.Threshold,
+			Color:     int(color),
+			Icon:      strings.TrimSpace(entry.Icon),
+		})
+	}
+	if err := monitor.ValidateSeverityLevels(levels); err != nil {
+		return nil, fmt.Errorf("invalid severity levels file %s: %w", path, err)
+	}
+
+	logger.Info("Loaded", len(levels), "severity levels")
+	return levels, nil
+}
+
+// parseSensorThresholds parses SENSOR_THRESHOLDS, a comma-separated list of
+// sensor=warning:critical entries such as "nvme-pci-0100_temp1=60:70".
+// Sensor IDs are the ones shown by /sensors list.
+func parseSensorThresholds(value string) (map[string]monitor.SensorThresholds, error) {
+	logger.Info("Reading SENSOR_THRESHOLDS...")
+	overrides := make(map[string]monitor.SensorThresholds)
+	if strings.TrimSpace(value) == "" {
+		logger.Info("No per-sensor thresholds specified - all sensors use the global thresholds")
+		return overrides, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		id, limits, found := strings.Cut(strings.TrimSpace(entry), "=")
+		id = strings.TrimSpace(id)
+		warningValue, criticalValue, hasBoth := strings.Cut(limits, ":")
+		if !found || id == "" || !hasBoth {
+			return nil, fmt.Errorf("SENSOR_THRESHOLDS entries must be sensor=warning:critical, got %q", entry)
+		}
+		if _, duplicate := overrides[id]; duplicate {
+			return nil, fmt.Errorf("SENSOR_THRESHOLDS lists sensor %q twice", id)
+		}
+
+		warning, err := parseTemperature(strings.TrimSpace(warningValue))
+		if err != nil {
+			return nil, fmt.Errorf("SENSOR_THRESHOLDS warning for %s %w", id, err)
+		}
+		critical, err := parseTemperature(strings.TrimSpace(criticalValue))
+		if err != nil {
+			return nil, fmt.Errorf("SENSOR_THRESHOLDS critical for %s %w", id, err)
+		}
+		if warning >= critical {
+			return nil, fmt.Errorf("SENSOR_THRESHOLDS warning for %s (%.1f°C) must be below its critical threshold (%.1f°C)", id, warning, critical)
+		}
+		overrides[id] = monitor.SensorThresholds{Warning: warning, Critical: critical}
+	}
+
+	logger.Info("Per-sensor thresholds configured for", len(overrides), "sensors")
+	return overrides, nil
+}
+
+// parseWatchedPorts parses WATCHED_PORTS, a comma-separated list of ports
+// with an optional protocol such as "22,443/tcp,53/udp"
+func parseWatchedPorts(value string) ([]monitor.WatchedPort, error) {
+	logger.Info("Reading WATCHED_PORTS...")
+	if strings.TrimSpace(value) == "" {
+		logger.Info("No watched ports specified - port monitoring disabled")
+		return nil, nil
+	}
+
+	var ports []monitor.WatchedPort
+	seen := make(map[monitor.WatchedPort]bool)
+	for _, entry := range strings.Split(value, ",") {
+		portValue, protocol, _ := strings.Cut(strings.TrimSpace(entry), "/")
+		protocol = strings.ToLower(strings.TrimSpace(protocol))
+		if protocol != "" && protocol != "tcp" && protocol != "udp" {
+			return nil, fmt.Errorf("WATCHED_PORTS protocol must be tcp or udp, got %q", entry)
+		}
+		number, err := strconv.Atoi(strings.TrimSpace(portValue))
+		if err != nil || number < 1 || number > 65535 {
+			return nil, fmt.Errorf("WATCHED_PORTS entries must be ports between 1 and 65535, got %q", entry)
+		}
+
+		port := monitor.WatchedPort{Port: strconv.Itoa(number), Protocol: protocol}
+		if seen[port] {
+			return nil, fmt.Errorf("WATCHED_PORTS lists %s twice", port)
+		}
+		seen[port] = true
+		ports = append(ports, port)
+	}
+
+	logger.Info("Watching", len(ports), "ports:", ports)
+	return ports, nil
+}
+
+// parseCategoryEmoji parses CATEGORY_EMOJI, a comma-separated list of
+// category=emoji pairs such as "CPU=<:cpu:1234567890>". Category names are
+// matched case-insensitively.
+func parseCategoryEmoji(value string) (map[string]string, error) {
+	logger.Info("Reading CATEGORY_EMOJI...")
+	emoji := make(map[string]string)
+	if strings.TrimSpace(value) == "" {
+		logger.Info("No category emoji specified - using status icons")
+		return emoji, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, ref, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("CATEGORY_EMOJI entries must be category=emoji, got %q", pair)
+		}
+		name, ref = strings.TrimSpace(name), strings.TrimSpace(ref)
+
+		category := ""
+		for _, known := range monitor.Categories {
+			if strings.EqualFold(name, known) {
+				category = known
+				break
+			}
+		}
+		if category == "" {
+			return nil, fmt.Errorf("CATEGORY_EMOJI has unknown category %q (known: %s)", name, strings.Join(monitor.Categories, ", "))
+		}
+		if !customEmojiRegex.MatchString(ref) {
+			return nil, fmt.Errorf("CATEGORY_EMOJI value for %s must be a custom emoji like <:name:id>, got %q", category, ref)
+		}
+		emoji[category] = ref
+	}
+
+	logger.Info("Custom emoji configured for", len(emoji), "categories")
+	return emoji, nil
+}
+
+// getEnvSecret reads a secret from key, or from the file named by key+"_FILE"
+// (e.g. a Docker or Kubernetes secret mount) so it stays out of the process
+// environment. Setting both is rejected rather than silently picking one.
+func getEnvSecret(key string) (string, error) {
+	fileKey := key + "_FILE"
+	path := os.Getenv(fileKey)
+	if path == "" {
+		return os.Getenv(key), nil
+	}
+	if os.Getenv(key) != "" {
+		return "", fmt.Errorf("only one of %s and %s may be set", key, fileKey)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("%s is set but %q could not be read: %w", fileKey, path, err)
+	}
+	secret := strings.TrimSpace(string(data))
+	if secret == "" {
+		return "", fmt.Errorf("%s points to %q, which is empty", fileKey, path)
+	}
+
+	logger.Info(key, "loaded from file:", path)
+	return secret, nil
+}
+
+// getEnvFloat reads a float environment variable, returning def when unset
+func getEnvFloat(key string, def float64) (float64, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		logger.Info(key, "not set - using default:", def)
+		return def, nil
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number, got %q", key, value)
+	}
+
+	logger.Info(key, "loaded:", parsed)
+	return parsed, nil
+}
+
+// getEnvInt reads an integer environment variable, returning def when unset
+func getEnvInt(key string, def int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		logger.Info(key, "not set - using default:", def)
+		return def, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer, got %q", key, value)
+	}
+
+	logger.Info(key, "loaded:", parsed)
+	return parsed, nil
+}
+
+// getEnvBool reads a boolean environment variable, returning def when unset
+func getEnvBool(key string, def bool) (bool, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		logger.Info(key, "not set - using default:", def)
+		return def, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be true or false, got %q", key, value)
+	}
+
+	logger.Info(key, "loaded:", parsed)
+	return parsed, nil
+}
+
+// getEnvDuration reads a Go duration environment variable (e.g. "15m"),
+// returning def when unset
+func getEnvDuration(key string, def time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		logger.Info(key, "not set - using default:", def)
+		return def, nil
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a duration such as 30s or 15m, got %q", key, value)
+	}
+
+	logger.Info(key, "loaded:", parsed)
+	return parsed, nil
+}
+
+// getEnvTemperature reads a temperature in Celsius, accepting an optional
+// C or F suffix (e.g. "85", "85C", "185F"); Fahrenheit is converted
+func getEnvTemperature(key string, def float64) (float64, error) {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		logger.Info(key, "not set - using default:", def, "°C")
+		return def, nil
+	}
+
+	celsius, err := parseTemperature(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s %w", key, err)
+	}
+	logger.Info(key, "loaded:", celsius, "°C")
+	return celsius, nil
+}
+
+// parseTemperature parses a temperature in °C, or in °F with an F suffix
+func parseTemperature(value string) (float64, error) {
+	number := strings.TrimSuffix(strings.ToUpper(value), "°")
+	fahrenheit := false
+	switch {
+	case strings.HasSuffix(number, "F"):
+		fahrenheit = true
+		number = strings.TrimSuffix(number, "F")
+	case strings.HasSuffix(number, "C"):
+		number = strings.TrimSuffix(number, "C")
+	}
+	number = strings.TrimSuffix(strings.TrimSpace(number), "°")
+
+	parsed, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("must be a temperature like 85, 85C or 185F, got %q", value)
+	}
+	if fahrenheit {
+		celsius := (parsed - 32) * 5 / 9
+		logger.Info("Converted", parsed, "°F to", celsius, "°C")
+		return celsius, nil
+	}
+	return parsed, nil
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"strings"
+	"sync"
+	"system-monitor-bot/pkg/logger"
+	"testing"
+	"time"
+)
+
+var initLogger sync.Once
+
+func TestLoadDurations(t *testing.T) {
+	initLogger.Do(logger.Init)
+
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		get     func(*Config) time.Duration
+		want    time.Duration
+		wantErr string
+	}{
+		{name: "valid interval", key: "MONITOR_INTERVAL", value: "45s", get: func(c *Config) time.Duration { return c.Monitor.Interval }, want: 45 * time.Second},
+		{name: "default interval", key: "MONITOR_INTERVAL", value: "", get: func(c *Config) time.Duration { return c.Monitor.Interval }, want: defaultMonitorInterval},
+		{name: "invalid interval", key: "MONITOR_INTERVAL", value: "30", wantErr: `MONITOR_INTERVAL must be a duration such as 30s or 15m, got "30"`},
+		{name: "zero interval", key: "MONITOR_INTERVAL", value: "0s", wantErr: "MONITOR_INTERVAL must be between 1s and 1h0m0s, got 0s"},
+		{name: "negative interval", key: "MONITOR_INTERVAL", value: "-5s", wantErr: "MONITOR_INTERVAL must be between 1s and 1h0m0s, got -5s"},
+		{name: "valid cooldown", key: "ALERT_COOLDOWN", value: "10m", get: func(c *Config) time.Duration { return c.Monitor.AlertCooldown }, want: 10 * time.Minute},
+		{name: "zero cooldown", key: "ALERT_COOLDOWN", value: "0s", get: func(c *Config) time.Duration { return c.Monitor.AlertCooldown }, want: 0},
+		{name: "invalid cooldown", key: "ALERT_COOLDOWN", value: "five minutes", wantErr: `ALERT_COOLDOWN must be a duration such as 30s or 15m, got "five minutes"`},
+		{name: "negative cooldown", key: "ALERT_COOLDOWN", value: "-1m", wantErr: "ALERT_COOLDOWN must be between 0s and 24h0m0s, got -1m0s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DISCORD_BOT_TOKEN", "test-token")
+			t.Setenv(tt.key, tt.value)
+
+			cfg, err := Load()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := tt.get(cfg); got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
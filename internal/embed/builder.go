@@ -3,7 +3,9 @@ package embed
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"system-monitor-bot/internal/config"
 	"system-monitor-bot/internal/monitor"
 	"system-monitor-bot/pkg/logger"
 	"time"
@@ -11,50 +13,94 @@ import (
 	"github.com/bwmarrin/discordgo"
 )
 
+// maxCommandDisplayLength caps process names shown in field titles
+const maxCommandDisplayLength = 40
+
+// SensorListPageSize is how many sensors are described per /sensors list page
+const SensorListPageSize = 10
+
 type Builder struct {
 	criticalThreshold float64
 	warningThreshold  float64
+	location          *time.Location
+	timeStyle         string
+	limits            config.DisplayLimits
+	categoryEmoji     map[string]string
+	rateUnit          string
+	ratePrecision     int
 }
 
-func NewBuilder(critical, warning float64) *Builder {
-	logger.Info("Creating new embed Builder with thresholds - Critical:", critical, "Warning:", warning)
+func NewBuilder(critical, warning float64, display config.DisplayConfig) *Builder {
+	logger.Info("Creating new embed Builder with thresholds - Critical:", critical, "Warning:", warning, "Time zone:", display.Location)
 	return &Builder{
 		criticalThreshold: critical,
 		warningThreshold:  warning,
+		location:          display.Location,
+		timeStyle:         display.TimeStyle,
+		limits:            display.Limits,
+		categoryEmoji:     display.CategoryEmoji,
+		rateUnit:          display.RateUnit,
+		ratePrecision:     display.RatePrecision,
+	}
+}
+
+// categorySummary renders one category of the hardware overview with its
+// hottest reading as a share of that sensor's critical threshold. A custom
+// emoji replaces the status icon, which is then appended only for warning and
+// critical so the status stays visible.
+func (b *Builder) categorySummary(category string, status monitor.TempStatus, temp, critical float64) string {
+	reading := fmt.Sprintf("%.1f°C", temp)
+	if critical > 0 {
+		reading += fmt.Sprintf(" (%.0f%% of %.0f°C crit)", temp/critical*100, critical)
+	}
+
+	emoji, custom := b.categoryEmoji[category]
+	if !custom {
+		return fmt.Sprintf("%s **%s**: %s  ", b.getStatusIcon(status), category, reading)
+	}
+	if status == monitor.TempNormal {
+		return fmt.Sprintf("%s **%s**: %s  ", emoji, category, reading)
 	}
+	return fmt.Sprintf("%s **%s**: %s %s  ", emoji, category, reading, b.getStatusIcon(status))
 }
 
-func (b *Builder) BuildTemperature(sensors []monitor.TemperatureSensor) *discordgo.MessageEmbed {
-	logger.Info("Building temperature embed for", len(sensors), "sensors")
+// BuildTemperature builds the temperature embed; compact packs all sensors of
+// a category into one field instead of one field per sensor
+func (b *Builder) BuildTemperature(sensors []monitor.TemperatureSensor, compact bool) *discordgo.MessageEmbed {
+	logger.Info("Building temperature embed for", len(sensors), "sensors, compact:", compact)
 
 	// Find maximum temperature and categorize
 	maxTemp := 0.0
+	overallStatus := monitor.TempNormal
 	hardwareTemps := make(map[string]float64)
 	hardwareStatus := make(map[string]monitor.TempStatus)
+	hardwareCritical := make(map[string]float64)
 
 	for _, sensor := range sensors {
 		if sensor.Temperature > maxTemp {
 			maxTemp = sensor.Temperature
 		}
+		// Sensors may have their own thresholds, so the hottest is not
+		// necessarily the worst
+		overallStatus = max(overallStatus, sensor.Status)
 
 		// Track highest temperature per category
 		if existing, exists := hardwareTemps[sensor.Category]; !exists || sensor.Temperature > existing {
 			hardwareTemps[sensor.Category] = sensor.Temperature
 			hardwareStatus[sensor.Category] = sensor.Status
+			hardwareCritical[sensor.Category] = monitor.SensorThreshold(sensor.ID, monitor.TempCritical)
 		}
 	}
 
 	logger.Info("Maximum temperature found:", maxTemp, "°C")
 	logger.Info("Hardware categories found:", len(hardwareTemps))
 
-	// Determine overall status
-	overallStatus := b.getTemperatureStatus(maxTemp)
 	logger.Info("Overall temperature status:", overallStatus)
 
 	embed := &discordgo.MessageEmbed{
 		Title:     "🖥️ System Hardware Temperatures",
 		Color:     b.getStatusColor(overallStatus),
-		Timestamp: time.Now().Format(time.RFC3339),
+		Timestamp: b.Timestamp(),
 		Footer: &discordgo.MessageEmbedFooter{
 			Text: "System Hardware Monitor",
 		},
@@ -63,18 +109,13 @@ func (b *Builder) BuildTemperature(sensors []monitor.TemperatureSensor) *discord
 	// Build hardware overview
 	logger.Info("Building hardware overview...")
 	hardwareSummary := ""
-	categories := []string{
-		monitor.CategoryCPU, monitor.CategoryGPU, monitor.CategoryMotherboard,
-		monitor.CategoryChipset, monitor.CategoryWiFi, monitor.CategoryStorage,
-		monitor.CategorySystem, monitor.CategoryOther,
-	}
+	categories := monitor.Categories
 
 	categoriesFound := 0
 	for _, category := range categories {
 		if temp, exists := hardwareTemps[category]; exists {
 			status := hardwareStatus[category]
-			icon := b.getStatusIcon(status)
-			hardwareSummary += fmt.Sprintf("%s **%s**: %.1f°C  ", icon, category, temp)
+			hardwareSummary += b.categorySummary(category, status, temp, hardwareCritical[category])
 			categoriesFound++
 		}
 	}
@@ -89,15 +130,20 @@ func (b *Builder) BuildTemperature(sensors []monitor.TemperatureSensor) *discord
 		Inline: false,
 	})
 
+	if compact {
+		b.addCompactSensorFields(embed, sensors, categories)
+		return embed
+	}
+
 	// Add individual sensor readings
 	logger.Info("Adding individual sensor fields...")
 	sensorsAdded := 0
 	for _, sensor := range sensors {
-		if len(embed.Fields) >= 25 { // Discord limit
-			logger.Info("Reached Discord field limit (25), adding truncation notice")
+		if sensorsAdded >= b.limits.MaxSensorFields {
+			logger.Info("Reached sensor field limit (", b.limits.MaxSensorFields, "), adding truncation notice")
 			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
 				Name:   "...",
-				Value:  fmt.Sprintf("And %d more sensors", len(sensors)-(len(embed.Fields)-1)),
+				Value:  fmt.Sprintf("And %d more sensors", len(sensors)-sensorsAdded),
 				Inline: true,
 			})
 			break
@@ -105,7 +151,7 @@ func (b *Builder) BuildTemperature(sensors []monitor.TemperatureSensor) *discord
 
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
 			Name:   fmt.Sprintf("%s %s", b.getStatusIcon(sensor.Status), sensor.Name),
-			Value:  fmt.Sprintf("%.1f°C", sensor.Temperature),
+			Value:  fmt.Sprintf("%.1f°C%s", sensor.Temperature, formatTempDelta(sensor)),
 			Inline: true,
 		})
 		sensorsAdded++
@@ -115,26 +161,194 @@ func (b *Builder) BuildTemperature(sensors []monitor.TemperatureSensor) *discord
 	return embed
 }
 
-func (b *Builder) BuildPorts(ports []monitor.NetworkPort, showAll bool) *discordgo.MessageEmbed {
-	logger.Info("Building ports embed for", len(ports), "ports, showAll:", showAll)
+// AddTopProcess inserts the top CPU-consuming process below the hardware
+// overview of a temperature embed, next to the hottest CPU reading, so a
+// hot CPU comes with what is driving it
+func (b *Builder) AddTopProcess(embed *discordgo.MessageEmbed, sensors []monitor.TemperatureSensor, process monitor.ProcessMemory) {
+	value := fmt.Sprintf("**%s** (PID %s, %s)\n%.1f%% CPU • %.1f%% MEM",
+		truncate(process.Command, 100), process.PID, process.User, process.CPUPercent, process.MemoryPercent)
 
-	title := "🔌 Network Ports"
-	description := "Showing listening ports"
-	if showAll {
-		title = "🌐 All Network Connections"
-		description = "Showing all active connections and listening ports"
+	cpuTemp, found := 0.0, false
+	for _, sensor := range sensors {
+		if sensor.Category == monitor.CategoryCPU && (!found || sensor.Temperature > cpuTemp) {
+			cpuTemp, found = sensor.Temperature, true
+		}
+	}
+	if found {
+		value += fmt.Sprintf("\nCPU at **%.1f°C**", cpuTemp)
 	}
 
+	// An embed already at Discord's field limit carries it in the overview
+	if len(embed.Fields) >= config.DiscordMaxEmbedFields {
+		overview := embed.Fields[0]
+		overview.Value = truncate(overview.Value+"\n\n🔥 "+value, config.DiscordMaxFieldValueChars)
+		return
+	}
+	field := &discordgo.MessageEmbedField{Name: "🔥 Top CPU Process", Value: value, Inline: false}
+	embed.Fields = append(embed.Fields[:1], append([]*discordgo.MessageEmbedField{field}, embed.Fields[1:]...)...)
+}
+
+// addCompactSensorFields lists sensors one line each, grouped into a single
+// field per category
+func (b *Builder) addCompactSensorFields(embed *discordgo.MessageEmbed, sensors []monitor.TemperatureSensor, categories []string) {
+	logger.Info("Adding compact sensor fields...")
+	lines := make(map[string][]string)
+	for _, sensor := range sensors {
+		lines[sensor.Category] = append(lines[sensor.Category],
+			fmt.Sprintf("%s %s: **%.1f°C**%s", b.getStatusIcon(sensor.Status), sensor.Name, sensor.Temperature, formatTempDelta(sensor)))
+	}
+
+	for _, category := range categories {
+		categoryLines, exists := lines[category]
+		if !exists {
+			continue
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   fmt.Sprintf("%s (%d)", category, len(categoryLines)),
+			Value:  truncate(strings.Join(categoryLines, "\n"), config.DiscordMaxFieldValueChars),
+			Inline: true,
+		})
+	}
+
+	logger.Info("Compact temperature embed built with", len(embed.Fields)-1, "category fields")
+}
+
+// formatTempDelta renders a sensor's change since the previous cycle, e.g.
+// " ▲1.5"; empty when there is no earlier reading
+func formatTempDelta(sensor monitor.TemperatureSensor) string {
+	if !sensor.HasDelta {
+		return ""
+	}
+	// Changes that round to 0.0 count as steady
+	switch {
+	case sensor.Delta >= 0.05:
+		return fmt.Sprintf(" ▲%.1f", sensor.Delta)
+	case sensor.Delta <= -0.05:
+		return fmt.Sprintf(" ▼%.1f", -sensor.Delta)
+	default:
+		return " –"
+	}
+}
+
+// BuildDiskTemperature builds the temperature embed for storage sensors only
+func (b *Builder) BuildDiskTemperature(sensors []monitor.TemperatureSensor) *discordgo.MessageEmbed {
+	embed := b.BuildTemperature(sensors, false)
+	embed.Title = "💽 Storage Temperatures"
+	return embed
+}
+
+// BuildSensorList describes one page of sensors with their IDs and limits,
+// for discovering what to tune. page is zero-based and clamped to range.
+func (b *Builder) BuildSensorList(sensors []monitor.TemperatureSensor, page int) (*discordgo.MessageEmbed, int) {
+	totalPages := max(1, (len(sensors)+SensorListPageSize-1)/SensorListPageSize)
+	page = min(max(page, 0), totalPages-1)
+	logger.Info("Building sensor list embed page", page+1, "of", totalPages, "for", len(sensors), "sensors")
+
 	embed := &discordgo.MessageEmbed{
-		Title:       title,
-		Description: description,
+		Title:       "📋 Available Sensors",
+		Description: fmt.Sprintf("%d sensors detected • Page %d/%d", len(sensors), page+1, totalPages),
 		Color:       0x3498db,
-		Timestamp:   time.Now().Format(time.RFC3339),
+		Timestamp:   b.Timestamp(),
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "System Network Monitor",
+			Text: "System Hardware Monitor",
 		},
 	}
 
+	start := page * SensorListPageSize
+	end := min(start+SensorListPageSize, len(sensors))
+	for _, sensor := range sensors[start:end] {
+		limits := "No chip limits reported"
+		switch {
+		case sensor.High > 0 && sensor.Crit > 0:
+			limits = fmt.Sprintf("High %.1f°C • Crit %.1f°C", sensor.High, sensor.Crit)
+		case sensor.High > 0:
+			limits = fmt.Sprintf("High %.1f°C", sensor.High)
+		case sensor.Crit > 0:
+			limits = fmt.Sprintf("Crit %.1f°C", sensor.Crit)
+		}
+
+		value := fmt.Sprintf("`%s`\n%s • **%.1f°C**\n%s", sensor.ID, sensor.Category, sensor.Temperature, limits)
+		if override, ok := monitor.SensorThresholdsFor(sensor.ID); ok {
+			value += fmt.Sprintf("\nAlerts at %.1f°C / %.1f°C", override.Warning, override.Critical)
+		}
+		if sensor.ExcludeFromAlerts {
+			value += "\n_Excluded from alerts_"
+		}
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   truncate(fmt.Sprintf("%s %s", b.getStatusIcon(sensor.Status), sensor.Name), config.DiscordMaxFieldNameChars),
+			Value:  truncate(value, config.DiscordMaxFieldValueChars),
+			Inline: true,
+		})
+	}
+
+	return embed, totalPages
+}
+
+// PortFilter narrows the ports list to one port number and/or processes
+// whose name contains Process (case-insensitive); zero values match all
+type PortFilter struct {
+	Port    int
+	Process string
+}
+
+// Active reports whether the filter excludes anything
+func (pf PortFilter) Active() bool {
+	return pf.Port != 0 || pf.Process != ""
+}
+
+// Matches reports whether port passes the filter
+func (pf PortFilter) Matches(port monitor.NetworkPort) bool {
+	if pf.Port != 0 && port.Port != strconv.Itoa(pf.Port) {
+		return false
+	}
+	if pf.Process == "" {
+		return true
+	}
+
+	// Any process sharing the socket matches, not just the one displayed
+	process := strings.ToLower(pf.Process)
+	if strings.Contains(strings.ToLower(port.ProcessName), process) {
+		return true
+	}
+	for _, ref := range port.Processes {
+		if strings.Contains(strings.ToLower(ref.Name), process) {
+			return true
+		}
+	}
+	return false
+}
+
+// String describes the filter for embed descriptions
+func (pf PortFilter) String() string {
+	var parts []string
+	if pf.Port != 0 {
+		parts = append(parts, fmt.Sprintf("port %d", pf.Port))
+	}
+	if pf.Process != "" {
+		parts = append(parts, fmt.Sprintf("process matching \"%s\"", pf.Process))
+	}
+	return strings.Join(parts, " and ")
+}
+
+// BuildPorts builds the first page of the ports embed
+func (b *Builder) BuildPorts(ports []monitor.NetworkPort, showAll bool, filter PortFilter) *discordgo.MessageEmbed {
+	return b.BuildPortPages(ports, showAll, filter)[0]
+}
+
+// BuildPortPages builds the ports list as one or more embeds, each within
+// Discord's limits. Up to MaxPortMessages pages are produced; anything that
+// still does not fit is dropped with a truncation notice on the last page.
+func (b *Builder) BuildPortPages(ports []monitor.NetworkPort, showAll bool, filter PortFilter) []*discordgo.MessageEmbed {
+	logger.Info("Building ports embed for", len(ports), "ports, showAll:", showAll, "filter:", filter)
+
+	title := "🔌 Network Ports"
+	description := "Showing listening ports"
+	if showAll {
+		title = "🌐 All Network Connections"
+		description = "Showing all active connections and listening ports"
+	}
+
 	// Debug: Show original count
 	originalCount := len(ports)
 	logger.Info("Original port count:", originalCount)
@@ -146,13 +360,30 @@ func (b *Builder) BuildPorts(ports []monitor.NetworkPort, showAll bool) *discord
 
 	// Debug info in description if we removed duplicates
 	if len(uniquePorts) != originalCount {
-		embed.Description += fmt.Sprintf(" (removed %d duplicates)", originalCount-len(uniquePorts))
+		description += fmt.Sprintf(" (removed %d duplicates)", originalCount-len(uniquePorts))
+	}
+
+	// Filter after deduplication so the matches are the clean entries
+	if filter.Active() {
+		var matched []monitor.NetworkPort
+		for _, port := range uniquePorts {
+			if filter.Matches(port) {
+				matched = append(matched, port)
+			}
+		}
+		logger.Info("Filter kept", len(matched), "of", len(uniquePorts), "ports")
+		description += fmt.Sprintf("\nFiltered to %s: **%d** of %d ports", filter, len(matched), len(uniquePorts))
+		if len(matched) == 0 {
+			description += "\n\n🔍 No ports match the filter"
+		}
+		uniquePorts = matched
 	}
 
 	// Group ports by protocol
 	logger.Info("Grouping ports by protocol...")
 	tcpPorts := []monitor.NetworkPort{}
 	udpPorts := []monitor.NetworkPort{}
+	unixSockets := []monitor.NetworkPort{}
 
 	for _, port := range uniquePorts {
 		switch strings.ToUpper(port.Protocol) {
@@ -160,179 +391,1045 @@ func (b *Builder) BuildPorts(ports []monitor.NetworkPort, showAll bool) *discord
 			tcpPorts = append(tcpPorts, port)
 		case "UDP":
 			udpPorts = append(udpPorts, port)
+		case "UNIX":
+			unixSockets = append(unixSockets, port)
+		}
+	}
+
+	logger.Info("Protocol distribution - TCP:", len(tcpPorts), "UDP:", len(udpPorts), "Unix:", len(unixSockets))
+
+	// Limits for Discord fields - adjusted for full addresses
+	maxPortsPerField := b.limits.MaxPortsPerField
+	const maxFieldValueLength = config.DiscordMaxFieldValueChars
+
+	var portFields []*discordgo.MessageEmbedField
+	protocols := []struct {
+		name  string
+		icon  string
+		ports []monitor.NetworkPort
+	}{
+		{"TCP", "🔵", tcpPorts},
+		{"UDP", "🟡", udpPorts},
+		{"Unix", "🟣", unixSockets},
+	}
+	for _, protocol := range protocols {
+		if len(protocol.ports) == 0 {
+			continue
+		}
+		logger.Info("Processing", protocol.name, "ports...")
+		chunks := b.chunkPorts(protocol.ports, maxPortsPerField, maxFieldValueLength, showAll)
+		logger.Info(protocol.name, "ports split into", len(chunks), "chunks")
+
+		for i, chunk := range chunks {
+			fieldName := fmt.Sprintf("%s %s (%d total)", protocol.icon, protocol.name, len(protocol.ports))
+			if len(chunks) > 1 {
+				fieldName = fmt.Sprintf("%s %s - Page %d/%d", protocol.icon, protocol.name, i+1, len(chunks))
+			}
+			portFields = append(portFields, &discordgo.MessageEmbedField{
+				Name:   fieldName,
+				Value:  chunk,
+				Inline: false,
+			})
+		}
+	}
+
+	// Add summary with notable services
+	logger.Info("Building summary section...")
+	summaryValue := fmt.Sprintf("**Original**: %d | **Unique**: %d | **TCP**: %d | **UDP**: %d",
+		originalCount, len(uniquePorts), len(tcpPorts), len(udpPorts))
+	if len(unixSockets) > 0 {
+		summaryValue += fmt.Sprintf(" | **Unix**: %d", len(unixSockets))
+	}
+
+	// Add notable services
+	notableServices := b.getNotableServices(uniquePorts)
+	if notableServices != "" {
+		summaryValue += fmt.Sprintf("\n\n**Services**: %s", notableServices)
+		logger.Info("Notable services found:", notableServices)
+	}
+	if busiest := b.getBusiestServices(uniquePorts); busiest != "" {
+		summaryValue += fmt.Sprintf("\n**Busiest**: %s", busiest)
+	}
+	summary := &discordgo.MessageEmbedField{
+		Name:   "📊 Summary",
+		Value:  summaryValue,
+		Inline: false,
+	}
+
+	// The first page carries the summary; every page keeps room for a
+	// truncation notice so the embed total stays under Discord's limit
+	const noticeReserve = 100
+	newPage := func() *discordgo.MessageEmbed {
+		return &discordgo.MessageEmbed{
+			Title:       title,
+			Description: description,
+			Color:       0x3498db,
+			Timestamp:   b.Timestamp(),
+			Footer: &discordgo.MessageEmbedFooter{
+				Text: "System Network Monitor",
+			},
+		}
+	}
+	pages := []*discordgo.MessageEmbed{newPage()}
+	pageChars := len(title) + len(description) + len(summary.Name) + len(summary.Value) + noticeReserve
+	pageFields := 0
+	shown := 0
+	for _, field := range portFields {
+		fieldChars := len(field.Name) + len(field.Value)
+		if pageFields >= b.limits.MaxPortFields || pageChars+fieldChars > config.DiscordMaxEmbedChars {
+			if len(pages) >= b.limits.MaxPortMessages {
+				break
+			}
+			pages = append(pages, newPage())
+			pageChars = len(title) + len(description) + noticeReserve
+			pageFields = 0
+		}
+		page := pages[len(pages)-1]
+		page.Fields = append(page.Fields, field)
+		pageChars += fieldChars
+		pageFields++
+		shown++
+	}
+
+	if shown < len(portFields) {
+		logger.Info("Reached port message limit, adding truncation notice after", shown, "of", len(portFields), "fields")
+		last := pages[len(pages)-1]
+		last.Fields = append(last.Fields, &discordgo.MessageEmbedField{
+			Name:   "⚠️ Truncated",
+			Value:  fmt.Sprintf("Showing %d/%d port lists (Discord limit)", shown, len(portFields)),
+			Inline: false,
+		})
+	}
+	pages[0].Fields = append(pages[0].Fields, summary)
+
+	if len(pages) > 1 {
+		for n, page := range pages {
+			page.Title = fmt.Sprintf("%s (%d/%d)", title, n+1, len(pages))
+		}
+	}
+
+	logger.Info("Ports embed built successfully with", len(pages), "pages and", shown+1, "total fields")
+	return pages
+}
+
+func (b *Builder) BuildConnections(remotes []monitor.RemoteConnections, limit int) *discordgo.MessageEmbed {
+	logger.Info("Building connections embed for", len(remotes), "remotes, limit:", limit)
+
+	total := 0
+	for _, remote := range remotes {
+		total += remote.Count
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "📡 Top Remote Connections",
+		Description: fmt.Sprintf("**%d** established connections from **%d** remote addresses", total, len(remotes)),
+		Color:       0x3498db,
+		Timestamp:   b.Timestamp(),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "System Network Monitor",
+		},
+	}
+
+	var list strings.Builder
+	for i, remote := range remotes {
+		if i >= limit {
+			break
+		}
+		entry := fmt.Sprintf("**%d.** `%s` - %d connections\n", i+1, remote.Address, remote.Count)
+		if list.Len()+len(entry) > config.DiscordMaxFieldValueChars {
+			logger.Info("Reached field length limit after", i, "remotes")
+			break
+		}
+		list.WriteString(entry)
+	}
+
+	if list.Len() > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   fmt.Sprintf("🔝 Top %d Remotes", min(limit, len(remotes))),
+			Value:  strings.TrimSpace(list.String()),
+			Inline: false,
+		})
+	}
+
+	logger.Info("Connections embed built successfully with", len(embed.Fields), "fields")
+	return embed
+}
+
+func (b *Builder) BuildAlert(level string, sensors []monitor.TemperatureSensor, message string) *discordgo.MessageEmbed {
+	logger.Info("Building alert embed - Level:", level, "Sensors:", len(sensors))
+
+	// Find max temperature and worst status for color
+	maxTemp := 0.0
+	worst := monitor.TempNormal
+	for _, sensor := range sensors {
+		if sensor.Temperature > maxTemp {
+			maxTemp = sensor.Temperature
+		}
+		worst = max(worst, sensor.Status)
+	}
+	logger.Info("Alert max temperature:", maxTemp, "°C")
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("%s Temperature Alert", level),
+		Description: message,
+		Color:       b.getStatusColor(worst),
+		Timestamp:   b.Timestamp(),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "System Hardware Monitor - Alert",
+		},
+	}
+
+	// Add critical and warning sensors
+	logger.Info("Processing sensors for alert...")
+	alertSensors := ""
+	normalSensors := ""
+	sensorCount := 0
+	alertSensorCount := 0
+	normalSensorCount := 0
+
+	for _, sensor := range sensors {
+		if sensorCount >= b.limits.MaxAlertSensors {
+			logger.Info("Reached sensor limit for alert embed")
+			break
+		}
+
+		icon := b.getStatusIcon(sensor.Status)
+		sensorInfo := fmt.Sprintf("%s **%s**: %.1f°C\n", icon, sensor.Name, sensor.Temperature)
+
+		if sensor.Status >= monitor.TempWarning {
+			alertSensors += sensorInfo
+			alertSensorCount++
+		} else {
+			normalSensors += sensorInfo
+			normalSensorCount++
+		}
+		sensorCount++
+	}
+
+	logger.Info("Alert sensors breakdown - Alert/Warning:", alertSensorCount, "Normal:", normalSensorCount)
+
+	// Add alert sensors first
+	if alertSensors != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "🔥 Critical/Warning Sensors",
+			Value:  alertSensors,
+			Inline: false,
+		})
+	}
+
+	// Add normal sensors if space permits
+	if normalSensors != "" && len(embed.Fields) < 3 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "✅ Normal Sensors",
+			Value:  normalSensors,
+			Inline: false,
+		})
+	}
+
+	// Add timestamp
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "⏰ Alert Time",
+		Value:  b.FormatTime(time.Now()),
+		Inline: true,
+	})
+
+	logger.Info("Alert embed built successfully with", len(embed.Fields), "fields")
+	return embed
+}
+
+func (b *Builder) BuildFileDescriptorAlert(stats *monitor.FileDescriptorStats, threshold float64) *discordgo.MessageEmbed {
+	logger.Info("Building file descriptor alert embed - Usage:", stats.UsagePercent(), "% Threshold:", threshold, "%")
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "📂 File Descriptor Alert",
+		Description: fmt.Sprintf("System-wide file descriptor usage is above **%.1f%%**", threshold),
+		Color:       b.getStatusColor(monitor.TempWarning),
+		Timestamp:   b.Timestamp(),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "System Resource Monitor - Alert",
+		},
+	}
+
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "📊 Usage",
+		Value:  fmt.Sprintf("**%d** / **%d** (%.1f%%)", stats.Allocated, stats.Maximum, stats.UsagePercent()),
+		Inline: false,
+	})
+
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "⏰ Alert Time",
+		Value:  b.FormatTime(time.Now()),
+		Inline: true,
+	})
+
+	logger.Info("File descriptor alert embed built successfully with", len(embed.Fields), "fields")
+	return embed
+}
+
+// BuildPressureAlert reports memory pressure above the threshold
+func (b *Builder) BuildPressureAlert(memory monitor.ResourcePressure, threshold float64) *discordgo.MessageEmbed {
+	logger.Info("Building memory pressure alert embed - avg60:", memory.Some.Avg60, "% Threshold:", threshold, "%")
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "🧠 Memory Pressure Alert",
+		Description: fmt.Sprintf("Tasks stalled waiting for memory more than **%.1f%%** of the last minute", threshold),
+		Color:       b.getStatusColor(monitor.TempWarning),
+		Timestamp:   b.Timestamp(),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "System Resource Monitor - Alert",
+		},
+	}
+
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "📊 Stall Time (avg10 / avg60 / avg300)",
+		Value:  fmt.Sprintf("**Some**: %s\n**Full**: %s", formatPressure(memory.Some), formatPressure(memory.Full)),
+		Inline: false,
+	})
+
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "⏰ Alert Time",
+		Value:  b.FormatTime(time.Now()),
+		Inline: true,
+	})
+
+	logger.Info("Memory pressure alert embed built successfully with", len(embed.Fields), "fields")
+	return embed
+}
+
+// PressureSummary renders the "some" stall averages of each resource for
+// /status
+func (b *Builder) PressureSummary(pressures []monitor.ResourcePressure) string {
+	var lines []string
+	for _, pressure := range pressures {
+		lines = append(lines, fmt.Sprintf("**%s**: %s", pressure.Resource, formatPressure(pressure.Some)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatPressure renders PSI averages as "avg10 / avg60 / avg300"
+func formatPressure(averages monitor.PressureAverages) string {
+	return fmt.Sprintf("%.2f%% / %.2f%% / %.2f%%", averages.Avg10, averages.Avg60, averages.Avg300)
+}
+
+// BuildSensorMissingAlert reports sensors that stopped reporting for the
+// given number of consecutive cycles
+func (b *Builder) BuildSensorMissingAlert(names []string, cycles int) *discordgo.MessageEmbed {
+	logger.Info("Building missing sensor alert embed for", len(names), "sensors")
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "❓ Sensor Missing",
+		Description: fmt.Sprintf("These sensors have not reported for **%d** consecutive cycles - possible hardware failure or driver reload", cycles),
+		Color:       b.getStatusColor(monitor.TempWarning),
+		Timestamp:   b.Timestamp(),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "System Hardware Monitor - Alert",
+		},
+	}
+
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "📡 Missing Sensors",
+		Value:  truncate(strings.Join(names, "\n"), config.DiscordMaxFieldValueChars),
+		Inline: false,
+	})
+
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "⏰ Alert Time",
+		Value:  b.FormatTime(time.Now()),
+		Inline: true,
+	})
+
+	return embed
+}
+
+// BuildPortClosedAlert reports watched ports that are no longer listening
+func (b *Builder) BuildPortClosedAlert(ports []monitor.WatchedPort) *discordgo.MessageEmbed {
+	logger.Info("Building port closed alert embed for", len(ports), "ports")
+
+	names := make([]string, len(ports))
+	for idx, port := range ports {
+		names[idx] = port.String()
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "🔌 Watched Port Closed",
+		Description: "These ports are expected to be listening but no longer are - the service behind them may have stopped",
+		Color:       b.getStatusColor(monitor.TempCritical),
+		Timestamp:   b.Timestamp(),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "System Hardware Monitor - Alert",
+		},
+	}
+
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "🚪 Closed Ports",
+		Value:  truncate(strings.Join(names, "\n"), config.DiscordMaxFieldValueChars),
+		Inline: false,
+	})
+
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "⏰ Alert Time",
+		Value:  b.FormatTime(time.Now()),
+		Inline: true,
+	})
+
+	return embed
+}
+
+// BuildSensorAddedNotice announces sensors that appeared since the last cycle
+func (b *Builder) BuildSensorAddedNotice(sensors []monitor.TemperatureSensor) *discordgo.MessageEmbed {
+	logger.Info("Building new sensor notice embed for", len(sensors), "sensors")
+
+	var lines []string
+	for _, sensor := range sensors {
+		lines = append(lines, fmt.Sprintf("%s **%s** (%s): %.1f°C", b.getStatusIcon(sensor.Status), sensor.Name, sensor.Category, sensor.Temperature))
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "🆕 New Sensors Detected",
+		Description: truncate(strings.Join(lines, "\n"), config.DiscordMaxDescriptionChars),
+		Color:       0x3498db,
+		Timestamp:   b.Timestamp(),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "System Hardware Monitor",
+		},
+	}
+}
+
+// BuildDiskFillAlert warns that a filesystem will fill up at its current
+// growth rate (bytes per second)
+func (b *Builder) BuildDiskFillAlert(disk monitor.DiskUsage, timeToFull time.Duration, rate float64) *discordgo.MessageEmbed {
+	logger.Info("Building disk fill alert embed for", disk.Mountpoint, "- time to full:", timeToFull)
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "💽 Disk Filling Up",
+		Description: fmt.Sprintf("**%s** will be full in **~%s** at the current rate", disk.Mountpoint, formatDuration(timeToFull)),
+		Color:       b.getStatusColor(monitor.TempCritical),
+		Timestamp:   b.Timestamp(),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "System Resource Monitor - Alert",
+		},
+	}
+
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "📊 Usage",
+		Value:  fmt.Sprintf("%.1f%% used\n%s free of %s", disk.UsagePercent(), formatBytes(disk.Available), formatBytes(disk.Total)),
+		Inline: true,
+	})
+
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "📈 Growth Rate",
+		Value:  fmt.Sprintf("%s/min", formatBytes(uint64(rate*60))),
+		Inline: true,
+	})
+
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "🗄️ Device",
+		Value:  fmt.Sprintf("`%s` (%s)", disk.Device, disk.FSType),
+		Inline: true,
+	})
+
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "⏰ Alert Time",
+		Value:  b.FormatTime(time.Now()),
+		Inline: true,
+	})
+
+	return embed
+}
+
+// Disk usage levels for /disk; above diskFullPercent the embed turns red
+const (
+	diskFullPercent    = 90.0
+	diskWarningPercent = 75.0
+)
+
+// BuildDisk lists filesystem usage, one field per mountpoint
+func (b *Builder) BuildDisk(disks []monitor.DiskUsage) *discordgo.MessageEmbed {
+	logger.Info("Building disk embed for", len(disks), "filesystems")
+
+	embed := &discordgo.MessageEmbed{
+		Title:     "💽 Filesystem Usage",
+		Color:     0x2ecc71,
+		Timestamp: b.Timestamp(),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "System Disk Monitor",
+		},
+	}
+
+	full := 0
+	for _, disk := range disks {
+		if disk.UsagePercent() > diskFullPercent {
+			full++
 		}
 	}
 
-	logger.Info("Protocol distribution - TCP:", len(tcpPorts), "UDP:", len(udpPorts))
+	for idx, disk := range disks {
+		if len(embed.Fields) >= config.DiscordMaxEmbedFields-1 {
+			logger.Info("Reached Discord field limit for disk embed, adding truncation notice")
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:   "...",
+				Value:  fmt.Sprintf("And %d more filesystems", len(disks)-idx),
+				Inline: true,
+			})
+			break
+		}
+
+		percent := disk.UsagePercent()
+		icon := "🟢"
+		switch {
+		case percent > diskFullPercent:
+			icon = "🔴"
+		case percent > diskWarningPercent:
+			icon = "🟠"
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name: truncate(fmt.Sprintf("%s %s", icon, disk.Mountpoint), config.DiscordMaxFieldNameChars),
+			Value: fmt.Sprintf("**Used**: %.1f%% (%s of %s)\n**Free**: %s\n**Device**: `%s` (%s)",
+				percent, formatBytes(disk.Used), formatBytes(disk.Total), formatBytes(disk.Available), disk.Device, disk.FSType),
+			Inline: true,
+		})
+	}
+
+	embed.Description = fmt.Sprintf("%d filesystems", len(disks))
+	if full > 0 {
+		embed.Color = b.getStatusColor(monitor.TempCritical)
+		embed.Description += fmt.Sprintf(" • 🔴 **%d above %.0f%% used**", full, diskFullPercent)
+	}
+
+	logger.Info("Disk embed built successfully with", len(embed.Fields), "fields")
+	return embed
+}
+
+// CheckResult is the outcome of one self-test check
+type CheckResult struct {
+	Name   string
+	Passed bool
+	Detail string
+}
+
+// BuildSelfTest renders self-test results as a pass/fail matrix
+func (b *Builder) BuildSelfTest(results []CheckResult) *discordgo.MessageEmbed {
+	failed := 0
+	for _, result := range results {
+		if !result.Passed {
+			failed++
+		}
+	}
+	logger.Info("Building self-test embed -", len(results), "checks,", failed, "failed")
+
+	status := monitor.TempNormal
+	description := fmt.Sprintf("✅ All %d checks passed", len(results))
+	if failed > 0 {
+		status = monitor.TempCritical
+		description = fmt.Sprintf("❌ %d of %d checks failed", failed, len(results))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "🧪 Self-Test",
+		Description: description,
+		Color:       b.getStatusColor(status),
+		Timestamp:   b.Timestamp(),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "System Monitor Bot",
+		},
+	}
+
+	for _, result := range results {
+		if len(embed.Fields) >= config.DiscordMaxEmbedFields {
+			logger.Info("Reached Discord field limit for self-test embed")
+			break
+		}
+		icon := "✅"
+		if !result.Passed {
+			icon = "❌"
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   truncate(fmt.Sprintf("%s %s", icon, result.Name), config.DiscordMaxFieldNameChars),
+			Value:  truncate(result.Detail, config.DiscordMaxFieldValueChars),
+			Inline: false,
+		})
+	}
+
+	return embed
+}
+
+// FleetMember is one host in a fleet comparison; Err is set instead of
+// Snapshot when the host could not be read
+type FleetMember struct {
+	Name     string
+	Snapshot *monitor.HostSnapshot
+	Err      error
+}
+
+// BuildFleet compares the hosts side by side, one inline field per host,
+// keeping unreachable peers in place with their error
+func (b *Builder) BuildFleet(members []FleetMember) *discordgo.MessageEmbed {
+	logger.Info("Building fleet embed for", len(members), "hosts")
+
+	embed := &discordgo.MessageEmbed{
+		Title:     "🛰️ Fleet Overview",
+		Color:     0x2ecc71,
+		Timestamp: b.Timestamp(),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "System Monitor Bot",
+		},
+	}
+
+	reachable, hottest := 0, -1
+	worst := monitor.TempNormal
+	for idx, member := range members {
+		if len(embed.Fields) >= config.DiscordMaxEmbedFields {
+			logger.Warn("Fleet embed field limit reached - omitting", len(members)-idx, "hosts")
+			break
+		}
+		if member.Err != nil {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:   "⚫ " + member.Name,
+				Value:  truncate(fmt.Sprintf("Unavailable: %v", member.Err), 200),
+				Inline: true,
+			})
+			continue
+		}
+
+		snapshot := member.Snapshot
+		reachable++
+		if hottest < 0 || snapshot.MaxTemperature > members[hottest].Snapshot.MaxTemperature {
+			hottest = idx
+		}
+		// Peers report status by name; keep the worst for the embed color
+		status, _ := monitor.StatusByName(snapshot.Status)
+		if status > worst {
+			worst = status
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name: fmt.Sprintf("%s %s", b.getStatusIcon(status), snapshot.Name),
+			Value: fmt.Sprintf("🌡️ **%.1f°C** (%s)\n💾 **%.1f%%** of %s\n⚙️ %d processes\n🕒 %s",
+				snapshot.MaxTemperature, valueOrNone(snapshot.MaxSensor), snapshot.MemoryUsedPercent,
+				formatBytes(snapshot.MemoryTotalBytes), snapshot.Processes, b.FormatTime(snapshot.Time)),
+			Inline: true,
+		})
+	}
+
+	embed.Description = fmt.Sprintf("**%d/%d** hosts reachable", reachable, len(members))
+	if hottest >= 0 {
+		embed.Description += fmt.Sprintf(" • hottest: **%s** at %.1f°C",
+			members[hottest].Snapshot.Name, members[hottest].Snapshot.MaxTemperature)
+	}
+	if worst > monitor.TempNormal {
+		embed.Color = b.getStatusColor(worst)
+	} else if reachable < len(members) {
+		embed.Color = 0x95a5a6
+	}
+	return embed
+}
+
+// BuildConfig renders the effective configuration; secrets are only shown
+// as configured or not
+func (b *Builder) BuildConfig(cfg *config.Config) *discordgo.MessageEmbed {
+	logger.Info("Building configuration embed")
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "⚙️ Effective Configuration",
+		Description: "Values in use after applying environment variables and defaults",
+		Color:       0x95a5a6,
+		Timestamp:   b.Timestamp(),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "System Monitor Bot",
+		},
+	}
+
+	thresholds := cfg.Thresholds
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name: "🌡️ Thresholds",
+		Value: fmt.Sprintf("%s**Per-sensor overrides**: %d\n**Hysteresis**: %.1f°C\n**File descriptors**: %s\n**Memory pressure**: %s\n**Low battery**: %s\n**Disk fill window**: %s",
+			severitySummary(thresholds.Levels), len(thresholds.Sensors), thresholds.Hysteresis, percentOrOff(thresholds.FileDescriptorPercent),
+			percentOrOff(thresholds.MemoryPressurePercent),
+			percentOrOff(thresholds.LowBatteryPercent), durationOrOff(thresholds.DiskFillWindow)),
+		Inline: true,
+	})
+
+	mon := cfg.Monitor
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name: "⏱️ Intervals",
+		Value: fmt.Sprintf("**Monitor interval**: %v\n**Network interval**: %v\n**Alert cooldown**: %v\n**Sample window**: %v\n**HTTP timeout**: %v\n**Defer after**: %v",
+			mon.Interval, mon.NetworkInterval, mon.AlertCooldown, mon.SampleWindow, cfg.Discord.HTTPTimeout, cfg.Discord.DeferThreshold),
+		Inline: true,
+	})
+
+	allowed := "Any channel"
+	if len(mon.AllowedAlertChannels) > 0 {
+		allowed = fmt.Sprintf("%d channels", len(mon.AllowedAlertChannels))
+	}
+	defaultChannel := "None"
+	if mon.DefaultAlertChannel != "" {
+		defaultChannel = fmt.Sprintf("<#%s>", mon.DefaultAlertChannel)
+	}
+	alertPolicy := mon.AlertPolicy
+	if alertPolicy == config.AlertPolicyCount {
+		alertPolicy = fmt.Sprintf("%s (%d+ sensors)", alertPolicy, mon.AlertMinSensors)
+	}
+	missing := "Off"
+	if mon.MissingSensorCycles > 0 {
+		missing = fmt.Sprintf("%d cycles", mon.MissingSensorCycles)
+	}
+	watched := "None"
+	if len(mon.WatchedPorts) > 0 {
+		names := make([]string, len(mon.WatchedPorts))
+		for idx, port := range mon.WatchedPorts {
+			names[idx] = port.String()
+		}
+		watched = truncate(strings.Join(names, ", "), 300)
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name: "🚨 Alerts",
+		Value: fmt.Sprintf("**Mode**: %s\n**Policy**: %s\n**Cooldown applies to**: %s\n**Format**: %s\n**Recovery after**: %s\n**Allowed channels**: %s\n**Default channel**: %s\n**On battery**: %s\n**Missing sensors**: %s\n**New sensors**: %s\n**Silences persisted**: %s\n**Watched ports**: %s",
+			mon.AlertMode, alertPolicy, cooldownSummary(mon.CooldownPolicy), mon.AlertFormat, recoverySummary(mon.RecoveryCycles), allowed, defaultChannel, onOff(mon.AlertOnBattery), missing,
+			onOff(mon.AnnounceNewSensors), onOff(mon.SilencedSensorsFile != ""), watched),
+		Inline: false,
+	})
+
+	display := cfg.Display
+	limits := display.Limits
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name: "🖥️ Display",
+		Value: fmt.Sprintf("**Time zone**: %v\n**Time style**: %s\n**Throughput unit**: %s, %d decimals\n**Hide own process**: %s\n**Top CPU process**: %s\n**Process aliases file**: %s\n**Log file**: %s\n**Category emoji**: %d\n"+
+			"**Limits**: %d sensors, %d port fields × %d ports over %d messages, %d processes in /memory and %d in /top, %d alert sensors",
+			display.Location, display.TimeStyle, display.RateUnit, display.RatePrecision, onOff(display.ExcludeSelf), onOff(display.ShowTopProcess), valueOrNone(display.ProcessAliasesFile), logFile(cfg.Log), len(display.CategoryEmoji),
+			limits.MaxSensorFields, limits.MaxPortFields, limits.MaxPortsPerField, limits.MaxPortMessages,
+			limits.MemoryTopN, limits.MaxProcesses, limits.MaxAlertSensors),
+		Inline: false,
+	})
+
+	discord := cfg.Discord
+	guild := "Global commands"
+	switch {
+	case discord.PerGuildCommands:
+		guild = "Every joined guild"
+	case discord.GuildID != "":
+		guild = discord.GuildID
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name: "🤖 Bot",
+		Value: fmt.Sprintf("**Guild**: %s\n**Read-only**: %s\n**Dynamic status**: %s\n**Per-user command stats**: %s",
+			guild, onOff(discord.ReadOnly), onOff(discord.DynamicStatus), onOff(discord.TrackCommandUsers)),
+		Inline: true,
+	})
+
+	matrix := "Off"
+	if cfg.Matrix.Enabled() {
+		matrix = fmt.Sprintf("%s on %s", cfg.Matrix.RoomID, cfg.Matrix.Homeserver)
+	}
+	proxy := "Environment"
+	if discord.ProxyURL != "" {
+		proxy = "Configured (hidden)"
+	}
+	sudo := "None"
+	if len(mon.SudoCommands) > 0 {
+		sudo = truncate(strings.Join(mon.SudoCommands, ", "), 300)
+	}
+	remote := "Off"
+	if cfg.Remote.Enabled() {
+		remote = cfg.Remote.User + "@" + cfg.Remote.Host
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name: "🔌 Integrations",
+		Value: fmt.Sprintf("**Proxy**: %s\n**Metrics**: %s\n**Matrix**: %s\n**Fleet**: %s\n**JSON alerts**: %s\n**Remote host**: %s\n**Temperature backend**: %s\n**Sudo commands**: %s",
+			proxy, valueOrNone(mon.MetricsAddr), matrix, fleetSummary(cfg.Fleet), valueOrNone(mon.AlertOutput), remote, mon.TempBackend, sudo),
+		Inline: true,
+	})
+
+	return embed
+}
 
-	// Constants for Discord limits - adjusted for full addresses
-	const maxPortsPerField = 6       // Reduced since addresses will be longer
-	const maxFieldValueLength = 1000 // Slightly increased for full addresses
-	const maxTotalFields = 12        // Reduced to prevent hitting overall embed limits
+// recoverySummary describes how long an incident must stay normal before
+// its recovery is announced
+func recoverySummary(cycles int) string {
+	if cycles <= 1 {
+		return "First normal cycle"
+	}
+	return fmt.Sprintf("%d normal cycles", cycles)
+}
 
-	fieldCount := 0
+// fleetSummary describes the fleet settings; the peer URLs stay hidden as
+// they may carry credentials
+func fleetSummary(fleet config.FleetConfig) string {
+	return fmt.Sprintf("%s, snapshot %s, %d peers, token %s", fleet.Name, onOff(fleet.ServeSnapshot),
+		len(fleet.Peers), onOff(fleet.Token != ""))
+}
+
+// cooldownSummary describes what the alert cooldown throttles
+func cooldownSummary(policy string) string {
+	if policy == config.CooldownPolicyRepeats {
+		return "Repeats only"
+	}
+	return "Every alert"
+}
+
+// onOff renders a boolean setting
+func onOff(enabled bool) string {
+	if enabled {
+		return "On"
+	}
+	return "Off"
+}
 
-	// Add TCP ports section with pagination
-	if len(tcpPorts) > 0 && fieldCount < maxTotalFields {
-		logger.Info("Processing TCP ports...")
-		tcpChunks := b.chunkPorts(tcpPorts, maxPortsPerField, maxFieldValueLength)
-		logger.Info("TCP ports split into", len(tcpChunks), "chunks")
+// severitySummary lists each severity level with the temperature it starts at
+func severitySummary(levels []monitor.SeverityLevel) string {
+	var summary strings.Builder
+	for _, level := range levels {
+		fmt.Fprintf(&summary, "%s **%s**: %.1f°C\n", level.Icon, level.Name, level.Threshold)
+	}
+	return summary.String()
+}
 
-		for i, chunk := range tcpChunks {
-			if fieldCount >= maxTotalFields {
-				logger.Info("Reached field limit, adding TCP truncation notice")
-				embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-					Name:   "⚠️ Truncated",
-					Value:  fmt.Sprintf("Showing %d/%d TCP ports (Discord limit)", i, len(tcpChunks)),
-					Inline: false,
-				})
-				break
-			}
+// percentOrOff renders a percentage threshold where zero disables it
+func percentOrOff(percent float64) string {
+	if percent <= 0 {
+		return "Off"
+	}
+	return fmt.Sprintf("%.0f%%", percent)
+}
 
-			fieldName := fmt.Sprintf("🔵 TCP (%d total)", len(tcpPorts))
-			if len(tcpChunks) > 1 {
-				fieldName = fmt.Sprintf("🔵 TCP - Page %d/%d", i+1, len(tcpChunks))
-			}
+// durationOrOff renders a duration setting where zero disables it
+func durationOrOff(d time.Duration) string {
+	if d <= 0 {
+		return "Off"
+	}
+	return d.String()
+}
 
-			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-				Name:   fieldName,
-				Value:  chunk,
-				Inline: false,
-			})
-			fieldCount++
-		}
+// logFile describes the log file setting for the configuration embed
+func logFile(lc config.LogConfig) string {
+	if lc.File == "" {
+		return "None"
 	}
+	return fmt.Sprintf("`%s` (%d MB × %d)", lc.File, lc.MaxSizeMB, lc.MaxFiles)
+}
 
-	// Add UDP ports section with pagination
-	if len(udpPorts) > 0 && fieldCount < maxTotalFields {
-		logger.Info("Processing UDP ports...")
-		udpChunks := b.chunkPorts(udpPorts, maxPortsPerField, maxFieldValueLength)
-		logger.Info("UDP ports split into", len(udpChunks), "chunks")
+// valueOrNone renders an optional string setting
+func valueOrNone(value string) string {
+	if value == "" {
+		return "None"
+	}
+	return value
+}
 
-		for i, chunk := range udpChunks {
-			if fieldCount >= maxTotalFields {
-				logger.Info("Reached field limit, adding UDP truncation notice")
-				embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-					Name:   "⚠️ Truncated",
-					Value:  fmt.Sprintf("Showing %d/%d UDP ports (Discord limit)", i, len(udpChunks)),
-					Inline: false,
-				})
-				break
-			}
+// cpuLinesPerField is how many per-CPU lines are packed into one embed field
+const cpuLinesPerField = 16
 
-			fieldName := fmt.Sprintf("🟡 UDP (%d total)", len(udpPorts))
-			if len(udpChunks) > 1 {
-				fieldName = fmt.Sprintf("🟡 UDP - Page %d/%d", i+1, len(udpChunks))
-			}
+// BuildCPU builds the CPU load embed with one line per logical CPU
+func (b *Builder) BuildCPU(usage *monitor.CPUUsage) *discordgo.MessageEmbed {
+	logger.Info("Building CPU embed for", len(usage.Cores), "CPUs")
 
-			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-				Name:   fieldName,
-				Value:  chunk,
-				Inline: false,
-			})
-			fieldCount++
-		}
+	var lines []string
+	for _, core := range usage.Cores {
+		lines = append(lines, fmt.Sprintf("`cpu%-3d` %s `%5.1f%%`", core.CPU, loadBar(core.Percent), core.Percent))
 	}
+	return b.buildCPUEmbed(usage, lines)
+}
 
-	// Add summary with notable services
-	logger.Info("Building summary section...")
-	summaryValue := fmt.Sprintf("**Original**: %d | **Unique**: %d | **TCP**: %d | **UDP**: %d",
-		originalCount, len(uniquePorts), len(tcpPorts), len(udpPorts))
-
-	// Add notable services
-	notableServices := b.getNotableServices(uniquePorts)
-	if notableServices != "" {
-		summaryValue += fmt.Sprintf("\n\n**Services**: %s", notableServices)
-		logger.Info("Notable services found:", notableServices)
+// loadBarSegments is the width of a load bar; with cpuLinesPerField lines
+// a field stays within Discord's value limit
+const loadBarSegments = 8
+
+// loadBar draws a load percentage as squares colored by how busy the CPU
+// is, e.g. 🟨🟨🟨🟨🟨⬛⬛⬛ for 65%
+func loadBar(percent float64) string {
+	filled := min(max(int(percent/100*loadBarSegments+0.5), 0), loadBarSegments)
+	segment := "🟩"
+	switch {
+	case percent >= 85:
+		segment = "🟥"
+	case percent >= 60:
+		segment = "🟨"
 	}
+	return strings.Repeat(segment, filled) + strings.Repeat("⬛", loadBarSegments-filled)
+}
 
-	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-		Name:   "📊 Summary",
-		Value:  summaryValue,
-		Inline: false,
-	})
+// BuildCPUDetailed pairs each logical CPU's load with its core temperature
+func (b *Builder) BuildCPUDetailed(usage *monitor.CPUUsage, sensors []monitor.TemperatureSensor) *discordgo.MessageEmbed {
+	logger.Info("Building detailed CPU embed for", len(usage.Cores), "CPUs and", len(sensors), "sensors")
+
+	var lines []string
+	matched := 0
+	for _, core := range usage.Cores {
+		line := fmt.Sprintf("`cpu%-3d` %5.1f%%", core.CPU, core.Percent)
+		if sensor, found := monitor.CoreTemperature(core, sensors); found {
+			line += fmt.Sprintf(" • %s %.1f°C (%s)", b.getStatusIcon(sensor.Status), sensor.Temperature, sensor.Feature)
+			matched++
+		} else {
+			line += " • —"
+		}
+		lines = append(lines, line)
+	}
 
-	logger.Info("Ports embed built successfully with", fieldCount+1, "total fields")
+	embed := b.buildCPUEmbed(usage, lines)
+	if matched == 0 {
+		embed.Description += "\n_No per-core temperatures available (coretemp not detected)_"
+	}
 	return embed
 }
 
-func (b *Builder) BuildAlert(level string, sensors []monitor.TemperatureSensor, message string) *discordgo.MessageEmbed {
-	logger.Info("Building alert embed - Level:", level, "Sensors:", len(sensors))
+func (b *Builder) buildCPUEmbed(usage *monitor.CPUUsage, lines []string) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title:       "🧮 CPU Load",
+		Description: fmt.Sprintf("**Overall**: %.1f%% across %d CPUs", usage.Overall, len(usage.Cores)),
+		Color:       0x3498db,
+		Timestamp:   b.Timestamp(),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "System CPU Monitor",
+		},
+	}
 
-	// Find max temperature for color
-	maxTemp := 0.0
-	for _, sensor := range sensors {
-		if sensor.Temperature > maxTemp {
-			maxTemp = sensor.Temperature
+	for start := 0; start < len(lines); start += cpuLinesPerField {
+		if len(embed.Fields) >= config.DiscordMaxEmbedFields {
+			logger.Info("Reached Discord field limit for CPU embed")
+			break
 		}
+		end := min(start+cpuLinesPerField, len(lines))
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   fmt.Sprintf("CPUs %d-%d", start, end-1),
+			Value:  truncate(strings.Join(lines[start:end], "\n"), config.DiscordMaxFieldValueChars),
+			Inline: false,
+		})
 	}
-	logger.Info("Alert max temperature:", maxTemp, "°C")
+
+	return embed
+}
+
+func (b *Builder) BuildPower(supplies []monitor.PowerSupply) *discordgo.MessageEmbed {
+	logger.Info("Building power embed for", len(supplies), "supplies")
 
 	embed := &discordgo.MessageEmbed{
-		Title:       fmt.Sprintf("%s Temperature Alert", level),
-		Description: message,
-		Color:       b.getStatusColor(b.getTemperatureStatus(maxTemp)),
-		Timestamp:   time.Now().Format(time.RFC3339),
+		Title:     "🔋 Power Supplies",
+		Color:     0x2ecc71,
+		Timestamp: b.Timestamp(),
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "System Hardware Monitor - Alert",
+			Text: "System Power Monitor",
 		},
 	}
 
-	// Add critical and warning sensors
-	logger.Info("Processing sensors for alert...")
-	alertSensors := ""
-	normalSensors := ""
-	sensorCount := 0
-	alertSensorCount := 0
-	normalSensorCount := 0
-
-	for _, sensor := range sensors {
-		if sensorCount >= 15 { // Limit for alert embeds
-			logger.Info("Reached sensor limit for alert embed")
+	for _, supply := range supplies {
+		if len(embed.Fields) >= config.DiscordMaxEmbedFields {
+			logger.Info("Reached Discord field limit for power embed")
 			break
 		}
 
-		icon := b.getStatusIcon(sensor.Status)
-		sensorInfo := fmt.Sprintf("%s **%s**: %.1f°C\n", icon, sensor.Name, sensor.Temperature)
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   fmt.Sprintf("%s %s", b.getPowerIcon(supply), supply.Name),
+			Value:  b.formatPowerSupply(supply),
+			Inline: true,
+		})
 
-		if sensor.Status == monitor.TempCritical || sensor.Status == monitor.TempWarning {
-			alertSensors += sensorInfo
-			alertSensorCount++
-		} else {
-			normalSensors += sensorInfo
-			normalSensorCount++
+		if supply.OnBattery() {
+			embed.Color = b.getStatusColor(monitor.TempWarning)
 		}
-		sensorCount++
 	}
 
-	logger.Info("Alert sensors breakdown - Alert/Warning:", alertSensorCount, "Normal:", normalSensorCount)
+	logger.Info("Power embed built successfully with", len(embed.Fields), "fields")
+	return embed
+}
 
-	// Add alert sensors first
-	if alertSensors != "" {
-		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   "🔥 Critical/Warning Sensors",
-			Value:  alertSensors,
-			Inline: false,
-		})
+func (b *Builder) BuildPowerAlert(supplies []monitor.PowerSupply, message string) *discordgo.MessageEmbed {
+	logger.Info("Building power alert embed for", len(supplies), "supplies")
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "🪫 Power Alert",
+		Description: message,
+		Color:       b.getStatusColor(monitor.TempWarning),
+		Timestamp:   b.Timestamp(),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "System Power Monitor - Alert",
+		},
 	}
 
-	// Add normal sensors if space permits
-	if normalSensors != "" && len(embed.Fields) < 3 {
+	for _, supply := range supplies {
+		if !supply.IsBattery() {
+			continue
+		}
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   "✅ Normal Sensors",
-			Value:  normalSensors,
-			Inline: false,
+			Name:   fmt.Sprintf("%s %s", b.getPowerIcon(supply), supply.Name),
+			Value:  b.formatPowerSupply(supply),
+			Inline: true,
 		})
 	}
 
-	// Add timestamp
 	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
 		Name:   "⏰ Alert Time",
-		Value:  time.Now().Format("2006-01-02 15:04:05 MST"),
+		Value:  b.FormatTime(time.Now()),
+		Inline: false,
+	})
+
+	logger.Info("Power alert embed built successfully with", len(embed.Fields), "fields")
+	return embed
+}
+
+func (b *Builder) formatPowerSupply(supply monitor.PowerSupply) string {
+	value := fmt.Sprintf("**Type**: %s", supply.Type)
+	if supply.IsBattery() {
+		if supply.HasCapacity {
+			value += fmt.Sprintf("\n**Charge**: %.0f%%", supply.Capacity)
+		}
+		if supply.Status != "" {
+			value += fmt.Sprintf("\n**Status**: %s", supply.Status)
+		}
+	} else if supply.Online {
+		value += "\n**Status**: Online"
+	} else {
+		value += "\n**Status**: Offline"
+	}
+	if supply.PowerWatts >= 0 {
+		value += fmt.Sprintf("\n**Power**: %.1f W", supply.PowerWatts)
+	}
+	return value
+}
+
+func (b *Builder) getPowerIcon(supply monitor.PowerSupply) string {
+	switch {
+	case !supply.IsBattery():
+		return "🔌"
+	case supply.OnBattery() && supply.HasCapacity && supply.Capacity < 20:
+		return "🪫"
+	default:
+		return "🔋"
+	}
+}
+
+func (b *Builder) BuildRecovery(current monitor.TemperatureSensor, peak float64, duration time.Duration) *discordgo.MessageEmbed {
+	logger.Info("Building recovery embed - Peak:", peak, "°C Duration:", duration)
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "✅ Temperature Recovered",
+		Description: "All temperatures are back to normal.",
+		Color:       b.getStatusColor(monitor.TempNormal),
+		Timestamp:   b.Timestamp(),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "System Hardware Monitor - Recovery",
+		},
+	}
+
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "🌡️ Current Max",
+		Value:  fmt.Sprintf("%.1f°C (%s)", current.Temperature, current.Name),
+		Inline: true,
+	})
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "🔺 Peak",
+		Value:  fmt.Sprintf("%.1f°C", peak),
+		Inline: true,
+	})
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "⏱️ Duration",
+		Value:  duration.String(),
 		Inline: true,
 	})
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "⏰ Recovered At",
+		Value:  b.FormatTime(time.Now()),
+		Inline: false,
+	})
 
-	logger.Info("Alert embed built successfully with", len(embed.Fields), "fields")
+	logger.Info("Recovery embed built successfully with", len(embed.Fields), "fields")
 	return embed
 }
 
@@ -372,11 +1469,15 @@ func (b *Builder) deduplicatePorts(ports []monitor.NetworkPort) []monitor.Networ
 		unique = append(unique, port)
 	}
 
-	// Sort by protocol first, then by port number
+	// Sort by protocol first, then by port number; Unix sockets have no
+	// port and sort by path
 	logger.Info("Sorting", len(unique), "unique ports")
 	sort.Slice(unique, func(i, j int) bool {
 		if unique[i].Protocol != unique[j].Protocol {
-			return unique[i].Protocol == "TCP" // TCP before UDP
+			return protocolRank(unique[i].Protocol) < protocolRank(unique[j].Protocol)
+		}
+		if unique[i].Protocol == "UNIX" {
+			return unique[i].Address < unique[j].Address
 		}
 
 		// Convert port strings to integers for proper numeric sorting
@@ -389,6 +1490,18 @@ func (b *Builder) deduplicatePorts(ports []monitor.NetworkPort) []monitor.Networ
 	return unique
 }
 
+// protocolRank orders TCP before UDP before Unix sockets
+func protocolRank(protocol string) int {
+	switch strings.ToUpper(protocol) {
+	case "TCP":
+		return 0
+	case "UDP":
+		return 1
+	default:
+		return 2
+	}
+}
+
 // parsePortNumber safely converts port string to int for sorting
 func (b *Builder) parsePortNumber(portStr string) int {
 	// Handle cases where port might have extra characters
@@ -404,8 +1517,9 @@ func (b *Builder) parsePortNumber(portStr string) int {
 	return portNum
 }
 
-// chunkPorts splits ports into chunks that fit Discord field limits
-func (b *Builder) chunkPorts(ports []monitor.NetworkPort, maxPorts int, maxLength int) []string {
+// chunkPorts splits ports into chunks that fit Discord field limits; with
+// showPID each entry also carries its process ID
+func (b *Builder) chunkPorts(ports []monitor.NetworkPort, maxPorts int, maxLength int, showPID bool) []string {
 	logger.Info("Chunking", len(ports), "ports with maxPorts:", maxPorts, "maxLength:", maxLength)
 
 	if len(ports) == 0 {
@@ -419,15 +1533,17 @@ func (b *Builder) chunkPorts(ports []monitor.NetworkPort, maxPorts int, maxLengt
 
 	for i, port := range ports {
 		// Format port entry with full address and process name
-		processName := b.shortenProcessName(port.ProcessName)
+		processName := b.processSummary(port, showPID)
 		address := b.formatAddress(port.Address)
 
 		// Use a more compact format to fit full addresses
 		portEntry := fmt.Sprintf("`%s` %s\n", address, processName)
+		if port.Established > 0 {
+			portEntry = fmt.Sprintf("`%s` %s • 🔗 %d\n", address, processName, port.Established)
+		}
 
 		// Check if adding this entry would exceed limits
-		// Be more flexible with length to accommodate full addresses
-		if currentCount >= maxPorts || currentChunk.Len()+len(portEntry) > (maxLength+200) {
+		if currentCount >= maxPorts || currentChunk.Len()+len(portEntry) > maxLength {
 			if currentChunk.Len() > 0 {
 				chunks = append(chunks, strings.TrimSpace(currentChunk.String()))
 				chunkNumber++
@@ -456,6 +1572,34 @@ func (b *Builder) chunkPorts(ports []monitor.NetworkPort, maxPorts int, maxLengt
 	return chunks
 }
 
+// maxListedPIDs caps the PIDs a ports entry lists before summarising the
+// rest as a count
+const maxListedPIDs = 3
+
+// processSummary is the compact process column of a ports entry: the first
+// process, "×N" when several share the socket, and with showPID their PIDs
+func (b *Builder) processSummary(port monitor.NetworkPort, showPID bool) string {
+	summary := b.shortenProcessName(port.ProcessName)
+	if count := len(port.Processes); count > 1 {
+		summary += fmt.Sprintf(" ×%d", count)
+	}
+
+	pids := port.PIDs()
+	if !showPID || len(pids) == 0 {
+		return summary
+	}
+	more := ""
+	if len(pids) > maxListedPIDs {
+		more = fmt.Sprintf(" +%d", len(pids)-maxListedPIDs)
+		pids = pids[:maxListedPIDs]
+	}
+	label := "PID"
+	if len(pids) > 1 {
+		label = "PIDs"
+	}
+	return summary + fmt.Sprintf(" `%s %s%s`", label, strings.Join(pids, ", "), more)
+}
+
 // formatAddress shows the complete, unmodified address
 func (b *Builder) formatAddress(address string) string {
 	// Return the full address exactly as it appears in the system
@@ -469,59 +1613,14 @@ func (b *Builder) shortenProcessName(processName string) string {
 		return "Unknown"
 	}
 
-	// Clean up the name
 	cleaned := processName
 
-	// Remove PID information to save space
-	if strings.Contains(cleaned, " (PID:") {
-		parts := strings.Split(cleaned, " (PID:")
-		if len(parts) > 0 {
-			cleaned = parts[0]
-		}
-	}
-
-	// Map common service names to shorter versions
-	serviceAliases := map[string]string{
-		"Docker Container Port": "Docker",
-		"Docker Engine":         "Docker",
-		"Container Runtime":     "Containerd",
-		"Nginx Web Server":      "Nginx",
-		"Apache Web Server":     "Apache",
-		"Node.js Application":   "Node.js",
-		"MySQL Database":        "MySQL",
-		"PostgreSQL Database":   "PostgreSQL",
-		"Redis Cache":           "Redis",
-		"MongoDB Database":      "MongoDB",
-		"SSH Server":            "SSH",
-		"System Service":        "Systemd",
-		"DNS Resolver":          "Resolved",
-		"DHCP Client":           "DHCP",
-		"Python Application":    "Python",
-		"Java Application":      "Java",
-	}
-
-	// Check for exact matches
-	if alias, exists := serviceAliases[cleaned]; exists {
-		return alias
-	}
-
-	// Check for partial matches
-	cleanedLower := strings.ToLower(cleaned)
-	for full, alias := range serviceAliases {
-		if strings.Contains(cleanedLower, strings.ToLower(full)) {
-			return alias
-		}
-	}
-
-	// Handle common patterns
-	if strings.Contains(cleanedLower, "docker") {
-		return "Docker"
+	// Map known service names to shorter versions
+	if short, exists := monitor.ShortAliasFor(cleaned); exists {
+		return short
 	}
-	if strings.Contains(cleanedLower, "nginx") {
-		return "Nginx"
-	}
-	if strings.Contains(cleanedLower, "apache") || strings.Contains(cleanedLower, "httpd") {
-		return "Apache"
+	if alias, exists := monitor.LookupProcessAlias(cleaned, true); exists {
+		return alias.Short
 	}
 
 	// Intelligent truncation - preserve meaningful parts
@@ -540,34 +1639,42 @@ func (b *Builder) shortenProcessName(processName string) string {
 	return cleaned
 }
 
+// getBusiestServices lists the listening ports with the most established
+// connections; empty when no connection counts were collected
+func (b *Builder) getBusiestServices(ports []monitor.NetworkPort) string {
+	const maxBusiest = 3
+
+	var busy []monitor.NetworkPort
+	seen := make(map[string]bool)
+	for _, port := range ports {
+		// IPv4 and IPv6 listeners on one port share the same count
+		if port.Established > 0 && !seen[port.Port] {
+			busy = append(busy, port)
+			seen[port.Port] = true
+		}
+	}
+	sort.SliceStable(busy, func(i, j int) bool { return busy[i].Established > busy[j].Established })
+	if len(busy) > maxBusiest {
+		busy = busy[:maxBusiest]
+	}
+
+	var entries []string
+	for _, port := range busy {
+		entries = append(entries, fmt.Sprintf("%s:%s (%d)", b.shortenProcessName(port.ProcessName), port.Port, port.Established))
+	}
+	return strings.Join(entries, ", ")
+}
+
 // getNotableServices identifies well-known services for the summary
 func (b *Builder) getNotableServices(ports []monitor.NetworkPort) string {
 	logger.Info("Identifying notable services from", len(ports), "ports")
 
-	wellKnownPorts := map[string]string{
-		"22":    "SSH",
-		"80":    "HTTP",
-		"443":   "HTTPS",
-		"3306":  "MySQL",
-		"5432":  "PostgreSQL",
-		"6379":  "Redis",
-		"27017": "MongoDB",
-		"8080":  "HTTP-Alt",
-		"8443":  "HTTPS-Alt",
-		"9000":  "SonarQube",
-		"5672":  "RabbitMQ",
-		"15672": "RabbitMQ-UI",
-		"1433":  "SQL Server",
-		"9200":  "Elasticsearch",
-		"9300":  "Elasticsearch",
-	}
-
 	var services []string
 	seen := make(map[string]bool)
 	foundServices := 0
 
 	for _, port := range ports {
-		if service, exists := wellKnownPorts[port.Port]; exists && !seen[service] {
+		if service := monitor.LookupService(port.Port, port.Protocol); service != "" && !seen[service] {
 			services = append(services, fmt.Sprintf("%s:%s", service, port.Port))
 			seen[service] = true
 			foundServices++
@@ -592,48 +1699,127 @@ func (b *Builder) getNotableServices(ports []monitor.NetworkPort) string {
 	return ""
 }
 
-// Helper functions for temperature monitoring
-func (b *Builder) getTemperatureStatus(temp float64) monitor.TempStatus {
-	if temp >= b.criticalThreshold {
-		return monitor.TempCritical
+// FormatTime renders t in the configured time style: an absolute time in the
+// display zone, a zone-independent Discord relative timestamp, or both
+func (b *Builder) FormatTime(t time.Time) string {
+	if b.location != nil {
+		t = t.In(b.location)
 	}
-	if temp >= b.warningThreshold {
-		return monitor.TempWarning
+	absolute := t.Format("2006-01-02 15:04:05 MST")
+	relative := fmt.Sprintf("<t:%d:R>", t.Unix())
+
+	switch b.timeStyle {
+	case config.TimeStyleAbsolute:
+		return absolute
+	case config.TimeStyleRelative:
+		return relative
+	default:
+		return fmt.Sprintf("%s (%s)", absolute, relative)
 	}
-	return monitor.TempNormal
 }
 
-func (b *Builder) getStatusIcon(status monitor.TempStatus) string {
-	switch status {
-	case monitor.TempCritical:
-		return "🚨"
-	case monitor.TempWarning:
-		return "⚠️"
-	default:
-		return "✅"
+// Timestamp returns the current time for an embed's Timestamp field, which
+// Discord renders in each viewer's own locale
+func (b *Builder) Timestamp() string {
+	return time.Now().Format(time.RFC3339)
+}
+
+// formatProcessMemory renders a process's memory as percent and absolute
+// size, leading with the one the list is sorted by
+func formatProcessMemory(process monitor.ProcessMemory, byResident bool) string {
+	if process.ResidentBytes == 0 {
+		return fmt.Sprintf("%.1f%%", process.MemoryPercent)
+	}
+	if byResident {
+		return fmt.Sprintf("%s (%.1f%%)", formatBytes(process.ResidentBytes), process.MemoryPercent)
 	}
+	return fmt.Sprintf("%.1f%% (%s)", process.MemoryPercent, formatBytes(process.ResidentBytes))
 }
 
-func (b *Builder) getStatusColor(status monitor.TempStatus) int {
-	switch status {
-	case monitor.TempCritical:
-		return 0xff0000 // Red
-	case monitor.TempWarning:
-		return 0xff8800 // Orange
+// formatBytes renders a byte count with a binary unit, e.g. "1.5 GiB"
+func formatBytes(bytes uint64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := uint64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// FormatRate renders a network throughput in the configured unit, scaled by
+// powers of 1000 as network rates conventionally are, e.g. "12.5 Mbps" or
+// "1.6 MB/s"
+func (b *Builder) FormatRate(bytesPerSecond float64) string {
+	value, units := bytesPerSecond, []string{"B/s", "KB/s", "MB/s", "GB/s", "TB/s"}
+	if b.rateUnit != config.RateUnitBytes {
+		value, units = bytesPerSecond*8, []string{"bps", "Kbps", "Mbps", "Gbps", "Tbps"}
+	}
+
+	exp := 0
+	for value >= 1000 && exp < len(units)-1 {
+		value /= 1000
+		exp++
+	}
+	return fmt.Sprintf("%.*f %s", b.ratePrecision, value, units[exp])
+}
+
+// formatDuration renders a duration rounded to a readable precision
+func formatDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return d.Round(time.Second).String()
+	case d < time.Hour:
+		return fmt.Sprintf("%d min", int(d.Round(time.Minute).Minutes()))
 	default:
-		return 0x00ff00 // Green
+		return d.Round(time.Minute).String()
+	}
+}
+
+// truncate shortens s to at most max characters, marking the cut with an ellipsis
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	if max <= 1 {
+		return string(runes[:max])
 	}
+	return string(runes[:max-1]) + "…"
+}
+
+// Helper functions for temperature monitoring
+func (b *Builder) getStatusIcon(status monitor.TempStatus) string {
+	return status.Level().Icon
+}
+
+func (b *Builder) getStatusColor(status monitor.TempStatus) int {
+	return status.Level().Color
 }
 
-func (b *Builder) BuildMemory(processes []monitor.ProcessMemory) *discordgo.MessageEmbed {
-	logger.Info("Building memory embed for", len(processes), "processes")
+// BuildMemory lists the top processes by %MEM or, with byResident, by their
+// absolute resident memory, which says more on very large machines
+func (b *Builder) BuildMemory(processes []monitor.ProcessMemory, byResident bool, usage *monitor.MemoryUsage) *discordgo.MessageEmbed {
+	logger.Info("Building memory embed for", len(processes), "processes, by resident memory:", byResident)
+
+	sortKey := "%MEM"
+	if byResident {
+		sortKey = "RSS"
+		processes = append([]monitor.ProcessMemory(nil), processes...)
+		sort.SliceStable(processes, func(i, j int) bool {
+			return processes[i].ResidentBytes > processes[j].ResidentBytes
+		})
+	}
 
 	embed := &discordgo.MessageEmbed{
-		Title:     "💾 Top 10 Memory Usage (%MEM)", // Updated title
+		Title:     fmt.Sprintf("💾 Top %d Memory Usage (%s)", len(processes), sortKey),
 		Color:     0x9b59b6,
-		Timestamp: time.Now().Format(time.RFC3339),
+		Timestamp: b.Timestamp(),
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "System Memory Monitor - Sorted by %MEM column", // Updated footer
+			Text: fmt.Sprintf("System Memory Monitor - Sorted by %s", sortKey),
 		},
 	}
 
@@ -644,20 +1830,21 @@ func (b *Builder) BuildMemory(processes []monitor.ProcessMemory) *discordgo.Mess
 	}
 
 	totalMemory := 0.0
+	var totalResident uint64
 	for _, process := range processes {
 		totalMemory += process.MemoryPercent
+		totalResident += process.ResidentBytes
 	}
 
-	embed.Description = fmt.Sprintf("Top %d processes by **%%MEM** consuming **%.1f%%** total memory", len(processes), totalMemory)
+	embed.Description = fmt.Sprintf("Top %d processes by **%s** consuming **%.1f%%** total memory", len(processes), sortKey, totalMemory)
+	if totalResident > 0 {
+		embed.Description += fmt.Sprintf(" (**%s**)", formatBytes(totalResident))
+	}
 	logger.Info("Memory embed description set with total:", totalMemory, "%")
 
 	// Add individual process fields
 	logger.Info("Adding individual process fields...")
 	for i, process := range processes {
-		if i >= 10 {
-			break
-		}
-
 		var emoji string
 		if process.MemoryPercent >= 10.0 {
 			emoji = "🔴" // High usage
@@ -669,9 +1856,15 @@ func (b *Builder) BuildMemory(processes []monitor.ProcessMemory) *discordgo.Mess
 			emoji = "🟢" // Low usage
 		}
 
-		fieldName := fmt.Sprintf("%s #%d - %s", emoji, i+1, process.Command)
-		fieldValue := fmt.Sprintf("**Memory**: %.1f%%\n**CPU**: %.1f%%\n**User**: %s\n**PID**: %s",
-			process.MemoryPercent, process.CPUPercent, process.User, process.PID)
+		// Keep titles short; the full command goes in the body when truncated
+		displayCommand := truncate(process.Command, maxCommandDisplayLength)
+		fieldName := truncate(fmt.Sprintf("%s #%d - %s", emoji, i+1, displayCommand), config.DiscordMaxFieldNameChars)
+		memory := formatProcessMemory(process, byResident)
+		fieldValue := fmt.Sprintf("**Memory**: %s\n**CPU**: %.1f%%\n**User**: %s\n**PID**: %s",
+			memory, process.CPUPercent, process.User, process.PID)
+		if displayCommand != process.Command {
+			fieldValue += fmt.Sprintf("\n**Command**: %s", truncate(process.Command, config.DiscordMaxFieldValueChars-len(fieldValue)-20))
+		}
 
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
 			Name:   fieldName,
@@ -684,8 +1877,12 @@ func (b *Builder) BuildMemory(processes []monitor.ProcessMemory) *discordgo.Mess
 
 	// Add summary field
 	if len(processes) > 0 {
-		summaryValue := fmt.Sprintf("**Highest**: %s (%.1f%%)\n**Average**: %.1f%%\n**Last Updated**: <t:%d:R>",
-			processes[0].Command, processes[0].MemoryPercent, totalMemory/float64(len(processes)), time.Now().Unix())
+		summaryValue := fmt.Sprintf("**Highest**: %s (%s)\n**Average**: %.1f%%\n",
+			truncate(processes[0].Command, maxCommandDisplayLength), formatProcessMemory(processes[0], byResident), totalMemory/float64(len(processes)))
+		if usage != nil {
+			summaryValue += memoryBreakdown(usage, totalMemory, totalResident)
+		}
+		summaryValue += fmt.Sprintf("**Last Updated**: %s", b.FormatTime(time.Now()))
 
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
 			Name:   "📊 Summary",
@@ -698,3 +1895,83 @@ func (b *Builder) BuildMemory(processes []monitor.ProcessMemory) *discordgo.Mess
 	logger.Info("Memory embed built successfully with", len(embed.Fields), "fields")
 	return embed
 }
+
+// memoryBreakdown splits used memory into the listed processes and the
+// rest. Resident memory counts shared pages once per process, so the listed
+// share can exceed what is used; other is then shown as none.
+func memoryBreakdown(usage *monitor.MemoryUsage, listedPercent float64, listedBytes uint64) string {
+	otherPercent := max(usage.UsedPercent()-listedPercent, 0)
+	var otherBytes uint64
+	if used := usage.UsedBytes(); used > listedBytes {
+		otherBytes = used - listedBytes
+	}
+	return fmt.Sprintf("**Other processes**: %.1f%% (%s)\n**Total used**: %.1f%% (%s of %s)\n",
+		otherPercent, formatBytes(otherBytes), usage.UsedPercent(), formatBytes(usage.UsedBytes()), formatBytes(usage.TotalBytes))
+}
+
+// topCommandWidth is the width of the command column in /top; together with
+// the other columns a row stays within a mobile code block
+const topCommandWidth = 18
+
+// BuildTop lists the top processes as one aligned table with both %CPU and
+// %MEM columns, ranked by sortColumn
+func (b *Builder) BuildTop(processes []monitor.ProcessMemory, sortColumn string) *discordgo.MessageEmbed {
+	logger.Info("Building top embed for", len(processes), "processes sorted by", sortColumn)
+
+	embed := &discordgo.MessageEmbed{
+		Title:     fmt.Sprintf("📈 Top Processes (%s)", sortColumn),
+		Color:     0x9b59b6,
+		Timestamp: b.Timestamp(),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("System Process Monitor - Sorted by %s", sortColumn),
+		},
+	}
+
+	if len(processes) == 0 {
+		embed.Description = "No processes found"
+		return embed
+	}
+
+	var totalCPU, totalMemory float64
+	for _, process := range processes {
+		totalCPU += process.CPUPercent
+		totalMemory += process.MemoryPercent
+	}
+	embed.Description = fmt.Sprintf("Top %d processes by **%s** using **%.1f%%** CPU and **%.1f%%** memory in total",
+		len(processes), sortColumn, totalCPU, totalMemory)
+
+	// The closing fence and an omission note must still fit in the field
+	const reserve = 40
+	var table strings.Builder
+	table.WriteString("```\n")
+	fmt.Fprintf(&table, "%-7s %-8s %5s %5s %s\n", "PID", "USER", "%CPU", "%MEM", "COMMAND")
+	shown := 0
+	for _, process := range processes {
+		row := fmt.Sprintf("%-7s %-8s %5.1f %5.1f %s\n", process.PID, truncate(process.User, 8),
+			process.CPUPercent, process.MemoryPercent, truncate(process.Command, topCommandWidth))
+		if table.Len()+len(row)+reserve > config.DiscordMaxFieldValueChars {
+			break
+		}
+		table.WriteString(row)
+		shown++
+	}
+	table.WriteString("```")
+	if shown < len(processes) {
+		logger.Info("Top table reached the field limit after", shown, "processes")
+		fmt.Fprintf(&table, "\n%d more not shown", len(processes)-shown)
+	}
+
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "🧮 Processes",
+		Value:  table.String(),
+		Inline: false,
+	})
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "⏰ Last Updated",
+		Value:  b.FormatTime(time.Now()),
+		Inline: true,
+	})
+
+	logger.Info("Top embed built successfully with", shown, "processes")
+	return embed
+}
@@ -0,0 +1,66 @@
+package embed
+
+import (
+	"fmt"
+	"strings"
+	"system-monitor-bot/internal/config"
+	"system-monitor-bot/internal/monitor"
+	"system-monitor-bot/pkg/logger"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// BuildAlertText renders a temperature alert as plain text with the key
+// numbers on their own lines, for channels scraped by tooling
+func (b *Builder) BuildAlertText(level string, sensors []monitor.TemperatureSensor, message string) string {
+	return b.BuildAlertTextWithThresholds(level, sensors, message, b.warningThreshold, b.criticalThreshold)
+}
+
+// BuildAlertTextWithThresholds is BuildAlertText for an alert raised at
+// thresholds other than the configured ones
+func (b *Builder) BuildAlertTextWithThresholds(level string, sensors []monitor.TemperatureSensor, message string, warning, critical float64) string {
+	logger.Info("Building plain text alert - Level:", level, "Sensors:", len(sensors))
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "**%s Temperature Alert**\n%s\n", level, message)
+	fmt.Fprintf(&text, "Thresholds: warning %.1f°C, critical %.1f°C\n", warning, critical)
+
+	listed := 0
+	for _, sensor := range sensors {
+		if sensor.Status < monitor.TempWarning {
+			continue
+		}
+		if listed >= b.limits.MaxAlertSensors {
+			break
+		}
+		fmt.Fprintf(&text, "%s: %.1f°C (%s)\n", sensor.Name, sensor.Temperature, sensor.Status)
+		listed++
+	}
+
+	fmt.Fprintf(&text, "Time: %s", b.plainTime(time.Now()))
+	return truncate(text.String(), config.DiscordMaxMessageChars)
+}
+
+// PlainText renders an embed as text: title, description and one
+// "name: value" line per field
+func (b *Builder) PlainText(embed *discordgo.MessageEmbed) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "**%s**\n", embed.Title)
+	if embed.Description != "" {
+		text.WriteString(embed.Description + "\n")
+	}
+	for _, field := range embed.Fields {
+		value := strings.ReplaceAll(field.Value, "\n", "; ")
+		fmt.Fprintf(&text, "%s: %s\n", field.Name, value)
+	}
+	return truncate(strings.TrimSuffix(text.String(), "\n"), config.DiscordMaxMessageChars)
+}
+
+// plainTime formats t in the display zone without Discord timestamp markup
+func (b *Builder) plainTime(t time.Time) string {
+	if b.location != nil {
+		t = t.In(b.location)
+	}
+	return t.Format("2006-01-02 15:04:05 MST")
+}
@@ -0,0 +1,62 @@
+package httpclient
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"system-monitor-bot/pkg/logger"
+	"time"
+)
+
+// ProxyFunc returns the proxy selector for outbound requests. An explicit
+// proxy URL takes precedence, otherwise HTTPS_PROXY/HTTP_PROXY/NO_PROXY
+// from the environment are honored.
+func ProxyFunc(proxyURL string) (func(*http.Request) (*url.URL, error), error) {
+	if proxyURL == "" {
+		logger.Info("No explicit proxy configured - using proxy settings from environment")
+		return http.ProxyFromEnvironment, nil
+	}
+
+	// The URL may carry credentials, so errors never include it verbatim
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, errors.New("invalid proxy URL: it could not be parsed")
+	}
+	if password, set := parsed.User.Password(); set {
+		logger.RegisterSecret(password)
+	}
+	if parsed.Host == "" {
+		return nil, errors.New("invalid proxy URL: no host, expected a URL such as http://proxy:3128")
+	}
+
+	logger.Info("Using explicit proxy:", parsed.Redacted())
+	return http.ProxyURL(parsed), nil
+}
+
+// New creates an HTTP client with the given proxy and timeouts applied
+func New(proxyURL string, timeout time.Duration) (*http.Client, error) {
+	logger.Info("Creating HTTP client with timeout:", timeout)
+
+	proxy, err := ProxyFunc(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: timeout,
+		IdleConnTimeout:       90 * time.Second,
+		MaxIdleConns:          10,
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}, nil
+}
@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"system-monitor-bot/pkg/logger"
+	"time"
+)
+
+// Counter is a monotonically increasing value exported in Prometheus text format
+type Counter struct {
+	name  string
+	help  string
+	value atomic.Uint64
+}
+
+func (c *Counter) Inc() {
+	c.value.Add(1)
+}
+
+func (c *Counter) Value() uint64 {
+	return c.value.Load()
+}
+
+// Gauge is a value that can go up and down, exported in Prometheus text format
+type Gauge struct {
+	name  string
+	help  string
+	value atomic.Int64
+}
+
+func (g *Gauge) Set(value int64) {
+	g.value.Store(value)
+}
+
+func (g *Gauge) Value() int64 {
+	return g.value.Load()
+}
+
+// LabeledCounter is a family of counters split by the value of one label
+type LabeledCounter struct {
+	name  string
+	help  string
+	label string
+
+	mu     sync.Mutex
+	values map[string]uint64
+}
+
+func (lc *LabeledCounter) Inc(value string) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	if lc.values == nil {
+		lc.values = make(map[string]uint64)
+	}
+	lc.values[value]++
+}
+
+// Snapshot returns a copy of the current count for each label value
+func (lc *LabeledCounter) Snapshot() map[string]uint64 {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	snapshot := make(map[string]uint64, len(lc.values))
+	for value, count := range lc.values {
+		snapshot[value] = count
+	}
+	return snapshot
+}
+
+// CommandInvocations counts slash command invocations by command name
+var CommandInvocations = &LabeledCounter{name: "sysmon_command_invocations_total", help: "Slash command invocations by command.", label: "command"}
+
+// Delivery and Discord API counters
+var (
+	AlertsSent        = &Counter{name: "sysmon_alerts_sent_total", help: "Alert messages delivered to channels."}
+	AlertSendFailures = &Counter{name: "sysmon_alert_send_failures_total", help: "Alert messages that failed to deliver."}
+	ChannelsRemoved   = &Counter{name: "sysmon_alert_channels_removed_total", help: "Alert channels removed after a failed delivery."}
+	DiscordAPIErrors  = &Counter{name: "sysmon_discord_api_errors_total", help: "Discord API calls that returned an error."}
+	SinkSendFailures  = &Counter{name: "sysmon_alert_sink_failures_total", help: "Alerts that failed to reach a non-Discord sink."}
+)
+
+var counters = []*Counter{AlertsSent, AlertSendFailures, ChannelsRemoved, DiscordAPIErrors, SinkSendFailures}
+
+// ListeningPorts is the number of listening sockets seen by the last
+// background port collection
+var ListeningPorts = &Gauge{name: "sysmon_listening_ports", help: "Listening sockets seen by the last port collection."}
+
+var gauges = []*Gauge{ListeningPorts}
+
+// Handler serves all counters and gauges in the Prometheus text exposition format
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		for _, c := range counters {
+			fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n%s %d\n", c.name, c.help, c.name, c.name, c.Value())
+		}
+		for _, g := range gauges {
+			fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %d\n", g.name, g.help, g.name, g.name, g.Value())
+		}
+
+		lc := CommandInvocations
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", lc.name, lc.help, lc.name)
+		snapshot := lc.Snapshot()
+		values := make([]string, 0, len(snapshot))
+		for value := range snapshot {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+		for _, value := range values {
+			fmt.Fprintf(w, "%s{%s=%q} %d\n", lc.name, lc.label, value, snapshot[value])
+		}
+	})
+}
+
+// Serve exposes /metrics, plus any extra routes by path, on addr in the
+// background
+func Serve(addr string, routes map[string]http.Handler) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	for path, handler := range routes {
+		logger.Info("Serving", addr+path)
+		mux.Handle(path, handler)
+	}
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	logger.Info("Serving Prometheus metrics on", addr+"/metrics")
+	go func() {
+		if err := server.ListenAndServe(); err != nil {
+			logger.Error("Metrics server stopped:", err)
+		}
+	}()
+}
@@ -0,0 +1,125 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"system-monitor-bot/pkg/logger"
+)
+
+// ProcessAlias holds the display names for a process binary
+type ProcessAlias struct {
+	Name      string `json:"name"`       // Descriptive name, e.g. "Nginx Web Server"
+	Short     string `json:"short"`      // Compact name for tight layouts, e.g. "Nginx"
+	ExactOnly bool   `json:"exact_only"` // Only match the exact binary name
+}
+
+// processAliases is keyed by lowercase binary name and seeded with the
+// built-in defaults; LoadProcessAliases merges operator entries on top
+var processAliases = map[string]ProcessAlias{
+	"dockerd":        {Name: "Docker Daemon", Short: "Docker"},
+	"docker-proxy":   {Name: "Docker Container Port", Short: "Docker"},
+	"docker":         {Name: "Docker Engine", Short: "Docker"},
+	"containerd":     {Name: "Container Runtime", Short: "Containerd"},
+	"nginx":          {Name: "Nginx Web Server", Short: "Nginx"},
+	"apache":         {Name: "Apache Web Server", Short: "Apache"},
+	"apache2":        {Name: "Apache Web Server", Short: "Apache"},
+	"httpd":          {Name: "Apache Web Server", Short: "Apache"},
+	"node":           {Name: "Node.js Application", Short: "Node.js"},
+	"mysql":          {Name: "MySQL Database", Short: "MySQL"},
+	"mysqld":         {Name: "MySQL Database", Short: "MySQL"},
+	"mariadb":        {Name: "MySQL Database", Short: "MySQL"},
+	"postgres":       {Name: "PostgreSQL Database", Short: "PostgreSQL"},
+	"redis":          {Name: "Redis Cache", Short: "Redis"},
+	"redis-server":   {Name: "Redis Cache", Short: "Redis"},
+	"mongo":          {Name: "MongoDB Database", Short: "MongoDB"},
+	"mongod":         {Name: "MongoDB Database", Short: "MongoDB"},
+	"sshd":           {Name: "SSH Server", Short: "SSH"},
+	"systemd":        {Name: "System Service", Short: "Systemd"},
+	"resolve":        {Name: "DNS Resolver", Short: "Resolved"},
+	"dhcp":           {Name: "DHCP Client", Short: "DHCP"},
+	"python":         {Name: "Python Application", Short: "Python"},
+	"java":           {Name: "Java Application", Short: "Java"},
+	"chrome":         {Name: "Chrome", Short: "Chrome"},
+	"firefox":        {Name: "Firefox", Short: "Firefox"},
+	"code":           {Name: "VS Code", Short: "VS Code", ExactOnly: true},
+	"gnome-shell":    {Name: "GNOME Shell", Short: "GNOME Shell"},
+	"xorg":           {Name: "X Server", Short: "X Server"},
+	"pulseaudio":     {Name: "PulseAudio", Short: "PulseAudio"},
+	"networkmanager": {Name: "Network Manager", Short: "Network Manager"},
+}
+
+// LoadProcessAliases merges aliases from a JSON file of the form
+// {"binary": {"name": "My Service", "short": "MySvc"}} into the defaults
+func LoadProcessAliases(path string) error {
+	logger.Info("Loading process aliases from", path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read process aliases file: %w", err)
+	}
+
+	var custom map[string]ProcessAlias
+	if err := json.Unmarshal(data, &custom); err != nil {
+		return fmt.Errorf("invalid process aliases file %s: %w", path, err)
+	}
+
+	for binary, alias := range custom {
+		if alias.Name == "" {
+			return fmt.Errorf("process alias for %q has no name", binary)
+		}
+		if alias.Short == "" {
+			alias.Short = alias.Name
+		}
+		processAliases[strings.ToLower(binary)] = alias
+		logger.Info("Registered process alias:", binary, "->", alias.Name)
+	}
+
+	logger.Info("Loaded", len(custom), "process aliases from", path)
+	return nil
+}
+
+// LookupProcessAlias finds the alias for a process name. An exact binary
+// match wins; with partial set, the longest alias key contained in the
+// name is used.
+func LookupProcessAlias(process string, partial bool) (ProcessAlias, bool) {
+	lower := strings.ToLower(process)
+	if alias, exists := processAliases[lower]; exists {
+		return alias, true
+	}
+	if !partial {
+		return ProcessAlias{}, false
+	}
+
+	// Longest keys first so "docker-proxy" wins over "docker"
+	keys := make([]string, 0, len(processAliases))
+	for key := range processAliases {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	for _, key := range keys {
+		alias := processAliases[key]
+		if !alias.ExactOnly && strings.Contains(lower, key) {
+			return alias, true
+		}
+	}
+	return ProcessAlias{}, false
+}
+
+// ShortAliasFor returns the compact name for a descriptive alias name
+func ShortAliasFor(name string) (string, bool) {
+	for _, alias := range processAliases {
+		if strings.EqualFold(alias.Name, name) {
+			return alias.Short, true
+		}
+	}
+	return "", false
+}
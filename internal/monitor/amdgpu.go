@@ -0,0 +1,57 @@
+package monitor
+
+import (
+	"strings"
+	"system-monitor-bot/pkg/logger"
+)
+
+// amdgpu hwmon temperature features
+const (
+	amdgpuEdge     = "edge"
+	amdgpuJunction = "junction"
+	amdgpuMemory   = "mem"
+)
+
+var amdgpuSensorNames = map[string]string{
+	amdgpuEdge:     "GPU Edge",
+	amdgpuJunction: "GPU Junction (Hotspot)",
+	amdgpuMemory:   "GPU Memory",
+}
+
+func isAMDGPUChip(chip string) bool {
+	return strings.HasPrefix(strings.ToLower(chip), "amdgpu")
+}
+
+// amdgpuSensorName returns a readable name for an amdgpu temperature feature
+func amdgpuSensorName(feature string) string {
+	if name, exists := amdgpuSensorNames[feature]; exists {
+		return name
+	}
+	if feature == "" {
+		return "GPU"
+	}
+	return "GPU " + feature
+}
+
+// applyAMDGPUAlerting makes the junction temperature, which is what the card
+// throttles on, the only amdgpu reading that drives alerts. Cards without a
+// junction sensor keep alerting on their edge and memory readings.
+func applyAMDGPUAlerting(sensors []TemperatureSensor, features map[string]string) {
+	chipsWithJunction := make(map[string]bool)
+	for key, feature := range features {
+		if feature == amdgpuJunction {
+			chipsWithJunction[chipOfKey(key)] = true
+		}
+	}
+
+	for idx := range sensors {
+		feature, isAMDGPU := features[sensors[idx].ID]
+		if !isAMDGPU || feature == amdgpuJunction {
+			continue
+		}
+		if chipsWithJunction[chipOfKey(sensors[idx].ID)] {
+			sensors[idx].ExcludeFromAlerts = true
+			logger.Info("Excluding amdgpu sensor from alerting in favor of junction:", sensors[idx].ID)
+		}
+	}
+}
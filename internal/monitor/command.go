@@ -0,0 +1,82 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"system-monitor-bot/pkg/logger"
+)
+
+// externalCommands are the programs the monitors run and may run via sudo
+var externalCommands = []string{"sensors", "ipmitool", "ss", "top"}
+
+// sudoCommands holds the external commands to run through `sudo -n`
+var sudoCommands = map[string]bool{}
+
+// SetSudoCommands makes the named external commands run as `sudo -n -- <cmd>`
+// so only they need elevated privileges. Each needs a passwordless sudoers
+// entry for the bot user, for example:
+//
+//	monitorbot ALL=(root) NOPASSWD: /usr/bin/ipmitool sdr type temperature, /usr/bin/ss -tulnp, /usr/bin/ss -tan
+func SetSudoCommands(names []string) error {
+	enabled := make(map[string]bool, len(names))
+	for _, name := range names {
+		known := false
+		for _, command := range externalCommands {
+			if name == command {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown command %q in SUDO_COMMANDS, expected one of: %s", name, strings.Join(externalCommands, ", "))
+		}
+		enabled[name] = true
+	}
+	// A remote host's sudo is only found when the commands run
+	if len(enabled) > 0 && remote == nil {
+		if _, err := exec.LookPath("sudo"); err != nil {
+			return fmt.Errorf("SUDO_COMMANDS is set but sudo is not installed: %w", err)
+		}
+	}
+
+	sudoCommands = enabled
+	for name := range enabled {
+		logger.Info("External command", name, "will run via sudo -n")
+	}
+	return nil
+}
+
+// runner is an external command ready to run, locally or on the remote host
+type runner interface {
+	Output() ([]byte, error)
+}
+
+// command builds an external command, prefixing sudo when configured. Local
+// arguments are passed directly, never through a shell; remote ones are
+// quoted for the remote shell.
+func command(name string, args ...string) runner {
+	argv := append([]string{name}, args...)
+	if sudoCommands[name] {
+		argv = append([]string{"sudo", "-n", "--"}, argv...)
+	}
+	if remote != nil {
+		return &remoteCommand{runner: remote, argv: argv}
+	}
+	return exec.Command(argv[0], argv[1:]...)
+}
+
+// exitStatus returns the exit code and stderr of a command that ran but
+// exited non-zero, locally or remotely
+func exitStatus(err error) (code int, stderr []byte, ok bool) {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), exitErr.Stderr, true
+	}
+	var remoteErr *remoteExitError
+	if errors.As(err, &remoteErr) {
+		return remoteErr.ExitCode(), remoteErr.Stderr, true
+	}
+	return 0, nil, false
+}
@@ -0,0 +1,154 @@
+package monitor
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"system-monitor-bot/pkg/logger"
+	"time"
+)
+
+type CPUMonitor struct {
+	statPath     string
+	topologyPath string
+
+	// sampleWindow is the delay between the two /proc/stat reads; a single
+	// read only gives cumulative jiffies, not current load
+	sampleWindow time.Duration
+}
+
+func NewCPUMonitor(sampleWindow time.Duration) *CPUMonitor {
+	logger.Info("Creating new CPUMonitor instance with sample window:", sampleWindow)
+	return &CPUMonitor{
+		statPath:     "/proc/stat",
+		topologyPath: "/sys/devices/system/cpu",
+		sampleWindow: sampleWindow,
+	}
+}
+
+// cpuTimes are the busy and total jiffies of one /proc/stat cpu line
+type cpuTimes struct {
+	busy  uint64
+	total uint64
+}
+
+// GetUsage samples /proc/stat twice and returns overall and per-core load
+func (cm *CPUMonitor) GetUsage() (*CPUUsage, error) {
+	logger.Info("Starting CPU usage sampling over", cm.sampleWindow)
+
+	first, err := cm.readStat()
+	if err != nil {
+		return nil, err
+	}
+	time.Sleep(cm.sampleWindow)
+	second, err := cm.readStat()
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &CPUUsage{Overall: loadPercent(first["cpu"], second["cpu"])}
+	for index := 0; ; index++ {
+		name := fmt.Sprintf("cpu%d", index)
+		after, exists := second[name]
+		if !exists {
+			break
+		}
+		core := CoreUsage{
+			CPU:       index,
+			Percent:   loadPercent(first[name], after),
+			CoreID:    cm.readTopology(index, "core_id"),
+			PackageID: cm.readTopology(index, "physical_package_id"),
+		}
+		usage.Cores = append(usage.Cores, core)
+	}
+
+	logger.Info("CPU usage:", fmt.Sprintf("%.1f%%", usage.Overall), "across", len(usage.Cores), "CPUs")
+	return usage, nil
+}
+
+// readStat returns the cpu lines of /proc/stat keyed by name ("cpu", "cpu0", ...)
+func (cm *CPUMonitor) readStat() (map[string]cpuTimes, error) {
+	file, err := os.Open(cm.statPath)
+	if err != nil {
+		logger.Error("Failed to open", cm.statPath, "error:", err)
+		return nil, fmt.Errorf("failed to open %s: %v", cm.statPath, err)
+	}
+	defer file.Close()
+
+	times := make(map[string]cpuTimes)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// Format: cpuN user nice system idle iowait irq softirq steal guest guest_nice
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || !strings.HasPrefix(fields[0], "cpu") {
+			continue
+		}
+
+		var values []uint64
+		for _, field := range fields[1:min(len(fields), 9)] {
+			value, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s value %q: %v", fields[0], field, err)
+			}
+			values = append(values, value)
+		}
+
+		// guest time is already included in user, so it is not summed
+		var total uint64
+		for _, value := range values {
+			total += value
+		}
+		idle := values[3]
+		if len(values) > 4 {
+			idle += values[4] // iowait
+		}
+		times[fields[0]] = cpuTimes{busy: total - idle, total: total}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", cm.statPath, err)
+	}
+	if _, exists := times["cpu"]; !exists {
+		return nil, fmt.Errorf("no aggregate cpu line in %s", cm.statPath)
+	}
+	return times, nil
+}
+
+// readTopology reads a cpuN topology attribute, returning -1 when unavailable
+func (cm *CPUMonitor) readTopology(cpu int, attribute string) int {
+	data, err := os.ReadFile(filepath.Join(cm.topologyPath, fmt.Sprintf("cpu%d", cpu), "topology", attribute))
+	if err != nil {
+		return -1
+	}
+	value, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return -1
+	}
+	return value
+}
+
+func loadPercent(before, after cpuTimes) float64 {
+	if after.total <= before.total {
+		return 0
+	}
+	return float64(after.busy-before.busy) / float64(after.total-before.total) * 100
+}
+
+// CoreTemperature finds the coretemp "Core N" sensor for a CPU by matching
+// its physical package and core ID; hyperthread siblings share a sensor
+func CoreTemperature(core CoreUsage, sensors []TemperatureSensor) (TemperatureSensor, bool) {
+	if core.CoreID < 0 {
+		return TemperatureSensor{}, false
+	}
+	feature := fmt.Sprintf("Core %d", core.CoreID)
+	chip := fmt.Sprintf("coretemp-isa-%04d", max(core.PackageID, 0))
+
+	for _, sensor := range sensors {
+		if sensor.Feature == feature && strings.HasPrefix(sensor.ID, chip+"_") {
+			return sensor, true
+		}
+	}
+	return TemperatureSensor{}, false
+}
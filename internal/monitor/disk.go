@@ -0,0 +1,106 @@
+package monitor
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"system-monitor-bot/pkg/logger"
+)
+
+// pseudoFilesystems are mount types that do not hold user data
+var pseudoFilesystems = map[string]bool{
+	"proc": true, "sysfs": true, "devtmpfs": true, "devpts": true, "tmpfs": true,
+	"cgroup": true, "cgroup2": true, "securityfs": true, "pstore": true, "bpf": true,
+	"debugfs": true, "tracefs": true, "configfs": true, "fusectl": true, "mqueue": true,
+	"hugetlbfs": true, "autofs": true, "binfmt_misc": true, "efivarfs": true,
+	"overlay": true, "squashfs": true, "nsfs": true, "ramfs": true, "rpc_pipefs": true,
+}
+
+type DiskMonitor struct {
+	mountsPath string
+}
+
+func NewDiskMonitor() *DiskMonitor {
+	logger.Info("Creating new DiskMonitor instance")
+	return &DiskMonitor{mountsPath: "/proc/mounts"}
+}
+
+// GetDiskUsage returns the usage of every mounted block-backed filesystem,
+// reporting each device once under its first mountpoint
+func (dm *DiskMonitor) GetDiskUsage() ([]DiskUsage, error) {
+	return dm.GetFilesystems(false)
+}
+
+// GetFilesystems returns filesystem usage like GetDiskUsage; all adds
+// pseudo-filesystems such as tmpfs, each listed per mountpoint since they
+// share a device name
+func (dm *DiskMonitor) GetFilesystems(all bool) ([]DiskUsage, error) {
+	logger.Info("Starting disk usage reading from", dm.mountsPath, "including pseudo-filesystems:", all)
+
+	file, err := os.Open(dm.mountsPath)
+	if err != nil {
+		logger.Error("Failed to open", dm.mountsPath, "error:", err)
+		return nil, fmt.Errorf("failed to open %s: %v", dm.mountsPath, err)
+	}
+	defer file.Close()
+
+	seenDevices := make(map[string]bool)
+	var disks []DiskUsage
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// Format: <device> <mountpoint> <fstype> <options> <dump> <pass>
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		device, mountpoint, fsType := fields[0], unescapeMountField(fields[1]), fields[2]
+		pseudo := pseudoFilesystems[fsType]
+		if (pseudo && !all) || (!pseudo && seenDevices[device]) {
+			continue
+		}
+
+		var stat syscall.Statfs_t
+		if err := syscall.Statfs(mountpoint, &stat); err != nil {
+			logger.Info("Could not stat filesystem", mountpoint, "-", err)
+			continue
+		}
+		if stat.Blocks == 0 {
+			continue
+		}
+		if !pseudo {
+			seenDevices[device] = true
+		}
+
+		blockSize := uint64(stat.Bsize)
+		disk := DiskUsage{
+			Mountpoint: mountpoint,
+			Device:     device,
+			FSType:     fsType,
+			Total:      stat.Blocks * blockSize,
+			Used:       (stat.Blocks - stat.Bfree) * blockSize,
+			Available:  stat.Bavail * blockSize,
+		}
+		disks = append(disks, disk)
+		logger.Info("Filesystem", mountpoint, "("+device+")", fmt.Sprintf("%.1f%% used", disk.UsagePercent()))
+	}
+	if err := scanner.Err(); err != nil {
+		logger.Error("Failed to read", dm.mountsPath, "error:", err)
+		return nil, fmt.Errorf("failed to read %s: %v", dm.mountsPath, err)
+	}
+
+	logger.Info("Disk usage reading complete. Filesystems:", len(disks))
+	return disks, nil
+}
+
+// unescapeMountField decodes the octal escapes /proc/mounts uses for spaces
+// and other special characters in paths
+func unescapeMountField(field string) string {
+	if !strings.Contains(field, `\`) {
+		return field
+	}
+	replacer := strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`)
+	return replacer.Replace(field)
+}
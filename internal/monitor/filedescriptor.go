@@ -0,0 +1,90 @@
+package monitor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"system-monitor-bot/pkg/logger"
+)
+
+const fileNrPath = "/proc/sys/fs/file-nr"
+
+type FileDescriptorMonitor struct {
+	procPath    string
+	scanWorkers int
+}
+
+// NewFileDescriptorMonitor creates a file descriptor monitor; scanWorkers
+// bounds the concurrent /proc reads
+func NewFileDescriptorMonitor(scanWorkers int) *FileDescriptorMonitor {
+	logger.Info("Creating new FileDescriptorMonitor instance with scan workers:", scanWorkers)
+	return &FileDescriptorMonitor{procPath: "/proc", scanWorkers: scanWorkers}
+}
+
+// GetFileDescriptorStats reads system-wide file descriptor usage and the
+// open descriptor count of each given PID
+func (fm *FileDescriptorMonitor) GetFileDescriptorStats(pids []string) (*FileDescriptorStats, error) {
+	logger.Info("Starting file descriptor reading for", len(pids), "processes...")
+
+	data, err := os.ReadFile(fileNrPath)
+	if err != nil {
+		logger.Error("Failed to read", fileNrPath, "error:", err)
+		return nil, fmt.Errorf("failed to read %s: %v", fileNrPath, err)
+	}
+
+	// Format: <allocated> <allocated but unused> <maximum>
+	fields := strings.Fields(string(data))
+	if len(fields) < 3 {
+		logger.Error("Unexpected", fileNrPath, "format:", string(data))
+		return nil, fmt.Errorf("unexpected %s format", fileNrPath)
+	}
+
+	allocated, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid allocated fd count %q: %v", fields[0], err)
+	}
+	maximum, err := strconv.ParseUint(fields[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid maximum fd count %q: %v", fields[2], err)
+	}
+
+	stats := &FileDescriptorStats{
+		Allocated: allocated,
+		Maximum:   maximum,
+	}
+	logger.Info("System file descriptors:", allocated, "/", maximum, fmt.Sprintf("(%.2f%%)", stats.UsagePercent()))
+
+	counts := make([]int, len(pids))
+	forEachPID(pids, fm.scanWorkers, func(idx int, pid string) {
+		count, err := fm.countProcessDescriptors(pid)
+		switch {
+		case err == nil:
+			counts[idx] = count
+			return
+		case processGone(err):
+			// Exited since it was listed
+		default:
+			// Usually a permission error for processes owned by other users
+			logger.Info("Could not count file descriptors for PID", pid, "-", err)
+		}
+		counts[idx] = -1
+	})
+	for idx, pid := range pids {
+		if counts[idx] >= 0 {
+			stats.Processes = append(stats.Processes, ProcessFileDescriptors{PID: pid, Count: counts[idx]})
+		}
+	}
+
+	logger.Info("File descriptor reading complete. Processes counted:", len(stats.Processes))
+	return stats, nil
+}
+
+func (fm *FileDescriptorMonitor) countProcessDescriptors(pid string) (int, error) {
+	entries, err := os.ReadDir(filepath.Join(fm.procPath, pid, "fd"))
+	if err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
@@ -0,0 +1,120 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+	"system-monitor-bot/pkg/logger"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/host"
+)
+
+// Temperature backends
+const (
+	// TempBackendAuto uses lm-sensors when it is installed or the host is
+	// remote, and gopsutil otherwise
+	TempBackendAuto = "auto"
+
+	// TempBackendSensors parses the output of the sensors command
+	TempBackendSensors = "sensors"
+
+	// TempBackendGopsutil reads the kernel's sensors through gopsutil,
+	// without lm-sensors; the sensors command remains the fallback when
+	// gopsutil finds nothing
+	TempBackendGopsutil = "gopsutil"
+)
+
+// resolveTempBackend picks the backend for TempBackendAuto. gopsutil only
+// reads the local machine, so a remote host always uses sensors.
+func resolveTempBackend(backend string) string {
+	if backend != TempBackendAuto {
+		return backend
+	}
+	if IsRemote() || lookPath("sensors") == nil {
+		return TempBackendSensors
+	}
+	return TempBackendGopsutil
+}
+
+// getGopsutilSensors reads temperatures through gopsutil, falling back to
+// the sensors command when it returns nothing
+func (tm *TemperatureMonitor) getGopsutilSensors(raw *RawOutput) ([]TemperatureSensor, error) {
+	logger.Info("Reading temperature sensors through gopsutil...")
+	startTime := time.Now()
+	stats, err := host.SensorsTemperatures()
+	duration := time.Since(startTime)
+
+	if len(stats) == 0 {
+		if err != nil {
+			logger.Warn("gopsutil sensor reading failed after", duration, "error:", err)
+		}
+		logger.Warn("gopsutil found no temperature sensors - falling back to lm-sensors")
+		return tm.getLMSensors(raw)
+	}
+	if err != nil {
+		// Unreadable sensors are reported alongside the readable ones
+		logger.Warn("gopsutil could not read every sensor - using the", len(stats), "it read:", err)
+	}
+	logger.Info("gopsutil read", len(stats), "sensors in", duration)
+
+	var output strings.Builder
+	for _, stat := range stats {
+		fmt.Fprintf(&output, "%s: %.3f (high %.3f, crit %.3f)\n", stat.SensorKey, stat.Temperature, stat.High, stat.Critical)
+	}
+	raw.record("gopsutil", []string{"host.SensorsTemperatures"}, []byte(output.String()))
+
+	sensors := tm.convertGopsutilSensors(stats)
+	tm.sortSensors(sensors)
+	logger.Info("Successfully converted", len(sensors), "gopsutil temperature sensors")
+	return sensors, nil
+}
+
+// convertGopsutilSensors maps gopsutil readings, keyed "<chip>_<label>",
+// to sensors. Chips that report the same key twice, such as two NVMe
+// drives, get a numeric suffix.
+func (tm *TemperatureMonitor) convertGopsutilSensors(stats []host.TemperatureStat) []TemperatureSensor {
+	sensors := make([]TemperatureSensor, 0, len(stats))
+	amdgpuFeatures := make(map[string]string)
+	seen := make(map[string]int)
+
+	for _, stat := range stats {
+		id := stat.SensorKey
+		seen[id]++
+		if seen[id] > 1 {
+			id = fmt.Sprintf("%s_%d", id, seen[id])
+		}
+
+		label := strings.ReplaceAll(stat.SensorKey, "_", " ")
+		name := tm.getReadableSensorName(label)
+		category := tm.categorizeSensor(stat.SensorKey, label)
+		if isAMDGPUChip(stat.SensorKey) {
+			feature := strings.TrimPrefix(strings.TrimPrefix(stat.SensorKey, "amdgpu"), "_")
+			amdgpuFeatures[id] = feature
+			name = amdgpuSensorName(feature)
+			category = CategoryGPU
+		}
+
+		sensor := TemperatureSensor{
+			ID:          id,
+			Name:        name,
+			Temperature: stat.Temperature,
+			Category:    category,
+			Status:      tm.getTemperatureStatus(id, stat.Temperature),
+			High:        plausibleLimit(stat.High),
+			Crit:        plausibleLimit(stat.Critical),
+		}
+		sensors = append(sensors, sensor)
+		logger.Info("Created sensor:", sensor.Name, "Category:", sensor.Category, "Temp:", sensor.Temperature, "Status:", sensor.Status)
+	}
+
+	applyAMDGPUAlerting(sensors, amdgpuFeatures)
+	return sensors
+}
+
+// plausibleLimit drops unset and placeholder chip limits
+func plausibleLimit(limit float64) float64 {
+	if limit <= 0 || limit >= maxPlausibleLimit {
+		return 0
+	}
+	return limit
+}
@@ -0,0 +1,47 @@
+package monitor
+
+import (
+	"sync"
+	"system-monitor-bot/pkg/logger"
+)
+
+// statusHistory remembers each sensor's last status so a sensor only
+// recovers once it drops a margin below the threshold it crossed
+type statusHistory struct {
+	mu     sync.Mutex
+	margin float64
+	last   map[string]TempStatus // sensor ID -> status from the previous reading
+}
+
+func newStatusHistory(margin float64) *statusHistory {
+	return &statusHistory{margin: margin, last: make(map[string]TempStatus)}
+}
+
+// apply holds sensors at the highest level at or below their previous
+// status whose threshold they remain within the margin of. Rising still
+// takes effect as soon as a threshold is reached.
+func (sh *statusHistory) apply(sensors []TemperatureSensor) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	seen := make(map[string]TempStatus, len(sensors))
+	for idx := range sensors {
+		sensor := &sensors[idx]
+		previous, known := sh.last[sensor.ID]
+		if known && sh.margin > 0 && sensor.Status < previous {
+			status := sensor.Status
+			for held := previous; held > sensor.Status; held-- {
+				if sensor.Temperature >= SensorThreshold(sensor.ID, held)-sh.margin {
+					status = held
+					break
+				}
+			}
+			if status != sensor.Status {
+				logger.Info("Holding sensor", sensor.ID, "at", status, "within hysteresis margin - reading:", sensor.Temperature, "°C")
+				sensor.Status = status
+			}
+		}
+		seen[sensor.ID] = sensor.Status
+	}
+	sh.last = seen
+}
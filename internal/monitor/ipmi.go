@@ -0,0 +1,119 @@
+package monitor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"system-monitor-bot/pkg/logger"
+	"time"
+)
+
+// IPMI device nodes used by the different kernel drivers
+var ipmiDevices = []string{"/dev/ipmi0", "/dev/ipmi/0", "/dev/ipmidev/0"}
+
+// detectIPMI reports whether ipmitool is installed and a BMC device is
+// accessible with the current permissions
+func detectIPMI() bool {
+	logger.Info("Checking for IPMI availability...")
+	if remote != nil {
+		// The BMC device check below can only see the local host
+		logger.Info("Remote host configured - IPMI sensors disabled")
+		return false
+	}
+	if _, err := exec.LookPath("ipmitool"); err != nil {
+		logger.Info("ipmitool not found - IPMI sensors disabled")
+		return false
+	}
+
+	for _, device := range ipmiDevices {
+		file, err := os.OpenFile(device, os.O_RDWR, 0)
+		if err == nil {
+			file.Close()
+			logger.Info("IPMI device accessible:", device, "- IPMI sensors enabled")
+			return true
+		}
+		if os.IsPermission(err) {
+			logger.Warn("IPMI device", device, "exists but is not accessible - IPMI sensors disabled:", err)
+			return false
+		}
+	}
+
+	logger.Info("No IPMI device found - IPMI sensors disabled")
+	return false
+}
+
+func (tm *TemperatureMonitor) getIPMISensors(raw *RawOutput) ([]TemperatureSensor, error) {
+	logger.Info("Executing ipmitool command with args: sdr type temperature")
+	startTime := time.Now()
+	cmd := command("ipmitool", "sdr", "type", "temperature")
+	output, err := cmd.Output()
+	duration := time.Since(startTime)
+
+	if err != nil {
+		logger.Error("ipmitool command failed after", duration, "error:", err)
+		return nil, fmt.Errorf("ipmitool command failed: %v", err)
+	}
+
+	logger.Info("ipmitool command completed successfully in", duration)
+	raw.record("ipmitool", []string{"sdr", "type", "temperature"}, output)
+	return tm.parseIPMIOutput(string(output)), nil
+}
+
+// parseIPMIOutput parses lines of the form:
+// Inlet Temp       | 04h | ok  |  7.1 | 23 degrees C
+func (tm *TemperatureMonitor) parseIPMIOutput(output string) []TemperatureSensor {
+	logger.Info("Starting IPMI output parsing...")
+	var sensors []TemperatureSensor
+	readingRegex := regexp.MustCompile(`([+-]?[\d.]+)\s+degrees C`)
+
+	for lineNum, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "|")
+		if len(fields) < 5 {
+			continue
+		}
+
+		name := strings.TrimSpace(fields[0])
+		matches := readingRegex.FindStringSubmatch(fields[4])
+		if matches == nil {
+			logger.Info("Skipping IPMI sensor without reading at line", lineNum+1, ":", name)
+			continue
+		}
+
+		temp, err := strconv.ParseFloat(matches[1], 64)
+		if err != nil {
+			logger.Info("Could not parse IPMI temperature:", matches[1], "for sensor:", name)
+			continue
+		}
+
+		id := "ipmi_" + strings.ToLower(strings.ReplaceAll(name, " ", "_"))
+		sensor := TemperatureSensor{
+			ID:          id,
+			Name:        name,
+			Temperature: temp,
+			Category:    tm.categorizeIPMISensor(name),
+			Status:      tm.getTemperatureStatus(id, temp),
+		}
+		sensors = append(sensors, sensor)
+		logger.Info("Found IPMI sensor:", sensor.Name, "Category:", sensor.Category, "Temp:", temp, "°C")
+	}
+
+	logger.Info("IPMI parsing complete. Found", len(sensors), "sensors")
+	return sensors
+}
+
+func (tm *TemperatureMonitor) categorizeIPMISensor(name string) string {
+	lower := strings.ToLower(name)
+
+	switch {
+	case strings.Contains(lower, "inlet") || strings.Contains(lower, "exhaust") ||
+		strings.Contains(lower, "ambient") || strings.Contains(lower, "outlet"):
+		return CategorySystem
+	case strings.Contains(lower, "dimm") || strings.Contains(lower, "mem"):
+		return CategoryMemory
+	default:
+		return tm.categorizeSensor("", name)
+	}
+}
@@ -1,9 +1,10 @@
 package monitor
 
 import (
+	"bufio"
 	"fmt"
-	"os/exec"
-	"regexp"
+	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
@@ -11,26 +12,60 @@ import (
 	"time"
 )
 
-type MemoryMonitor struct{}
+type MemoryMonitor struct {
+	excludePID  string
+	procPath    string
+	scanWorkers int
+}
+
+// NewMemoryMonitor creates a memory monitor; with excludeSelf the bot's own
+// process is left out of the results. scanWorkers bounds the concurrent
+// /proc reads.
+func NewMemoryMonitor(excludeSelf bool, scanWorkers int) *MemoryMonitor {
+	logger.Info("Creating new MemoryMonitor instance with exclude self:", excludeSelf, "scan workers:", scanWorkers)
+	return &MemoryMonitor{
+		excludePID:  selfPID(excludeSelf),
+		procPath:    "/proc",
+		scanWorkers: scanWorkers,
+	}
+}
+
+// Columns top processes can be ranked by
+const (
+	SortByMemory = "%MEM"
+	SortByCPU    = "%CPU"
+)
 
-func NewMemoryMonitor() *MemoryMonitor {
-	logger.Info("Creating new MemoryMonitor instance")
-	return &MemoryMonitor{}
+// GetTopProcesses reads the limit processes using the most memory
+func (mm *MemoryMonitor) GetTopProcesses(limit int) ([]ProcessMemory, error) {
+	return mm.GetTopProcessesRaw(limit, nil)
 }
 
-func (mm *MemoryMonitor) GetTopProcesses() ([]ProcessMemory, error) {
-	logger.Info("Starting memory usage reading...")
+// GetTopProcessesRaw is GetTopProcesses that also records the top output the
+// processes were parsed from into raw
+func (mm *MemoryMonitor) GetTopProcessesRaw(limit int, raw *RawOutput) ([]ProcessMemory, error) {
+	return mm.GetTopProcessesBy(SortByMemory, limit, raw)
+}
+
+// GetTopProcessesBy reads the limit top processes ranked by sortColumn,
+// SortByMemory or SortByCPU, recording the top output into raw when it is
+// not nil
+func (mm *MemoryMonitor) GetTopProcessesBy(sortColumn string, limit int, raw *RawOutput) ([]ProcessMemory, error) {
+	if sortColumn != SortByMemory && sortColumn != SortByCPU {
+		return nil, fmt.Errorf("unknown process sort column %q", sortColumn)
+	}
+	logger.Info("Starting process usage reading sorted by", sortColumn+"...")
 
 	logger.Info("Checking for top command availability...")
-	if _, err := exec.LookPath("top"); err != nil {
+	if err := lookPath("top"); err != nil {
 		logger.Error("top command not found:", err)
 		return nil, fmt.Errorf("top command not found")
 	}
 	logger.Info("top command found and available")
 
-	logger.Info("Executing top command with flags: -b -n1 -o %MEM")
+	logger.Info("Executing top command with flags: -b -n1 -o", sortColumn)
 	startTime := time.Now()
-	cmd := exec.Command("top", "-b", "-n1", "-o", "%MEM")
+	cmd := command("top", "-b", "-n1", "-o", sortColumn)
 	output, err := cmd.Output()
 	duration := time.Since(startTime)
 
@@ -41,123 +76,311 @@ func (mm *MemoryMonitor) GetTopProcesses() ([]ProcessMemory, error) {
 
 	logger.Info("top command completed successfully in", duration)
 	logger.Info("top output length:", len(output), "bytes")
+	raw.record("top", []string{"-b", "-n1", "-o", sortColumn}, output)
 
-	processes, parseErr := mm.parseTopOutput(string(output))
+	processes, parseErr := mm.parseTopOutput(string(output), sortColumn, limit)
 	if parseErr != nil {
 		logger.Error("Failed to parse top output:", parseErr)
 		return nil, parseErr
 	}
 
+	// A remote host's PIDs mean nothing in the local /proc
+	if remote == nil {
+		mm.fillResidentBytes(processes)
+	}
+
 	logger.Info("Successfully parsed", len(processes), "memory processes")
 	return processes, nil
 }
 
-func (mm *MemoryMonitor) parseTopOutput(output string) ([]ProcessMemory, error) {
-	logger.Info("Starting top output parsing focused on %MEM column...")
+// ResidentPercent recomputes each process's %MEM from its resident bytes and
+// MemTotal, so the figures share one basis with the system totals instead of
+// carrying top's one-decimal rounding. Processes without resident bytes keep
+// top's value.
+func ResidentPercent(processes []ProcessMemory, totalBytes uint64) {
+	if totalBytes == 0 {
+		return
+	}
+	for idx := range processes {
+		if processes[idx].ResidentBytes > 0 {
+			processes[idx].MemoryPercent = float64(processes[idx].ResidentBytes) / float64(totalBytes) * 100
+		}
+	}
+}
+
+// fillResidentBytes replaces top's rounded RES value with the exact VmRSS
+// from /proc/<pid>/status; processes that exited since top ran, or that
+// are not readable, keep the top value
+func (mm *MemoryMonitor) fillResidentBytes(processes []ProcessMemory) {
+	pids := make([]string, len(processes))
+	for idx, process := range processes {
+		pids[idx] = process.PID
+	}
+
+	forEachPID(pids, mm.scanWorkers, func(idx int, pid string) {
+		rss, err := mm.readVmRSS(pid)
+		if err != nil {
+			if !processGone(err) {
+				logger.Info("Could not read VmRSS for PID:", pid, "error:", err)
+			}
+			return
+		}
+		processes[idx].ResidentBytes = rss
+	})
+}
+
+// readVmRSS reads the resident set size of a process, reported in kB by
+// the "VmRSS:" line of /proc/<pid>/status
+func (mm *MemoryMonitor) readVmRSS(pid string) (uint64, error) {
+	file, err := os.Open(filepath.Join(mm.procPath, pid, "status"))
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		value, found := strings.CutPrefix(scanner.Text(), "VmRSS:")
+		if !found {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) != 2 || fields[1] != "kB" {
+			return 0, fmt.Errorf("unexpected VmRSS line %q", scanner.Text())
+		}
+		kb, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid VmRSS value %q: %v", fields[0], err)
+		}
+		return kb * 1024, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	// Kernel threads have no VmRSS line
+	return 0, fmt.Errorf("no VmRSS in %s status", pid)
+}
+
+func (mm *MemoryMonitor) parseTopOutput(output string, sortColumn string, limit int) ([]ProcessMemory, error) {
+	logger.Info("Starting top output parsing focused on", sortColumn, "column...")
 	var processes []ProcessMemory
 	lines := strings.Split(output, "\n")
 	logger.Info("Processing", len(lines), "lines from top output")
 
 	// Find the header line to understand column positions
-	headerFound := false
+	var columns map[string]topColumn
 	dataStartIndex := 0
 
 	for i, line := range lines {
 		if strings.Contains(line, "PID") && strings.Contains(line, "%MEM") && strings.Contains(line, "COMMAND") {
-			headerFound = true
+			columns = parseTopHeader(line)
 			dataStartIndex = i + 1
 			logger.Info("Found header line at index", i, ":", strings.TrimSpace(line))
 			break
 		}
 	}
 
-	if !headerFound {
+	if columns == nil {
 		logger.Error("Could not find header line in top output")
 		return nil, fmt.Errorf("invalid top output format - no header found")
 	}
+	for _, name := range []string{"PID", "USER", "%CPU", "%MEM", "COMMAND"} {
+		if _, ok := columns[name]; !ok {
+			logger.Error("top header is missing column:", name)
+			return nil, fmt.Errorf("invalid top output format - no %s column", name)
+		}
+	}
 
 	processedLines := 0
 	foundProcesses := 0
 
-	// More robust regex that matches the exact top output format
-	// Matches: PID USER PR NI VIRT RES SHR S %CPU %MEM TIME+ COMMAND
-	processRegex := regexp.MustCompile(`^\s*(\d+)\s+(\S+)\s+\S+\s+\S+\s+\S+\s+\S+\s+\S+\s+\S+\s+([\d.]+)\s+([\d.]+)\s+\S+\s+(.+)$`)
+	// Collect a few extra candidates to ensure we have enough good ones
+	candidateLimit := limit + limit/2
 
-	for i := dataStartIndex; i < len(lines) && foundProcesses < 15; i++ { // Get 15 to ensure we have 10 good ones
-		line := strings.TrimSpace(lines[i])
-		if line == "" {
+	for i := dataStartIndex; i < len(lines) && foundProcesses < candidateLimit; i++ {
+		// Keep the leading padding: fields are located by header position
+		line := strings.TrimRight(lines[i], " \r")
+		if strings.TrimSpace(line) == "" {
 			continue
 		}
 		processedLines++
 
-		matches := processRegex.FindStringSubmatch(line)
-		if len(matches) >= 6 {
-			pid := matches[1]
-			user := matches[2]
-			cpuPercent := matches[3]
-			memPercent := matches[4] // This is the %MEM column we want to sort by
-			command := strings.TrimSpace(matches[5])
-
-			// Parse memory percentage (this is our primary sort key)
-			memPct, err := strconv.ParseFloat(memPercent, 64)
-			if err != nil {
-				logger.Info("Could not parse memory percentage:", memPercent, "for PID:", pid)
-				continue
-			}
+		pid := topField(line, columns["PID"])
+		memPercent := topField(line, columns["%MEM"]) // This is the %MEM column we want to sort by
+		command := topCommand(line, columns["COMMAND"])
+		if _, err := strconv.Atoi(pid); err != nil || memPercent == "" || command == "" {
+			logger.Info("Skipping line", i+1, "- fields not found under header columns:", line)
+			continue
+		}
+		if pid == mm.excludePID {
+			logger.Info("Skipping the bot's own process:", pid)
+			continue
+		}
+		user := topField(line, columns["USER"])
+		cpuPercent := topField(line, columns["%CPU"])
 
-			// Parse CPU percentage
-			cpuPct, err := strconv.ParseFloat(cpuPercent, 64)
-			if err != nil {
-				logger.Info("Could not parse CPU percentage:", cpuPercent, "for PID:", pid)
-				cpuPct = 0.0
-			}
+		// Parse memory percentage (this is our primary sort key)
+		memPct, err := strconv.ParseFloat(memPercent, 64)
+		if err != nil {
+			logger.Info("Could not parse memory percentage:", memPercent, "for PID:", pid)
+			continue
+		}
 
-			// Skip processes with 0% memory to focus on actual memory users
-			if memPct == 0.0 {
-				continue
-			}
+		// Parse CPU percentage
+		cpuPct, err := strconv.ParseFloat(cpuPercent, 64)
+		if err != nil {
+			logger.Info("Could not parse CPU percentage:", cpuPercent, "for PID:", pid)
+			cpuPct = 0.0
+		}
 
-			process := ProcessMemory{
-				PID:           pid,
-				User:          user,
-				Command:       mm.cleanCommandName(command),
-				MemoryPercent: memPct,
-				CPUPercent:    cpuPct,
+		// Skip processes with 0% memory to focus on actual memory users
+		if sortColumn == SortByMemory && memPct == 0.0 {
+			continue
+		}
+
+		var resident uint64
+		if column, ok := columns["RES"]; ok {
+			value := topField(line, column)
+			if resident, err = parseTopMemory(value); err != nil {
+				logger.Info("Could not parse resident memory:", value, "for PID:", pid)
 			}
+		}
 
-			processes = append(processes, process)
-			foundProcesses++
-			logger.Info("Found process:", pid, command, "Memory:", memPct, "% CPU:", cpuPct, "%")
-		} else {
-			logger.Info("Skipping line", i+1, "- regex didn't match:", line)
+		process := ProcessMemory{
+			PID:           pid,
+			User:          user,
+			Command:       mm.cleanCommandName(command),
+			MemoryPercent: memPct,
+			CPUPercent:    cpuPct,
+			ResidentBytes: resident,
 		}
+
+		processes = append(processes, process)
+		foundProcesses++
+		logger.Info("Found process:", pid, command, "Memory:", memPct, "% CPU:", cpuPct, "%")
 	}
 
 	logger.Info("Top parsing statistics:")
 	logger.Info("- Processed lines:", processedLines)
 	logger.Info("- Found processes:", foundProcesses)
 
-	// Sort by memory percentage (descending) - this ensures we get the TOP memory users
+	// Sort descending by the requested column - this ensures we get the TOP users
 	sort.Slice(processes, func(i, j int) bool {
+		if sortColumn == SortByCPU {
+			return processes[i].CPUPercent > processes[j].CPUPercent
+		}
 		return processes[i].MemoryPercent > processes[j].MemoryPercent
 	})
 
-	// Take top 10 by memory percentage
-	if len(processes) > 10 {
-		processes = processes[:10]
-		logger.Info("Trimmed to top 10 processes by %MEM column")
+	// Take top N by the sort column
+	if len(processes) > limit {
+		processes = processes[:limit]
+		logger.Info("Trimmed to top", limit, "processes by", sortColumn, "column")
 	}
 
-	// Log the final top 10 for verification
-	logger.Info("Final top 10 processes by memory:")
+	// Log the final top N for verification
+	logger.Info("Final top", len(processes), "processes by", sortColumn+":")
 	for i, p := range processes {
-		logger.Info(fmt.Sprintf("  #%d: %s - %.1f%% memory", i+1, p.Command, p.MemoryPercent))
+		logger.Info(fmt.Sprintf("  #%d: %s - %.1f%% memory %.1f%% CPU", i+1, p.Command, p.MemoryPercent, p.CPUPercent))
 	}
 
 	logger.Info("Memory usage parsing complete. Final process count:", len(processes))
 	return processes, nil
 }
 
+// topColumn is the byte span of a column label in top's header line
+type topColumn struct {
+	name       string
+	start, end int
+}
+
+// parseTopHeader maps each header label to its position in the line
+func parseTopHeader(header string) map[string]topColumn {
+	columns := make(map[string]topColumn)
+	for i := 0; i < len(header); {
+		if header[i] == ' ' {
+			i++
+			continue
+		}
+		start := i
+		for i < len(header) && header[i] != ' ' {
+			i++
+		}
+		name := header[start:i]
+		columns[name] = topColumn{name: name, start: start, end: i}
+	}
+	return columns
+}
+
+// topField returns the value under a header column. top right-aligns numeric
+// values under the end of their label and left-aligns USER and S, so the
+// field is the whitespace-delimited token covering that anchor position.
+// Anchoring on position rather than field count keeps the parse correct when
+// widths vary or a value carries a suffix such as "1.2g" or "+".
+func topField(line string, column topColumn) string {
+	pos := column.end - 1
+	if column.name == "USER" || column.name == "S" {
+		pos = column.start
+	}
+	if pos >= len(line) || line[pos] == ' ' {
+		return ""
+	}
+
+	start, end := pos, pos
+	for start > 0 && line[start-1] != ' ' {
+		start--
+	}
+	for end < len(line) && line[end] != ' ' {
+		end++
+	}
+	return line[start:end]
+}
+
+// topCommand returns the rest of the line from the COMMAND column, which is
+// the only column whose value may contain spaces
+func topCommand(line string, column topColumn) string {
+	start := column.start
+	if start >= len(line) {
+		return ""
+	}
+	// A value running into the column from the left is not part of it
+	for start < len(line) && start > 0 && line[start-1] != ' ' && line[start] != ' ' {
+		start++
+	}
+	return strings.TrimSpace(line[start:])
+}
+
+// topMemoryUnits scales top's memory suffixes; bare numbers are KiB
+var topMemoryUnits = map[byte]uint64{
+	'k': 1 << 10,
+	'm': 1 << 20,
+	'g': 1 << 30,
+	't': 1 << 40,
+	'p': 1 << 50,
+	'e': 1 << 60,
+}
+
+// parseTopMemory converts a top memory figure such as "123456", "1.2g" or
+// "512.0m" into bytes
+func parseTopMemory(value string) (uint64, error) {
+	value = strings.ToLower(strings.TrimSuffix(value, "+"))
+	if value == "" {
+		return 0, fmt.Errorf("empty memory value")
+	}
+
+	multiplier := topMemoryUnits['k']
+	if unit, ok := topMemoryUnits[value[len(value)-1]]; ok {
+		multiplier = unit
+		value = value[:len(value)-1]
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("invalid memory value %q", value)
+	}
+	return uint64(number * float64(multiplier)), nil
+}
+
 func (mm *MemoryMonitor) cleanCommandName(command string) string {
 	logger.Info("Cleaning command name:", command)
 
@@ -184,32 +407,9 @@ func (mm *MemoryMonitor) cleanCommandName(command string) string {
 	}
 
 	// Map common process names to friendlier versions
-	processMap := map[string]string{
-		"dockerd":        "Docker Daemon",
-		"containerd":     "Container Runtime",
-		"docker-proxy":   "Docker Proxy",
-		"nginx":          "Nginx",
-		"apache2":        "Apache",
-		"httpd":          "Apache",
-		"node":           "Node.js",
-		"mysql":          "MySQL",
-		"mysqld":         "MySQL",
-		"postgres":       "PostgreSQL",
-		"redis-server":   "Redis",
-		"mongod":         "MongoDB",
-		"systemd":        "SystemD",
-		"chrome":         "Chrome",
-		"firefox":        "Firefox",
-		"code":           "VS Code",
-		"gnome-shell":    "GNOME Shell",
-		"Xorg":           "X Server",
-		"pulseaudio":     "PulseAudio",
-		"NetworkManager": "Network Manager",
-	}
-
-	if friendlyName, exists := processMap[baseCommand]; exists {
-		logger.Info("Mapped process name:", command, "->", friendlyName)
-		return friendlyName
+	if alias, exists := LookupProcessAlias(baseCommand, false); exists {
+		logger.Info("Mapped process name:", command, "->", alias.Short)
+		return alias.Short
 	}
 
 	logger.Info("Using cleaned base command:", command, "->", baseCommand)
@@ -0,0 +1,140 @@
+package monitor
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"system-monitor-bot/pkg/logger"
+	"testing"
+)
+
+var initLogger sync.Once
+
+// topFixture is top -b output with a realtime PR, negative NI, VIRT values
+// wider than their column, an extra nTH column before COMMAND, and a row
+// whose oversized VIRT shifts every later column right by one
+const topFixture = `top - 10:00:00 up 1 day,  2:03,  1 user,  load average: 0.52, 0.58, 0.59
+Tasks: 312 total,   1 running, 311 sleeping,   0 stopped,   0 zombie
+
+    PID USER        PR   NI     VIRT     RES     SHR S   %CPU   %MEM      TIME+  nTH COMMAND
+      1 root        rt  -20  1234.5g  512.0m   10.0m S    0.0    3.2    0:01.00    1 /sbin/init splash
+ 123456 postgres    20    0 12345.6g    1.2g  100.0m S   12.5   12.7 1000:00.00   24 postgres: checkpointer
+     42 www-data    20   19   812.3m   65536    1024 R   99.9    0.8    0:42.00    8 nginx: worker process
+    314 mysql       20    0 123456789   2.5g   20.0m S    1.5   15.9   12:00.00   40 mysqld --daemonize
+      7 root        20    0        0       0       0 I    0.0    0.0    0:00.00    1 [kworker/0:1]
+`
+
+func TestParseTopOutput(t *testing.T) {
+	initLogger.Do(logger.Init)
+	mm := &MemoryMonitor{}
+
+	processes, err := mm.parseTopOutput(topFixture, SortByMemory, 10)
+	if err != nil {
+		t.Fatalf("parseTopOutput() error = %v", err)
+	}
+
+	want := []ProcessMemory{
+		{PID: "314", User: "mysql", MemoryPercent: 15.9, CPUPercent: 1.5, ResidentBytes: 5 << 29},
+		{PID: "123456", User: "postgres", MemoryPercent: 12.7, CPUPercent: 12.5, ResidentBytes: 1288490188}, // 1.2g,
+		{PID: "1", User: "root", MemoryPercent: 3.2, CPUPercent: 0, ResidentBytes: 512 << 20},
+		{PID: "42", User: "www-data", MemoryPercent: 0.8, CPUPercent: 99.9, ResidentBytes: 65536 << 10},
+	}
+	if len(processes) != len(want) {
+		t.Fatalf("got %d processes, want %d (the 0%% kernel thread is skipped): %+v", len(processes), len(want), processes)
+	}
+	for idx, process := range processes {
+		w := want[idx]
+		if process.PID != w.PID || process.User != w.User || process.MemoryPercent != w.MemoryPercent ||
+			process.CPUPercent != w.CPUPercent || process.ResidentBytes != w.ResidentBytes {
+			t.Errorf("process %d = %+v, want %+v", idx, process, w)
+		}
+		if process.Command == "" || strings.Contains(process.Command, " ") {
+			t.Errorf("process %d command = %q, want the bare program name", idx, process.Command)
+		}
+	}
+}
+
+func TestParseTopOutputByCPULimit(t *testing.T) {
+	initLogger.Do(logger.Init)
+	mm := &MemoryMonitor{}
+
+	processes, err := mm.parseTopOutput(topFixture, SortByCPU, 2)
+	if err != nil {
+		t.Fatalf("parseTopOutput() error = %v", err)
+	}
+	if len(processes) != 2 || processes[0].PID != "42" || processes[1].PID != "123456" {
+		t.Errorf("top 2 by CPU = %+v, want PIDs 42 and 123456", processes)
+	}
+}
+
+func TestParseTopOutputMissingColumn(t *testing.T) {
+	initLogger.Do(logger.Init)
+	mm := &MemoryMonitor{}
+
+	output := "    PID  %MEM COMMAND\n      1   3.2 init\n"
+	if _, err := mm.parseTopOutput(output, SortByMemory, 10); err == nil {
+		t.Error("parseTopOutput() accepted a header without USER and %CPU")
+	}
+}
+
+// writeProcFile writes a file under a fake /proc root
+func writeProcFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	path := filepath.Join(root, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResidentPercentFromProc(t *testing.T) {
+	initLogger.Do(logger.Init)
+	root := t.TempDir()
+	writeProcFile(t, root, "meminfo", "MemTotal:       16318412 kB\nMemFree:         1048576 kB\nMemAvailable:    8159206 kB\n")
+	writeProcFile(t, root, "100/status", "Name:\tpostgres\nVmPeak:\t 2000000 kB\nVmRSS:\t 1631841 kB\nThreads:\t24\n")
+	writeProcFile(t, root, "200/status", "Name:\tkworker/0:1\nThreads:\t1\n")
+
+	// top rounds both processes to one decimal; PID 300 exited since top ran
+	processes := []ProcessMemory{
+		{PID: "100", MemoryPercent: 10.0, ResidentBytes: 1600 << 20},
+		{PID: "200", MemoryPercent: 0.3},
+		{PID: "300", MemoryPercent: 1.5, ResidentBytes: 250 << 20},
+	}
+	mm := &MemoryMonitor{procPath: root, scanWorkers: 2}
+	mm.fillResidentBytes(processes)
+
+	usage, err := (&SystemInfoMonitor{procPath: root}).GetMemoryUsage()
+	if err != nil {
+		t.Fatalf("GetMemoryUsage() error = %v", err)
+	}
+	if usage.TotalBytes != 16318412*1024 {
+		t.Fatalf("TotalBytes = %d, want %d", usage.TotalBytes, 16318412*1024)
+	}
+	ResidentPercent(processes, usage.TotalBytes)
+
+	if got := processes[0].ResidentBytes; got != 1631841*1024 {
+		t.Errorf("ResidentBytes = %d, want the exact VmRSS %d", got, 1631841*1024)
+	}
+	// Exactly a tenth of MemTotal less 0.2 kB, which top shows as 10.0
+	if got, want := processes[0].MemoryPercent, 1631841.0/16318412.0*100; math.Abs(got-want) > 1e-9 || got >= 10 {
+		t.Errorf("MemoryPercent = %.9f, want %.9f", got, want)
+	}
+	if got := processes[1].MemoryPercent; got != 0.3 {
+		t.Errorf("kernel thread MemoryPercent = %v, want top's 0.3", got)
+	}
+	if got, want := processes[2].MemoryPercent, float64(250<<20)/float64(16318412*1024)*100; math.Abs(got-want) > 1e-9 {
+		t.Errorf("exited process MemoryPercent = %.9f, want %.9f from top's RES", got, want)
+	}
+}
+
+func TestResidentPercentWithoutTotal(t *testing.T) {
+	processes := []ProcessMemory{{PID: "1", MemoryPercent: 3.2, ResidentBytes: 512 << 20}}
+	ResidentPercent(processes, 0)
+	if processes[0].MemoryPercent != 3.2 {
+		t.Errorf("MemoryPercent = %v, want top's 3.2 kept", processes[0].MemoryPercent)
+	}
+}
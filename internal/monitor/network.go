@@ -2,8 +2,8 @@ package monitor
 
 import (
 	"fmt"
-	"os/exec"
 	"regexp"
+	"sort"
 	"strings"
 	"system-monitor-bot/pkg/logger"
 	"time"
@@ -12,19 +12,29 @@ import (
 	"golang.org/x/text/language"
 )
 
-type NetworkMonitor struct{}
+type NetworkMonitor struct {
+	excludePID string
+}
 
-func NewNetworkMonitor() *NetworkMonitor {
-	logger.Info("Creating new NetworkMonitor instance")
-	return &NetworkMonitor{}
+// NewNetworkMonitor creates a network monitor; with excludeSelf sockets owned
+// by the bot's own process are left out of the port list
+func NewNetworkMonitor(excludeSelf bool) *NetworkMonitor {
+	logger.Info("Creating new NetworkMonitor instance, exclude self:", excludeSelf)
+	return &NetworkMonitor{excludePID: selfPID(excludeSelf)}
 }
 
 func (nm *NetworkMonitor) GetPorts(showAll bool) ([]NetworkPort, error) {
+	return nm.GetPortsRaw(showAll, nil)
+}
+
+// GetPortsRaw is GetPorts that also records the ss output the ports were
+// parsed from into raw
+func (nm *NetworkMonitor) GetPortsRaw(showAll bool, raw *RawOutput) ([]NetworkPort, error) {
 	logger.Info("Starting network ports reading with showAll:", showAll)
 
 	// Check if ss command exists
 	logger.Info("Checking for ss command availability...")
-	if _, err := exec.LookPath("ss"); err != nil {
+	if err := lookPath("ss"); err != nil {
 		logger.Error("ss command not found:", err)
 		return nil, fmt.Errorf("ss command not found")
 	}
@@ -33,7 +43,7 @@ func (nm *NetworkMonitor) GetPorts(showAll bool) ([]NetworkPort, error) {
 	// Execute ss command
 	logger.Info("Executing ss command with flags: -tulnp")
 	startTime := time.Now()
-	cmd := exec.Command("ss", "-tulnp")
+	cmd := command("ss", "-tulnp")
 	output, err := cmd.Output()
 	duration := time.Since(startTime)
 
@@ -44,6 +54,7 @@ func (nm *NetworkMonitor) GetPorts(showAll bool) ([]NetworkPort, error) {
 
 	logger.Info("ss command completed successfully in", duration)
 	logger.Info("ss output length:", len(output), "bytes")
+	raw.record("ss", []string{"-tulnp"}, output)
 
 	ports, parseErr := nm.parseNetworkOutput(string(output), showAll)
 	if parseErr != nil {
@@ -55,6 +66,70 @@ func (nm *NetworkMonitor) GetPorts(showAll bool) ([]NetworkPort, error) {
 	return ports, nil
 }
 
+// GetUnixSocketsRaw reads the listening Unix domain sockets as ports with
+// the UNIX protocol, the socket path as Address and no port number. The ss
+// output is recorded into raw when it is not nil.
+func (nm *NetworkMonitor) GetUnixSocketsRaw(raw *RawOutput) ([]NetworkPort, error) {
+	logger.Info("Starting Unix socket reading...")
+
+	if err := lookPath("ss"); err != nil {
+		logger.Error("ss command not found:", err)
+		return nil, fmt.Errorf("ss command not found")
+	}
+
+	logger.Info("Executing ss command with flags: -xlnp")
+	startTime := time.Now()
+	cmd := command("ss", "-xlnp")
+	output, err := cmd.Output()
+	duration := time.Since(startTime)
+
+	if err != nil {
+		logger.Error("ss command failed after", duration, "error:", err)
+		return nil, fmt.Errorf("ss command failed: %v", err)
+	}
+
+	logger.Info("ss command completed successfully in", duration)
+	raw.record("ss", []string{"-xlnp"}, output)
+
+	sockets := nm.parseUnixSocketOutput(string(output))
+	logger.Info("Successfully parsed", len(sockets), "Unix sockets")
+	return sockets, nil
+}
+
+// parseUnixSocketOutput parses `ss -xlnp` lines of the form
+// "u_str LISTEN 0 128 /run/php/php-fpm.sock 12345 * 0 users:((...))"
+func (nm *NetworkMonitor) parseUnixSocketOutput(output string) []NetworkPort {
+	var sockets []NetworkPort
+	for i, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		// Skip the header and unnamed sockets, which have no path to show
+		if i == 0 || len(fields) < 8 || fields[4] == "*" {
+			continue
+		}
+
+		processInfo := ""
+		var processes []ProcessRef
+		if processField := fields[len(fields)-1]; strings.Contains(processField, "users:") {
+			if nm.excludePID != "" && strings.Contains(processField, "pid="+nm.excludePID+",") {
+				logger.Info("Skipping the bot's own socket:", fields[4])
+				continue
+			}
+			processes = nm.parseProcessInfo(processField)
+			processInfo = processes[0].Name
+		}
+
+		sockets = append(sockets, NetworkPort{
+			Protocol:    "UNIX",
+			Address:     fields[4],
+			State:       fields[1],
+			ProcessName: processInfo,
+			Processes:   processes,
+		})
+		logger.Info("Added Unix socket:", fields[4], "state:", fields[1])
+	}
+	return sockets
+}
+
 func (nm *NetworkMonitor) parseNetworkOutput(output string, showAll bool) ([]NetworkPort, error) {
 	logger.Info("Starting network output parsing...")
 	var ports []NetworkPort
@@ -83,6 +158,7 @@ func (nm *NetworkMonitor) parseNetworkOutput(output string, showAll bool) ([]Net
 		state := ""
 		address := ""
 		processInfo := ""
+		var processes []ProcessRef
 
 		logger.Info("Processing line", i+1, "- Protocol:", protocol, "Fields:", len(fields))
 
@@ -103,8 +179,13 @@ func (nm *NetworkMonitor) parseNetworkOutput(output string, showAll bool) ([]Net
 		if len(fields) > 5 {
 			processField := fields[len(fields)-1]
 			if strings.Contains(processField, "users:") {
-				processInfo = nm.parseProcessInfo(processField)
-				logger.Info("Found process info:", processInfo)
+				if nm.excludePID != "" && strings.Contains(processField, "pid="+nm.excludePID+",") {
+					logger.Info("Skipping the bot's own socket:", address)
+					continue
+				}
+				processes = nm.parseProcessInfo(processField)
+				processInfo = processes[0].Name
+				logger.Info("Found process info:", processInfo, "processes:", len(processes))
 			}
 		}
 
@@ -127,6 +208,7 @@ func (nm *NetworkMonitor) parseNetworkOutput(output string, showAll bool) ([]Net
 			Port:        port,
 			State:       state,
 			ProcessName: processInfo,
+			Processes:   processes,
 		}
 
 		ports = append(ports, networkPort)
@@ -142,70 +224,184 @@ func (nm *NetworkMonitor) parseNetworkOutput(output string, showAll bool) ([]Net
 	return ports, nil
 }
 
-func (nm *NetworkMonitor) parseProcessInfo(processField string) string {
+// parseProcessInfo extracts every process from an ss users field such as
+// users:(("nginx",pid=10,fd=6),("nginx",pid=11,fd=6)). A process listed
+// once per file descriptor is returned once. The result is never empty.
+func (nm *NetworkMonitor) parseProcessInfo(processField string) []ProcessRef {
 	logger.Info("Parsing process info from field:", processField)
 
-	// Extract process name and PID
-	re := regexp.MustCompile(`\(\("([^"]+)",pid=(\d+)`)
-	matches := re.FindStringSubmatch(processField)
-
-	if len(matches) >= 3 {
-		processName := matches[1]
-		pid := matches[2]
-		enhancedName := nm.enhanceProcessName(processName)
-		result := fmt.Sprintf("%s (PID: %s)", enhancedName, pid)
-		logger.Info("Extracted process with PID:", result)
-		return result
+	// Extract process names and PIDs
+	re := regexp.MustCompile(`\("([^"]+)",pid=(\d+)`)
+	var processes []ProcessRef
+	seen := make(map[string]bool)
+	for _, matches := range re.FindAllStringSubmatch(processField, -1) {
+		if pid := matches[2]; !seen[pid] {
+			seen[pid] = true
+			processes = append(processes, ProcessRef{Name: nm.enhanceProcessName(matches[1]), PID: pid})
+		}
+	}
+	if len(processes) > 0 {
+		logger.Info("Extracted", len(processes), "processes, first:", processes[0].Name, "PID:", processes[0].PID)
+		return processes
 	}
 
 	// Fallback: extract just process name
 	re2 := regexp.MustCompile(`"([^"]+)"`)
 	matches2 := re2.FindStringSubmatch(processField)
 	if len(matches2) >= 2 {
-		processName := matches2[1]
-		result := nm.enhanceProcessName(processName)
-		logger.Info("Extracted process name only:", result)
-		return result
+		name := nm.enhanceProcessName(matches2[1])
+		logger.Info("Extracted process name only:", name)
+		return []ProcessRef{{Name: name}}
 	}
 
 	logger.Info("Could not parse process info, using default")
-	return "Unknown Process"
+	return []ProcessRef{{Name: "Unknown Process"}}
 }
 
 func (nm *NetworkMonitor) enhanceProcessName(processName string) string {
 	logger.Info("Enhancing process name:", processName)
-	lower := strings.ToLower(processName)
 	caser := cases.Title(language.English)
 
-	processMap := map[string]string{
-		"docker-proxy": "Docker Container Port",
-		"docker":       "Docker Engine",
-		"containerd":   "Container Runtime",
-		"nginx":        "Nginx Web Server",
-		"apache":       "Apache Web Server",
-		"httpd":        "Apache Web Server",
-		"node":         "Node.js Application",
-		"mysql":        "MySQL Database",
-		"mariadb":      "MySQL Database",
-		"postgres":     "PostgreSQL Database",
-		"redis":        "Redis Cache",
-		"mongo":        "MongoDB Database",
-		"sshd":         "SSH Server",
-		"systemd":      "System Service",
-		"resolve":      "DNS Resolver",
-		"dhcp":         "DHCP Client",
-		"python":       "Python Application",
-		"java":         "Java Application",
-	}
-
-	for key, value := range processMap {
-		if strings.Contains(lower, key) {
-			logger.Info("Mapped process name:", processName, "->", value)
-			return value
-		}
+	if alias, exists := LookupProcessAlias(processName, true); exists {
+		logger.Info("Mapped process name:", processName, "->", alias.Name)
+		return alias.Name
 	}
 
 	result := caser.String(processName)
 	logger.Info("Using title case for process name:", processName, "->", result)
 	return result
 }
+
+// GetConnectionsByRemote aggregates established TCP connections by remote IP,
+// most connections first
+func (nm *NetworkMonitor) GetConnectionsByRemote() ([]RemoteConnections, error) {
+	logger.Info("Starting established connection reading...")
+
+	output, err := nm.readTCPSockets(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	remotes := nm.parseRemoteConnections(output)
+	logger.Info("Successfully aggregated connections from", len(remotes), "remote addresses")
+	return remotes, nil
+}
+
+// GetConnectionsByLocalPort counts established TCP connections per local
+// port, so listening services can be matched with their current clients
+func (nm *NetworkMonitor) GetConnectionsByLocalPort() (map[string]int, error) {
+	return nm.GetConnectionsByLocalPortRaw(nil)
+}
+
+// GetConnectionsByLocalPortRaw is GetConnectionsByLocalPort that also
+// records the ss output the counts were parsed from into raw
+func (nm *NetworkMonitor) GetConnectionsByLocalPortRaw(raw *RawOutput) (map[string]int, error) {
+	logger.Info("Starting established connection reading by local port...")
+
+	output, err := nm.readTCPSockets(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := nm.parseLocalPortConnections(output)
+	logger.Info("Successfully aggregated connections on", len(counts), "local ports")
+	return counts, nil
+}
+
+// readTCPSockets returns the raw `ss -tan` listing of every TCP socket
+func (nm *NetworkMonitor) readTCPSockets(raw *RawOutput) (string, error) {
+	if err := lookPath("ss"); err != nil {
+		logger.Error("ss command not found:", err)
+		return "", fmt.Errorf("ss command not found")
+	}
+
+	logger.Info("Executing ss command with flags: -tan")
+	startTime := time.Now()
+	cmd := command("ss", "-tan")
+	output, err := cmd.Output()
+	duration := time.Since(startTime)
+
+	if err != nil {
+		logger.Error("ss command failed after", duration, "error:", err)
+		return "", fmt.Errorf("ss command failed: %v", err)
+	}
+
+	logger.Info("ss command completed successfully in", duration)
+	raw.record("ss", []string{"-tan"}, output)
+	return string(output), nil
+}
+
+func (nm *NetworkMonitor) parseRemoteConnections(output string) []RemoteConnections {
+	logger.Info("Starting remote connection parsing...")
+	counts := make(map[string]int)
+	established := 0
+
+	for i, line := range strings.Split(output, "\n") {
+		// Skip header and empty lines
+		if i == 0 || strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		// State Recv-Q Send-Q Local Address:Port Peer Address:Port
+		fields := strings.Fields(line)
+		if len(fields) < 5 || fields[0] != "ESTAB" {
+			continue
+		}
+
+		remote := nm.stripPort(fields[4])
+		counts[remote]++
+		established++
+	}
+
+	var remotes []RemoteConnections
+	for address, count := range counts {
+		remotes = append(remotes, RemoteConnections{Address: address, Count: count})
+	}
+
+	sort.Slice(remotes, func(i, j int) bool {
+		if remotes[i].Count != remotes[j].Count {
+			return remotes[i].Count > remotes[j].Count
+		}
+		return remotes[i].Address < remotes[j].Address
+	})
+
+	logger.Info("Remote connection parsing complete. Established:", established, "Remotes:", len(remotes))
+	return remotes
+}
+
+func (nm *NetworkMonitor) parseLocalPortConnections(output string) map[string]int {
+	logger.Info("Starting local port connection parsing...")
+	counts := make(map[string]int)
+
+	for i, line := range strings.Split(output, "\n") {
+		// Skip header and empty lines
+		if i == 0 || strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		// State Recv-Q Send-Q Local Address:Port Peer Address:Port
+		fields := strings.Fields(line)
+		if len(fields) < 5 || fields[0] != "ESTAB" {
+			continue
+		}
+
+		idx := strings.LastIndex(fields[3], ":")
+		if idx < 0 {
+			continue
+		}
+		counts[fields[3][idx+1:]]++
+	}
+
+	logger.Info("Local port connection parsing complete. Ports:", len(counts))
+	return counts
+}
+
+// stripPort removes the port from an ss address, handling IPv6 brackets
+func (nm *NetworkMonitor) stripPort(address string) string {
+	idx := strings.LastIndex(address, ":")
+	if idx < 0 {
+		return address
+	}
+	host := address[:idx]
+	return strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+}
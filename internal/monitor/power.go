@@ -0,0 +1,94 @@
+package monitor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"system-monitor-bot/pkg/logger"
+)
+
+type PowerMonitor struct {
+	supplyPath string
+}
+
+func NewPowerMonitor() *PowerMonitor {
+	logger.Info("Creating new PowerMonitor instance")
+	return &PowerMonitor{supplyPath: "/sys/class/power_supply"}
+}
+
+// GetPowerSupplies reads all power supplies exposed by the kernel. An empty
+// result without error means the system has no power supply information.
+func (pm *PowerMonitor) GetPowerSupplies() ([]PowerSupply, error) {
+	logger.Info("Starting power supply reading from", pm.supplyPath)
+
+	entries, err := os.ReadDir(pm.supplyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			logger.Info("No power supply class found - skipping")
+			return nil, nil
+		}
+		logger.Error("Failed to read", pm.supplyPath, "error:", err)
+		return nil, fmt.Errorf("failed to read %s: %v", pm.supplyPath, err)
+	}
+
+	var supplies []PowerSupply
+	for _, entry := range entries {
+		dir := filepath.Join(pm.supplyPath, entry.Name())
+		supply := PowerSupply{
+			Name:       entry.Name(),
+			Type:       pm.readString(dir, "type"),
+			Status:     pm.readString(dir, "status"),
+			Capacity:   -1,
+			PowerWatts: -1,
+			Online:     pm.readString(dir, "online") == "1",
+		}
+
+		if capacity, ok := pm.readFloat(dir, "capacity"); ok {
+			supply.Capacity = capacity
+			supply.HasCapacity = true
+		}
+
+		// power_now is in µW; otherwise derive it from current (µA) and voltage (µV)
+		if power, ok := pm.readFloat(dir, "power_now"); ok {
+			supply.PowerWatts = power / 1e6
+		} else if current, ok := pm.readFloat(dir, "current_now"); ok {
+			if voltage, ok := pm.readFloat(dir, "voltage_now"); ok {
+				supply.PowerWatts = current * voltage / 1e12
+			}
+		}
+
+		supplies = append(supplies, supply)
+		logger.Info("Found power supply:", supply.Name, "Type:", supply.Type, "Status:", supply.Status, "Capacity:", supply.Capacity, "Power:", supply.PowerWatts, "W")
+	}
+
+	sort.Slice(supplies, func(i, j int) bool {
+		return supplies[i].Name < supplies[j].Name
+	})
+
+	logger.Info("Power supply reading complete. Found", len(supplies), "supplies")
+	return supplies, nil
+}
+
+func (pm *PowerMonitor) readString(dir, name string) string {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func (pm *PowerMonitor) readFloat(dir, name string) (float64, bool) {
+	value := pm.readString(dir, name)
+	if value == "" {
+		return 0, false
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		logger.Info("Could not parse", name, "value:", value, "in", dir)
+		return 0, false
+	}
+	return parsed, true
+}
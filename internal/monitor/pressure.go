@@ -0,0 +1,116 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"system-monitor-bot/pkg/logger"
+)
+
+// ErrPressureUnavailable is returned when the kernel does not expose
+// pressure stall information (before 4.20, or booted with psi=0)
+var ErrPressureUnavailable = errors.New("pressure stall information not available")
+
+// PressureResources are the /proc/pressure files read, in display order
+var PressureResources = []string{"cpu", "memory", "io"}
+
+type PressureMonitor struct {
+	pressurePath string
+}
+
+func NewPressureMonitor() *PressureMonitor {
+	logger.Info("Creating new PressureMonitor instance")
+	return &PressureMonitor{pressurePath: "/proc/pressure"}
+}
+
+// GetPressure reads PSI averages for cpu, memory and io. A resource whose
+// file is missing is left out; ErrPressureUnavailable means none exist.
+func (pm *PressureMonitor) GetPressure() ([]ResourcePressure, error) {
+	logger.Info("Starting pressure stall reading...")
+
+	var pressures []ResourcePressure
+	for _, resource := range PressureResources {
+		path := filepath.Join(pm.pressurePath, resource)
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Info("No pressure file for", resource, "- skipping")
+			continue
+		}
+		if err != nil {
+			logger.Error("Failed to read", path, "error:", err)
+			return nil, fmt.Errorf("failed to read %s: %v", path, err)
+		}
+
+		pressure, err := parsePressure(resource, string(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", path, err)
+		}
+		logger.Info("Pressure", resource, "- some avg10:", pressure.Some.Avg10, "avg60:", pressure.Some.Avg60, "avg300:", pressure.Some.Avg300)
+		pressures = append(pressures, pressure)
+	}
+
+	if len(pressures) == 0 {
+		return nil, ErrPressureUnavailable
+	}
+	return pressures, nil
+}
+
+// parsePressure parses a PSI file:
+//
+//	some avg10=0.12 avg60=0.05 avg300=0.01 total=123456
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//
+// Older kernels have no "full" line for cpu, leaving Full zero.
+func parsePressure(resource, content string) (ResourcePressure, error) {
+	pressure := ResourcePressure{Resource: resource}
+	foundSome := false
+
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		var target *PressureAverages
+		switch fields[0] {
+		case "some":
+			target = &pressure.Some
+			foundSome = true
+		case "full":
+			target = &pressure.Full
+		default:
+			continue
+		}
+
+		for _, field := range fields[1:] {
+			key, value, found := strings.Cut(field, "=")
+			if !found {
+				continue
+			}
+			var dest *float64
+			switch key {
+			case "avg10":
+				dest = &target.Avg10
+			case "avg60":
+				dest = &target.Avg60
+			case "avg300":
+				dest = &target.Avg300
+			default:
+				continue
+			}
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return pressure, fmt.Errorf("invalid %s value %q", key, value)
+			}
+			*dest = parsed
+		}
+	}
+
+	if !foundSome {
+		return pressure, fmt.Errorf("no \"some\" line")
+	}
+	return pressure, nil
+}
@@ -0,0 +1,38 @@
+package monitor
+
+import (
+	"errors"
+	"io/fs"
+	"sync"
+	"syscall"
+)
+
+// forEachPID calls read for every PID on a pool of at most workers
+// goroutines and returns once all have finished. read runs concurrently, so
+// it may only write to state owned by its index.
+func forEachPID(pids []string, workers int, read func(idx int, pid string)) {
+	workers = max(1, min(workers, len(pids)))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				read(idx, pids[idx])
+			}
+		}()
+	}
+	for idx := range pids {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// processGone reports whether err comes from a process that exited between
+// listing it and reading its /proc entry
+func processGone(err error) bool {
+	return errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ESRCH)
+}
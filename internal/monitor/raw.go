@@ -0,0 +1,34 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RawOutput collects the unparsed output of the external commands behind a
+// reading, so odd parser results can be checked against their input. A nil
+// *RawOutput records nothing.
+type RawOutput struct {
+	sections []string
+}
+
+// record adds the output of one command run
+func (r *RawOutput) record(name string, args []string, output []byte) {
+	if r == nil {
+		return
+	}
+	r.sections = append(r.sections, fmt.Sprintf("$ %s\n%s", strings.Join(append([]string{name}, args...), " "), output))
+}
+
+// Empty reports whether no command output was recorded
+func (r *RawOutput) Empty() bool {
+	return r == nil || len(r.sections) == 0
+}
+
+// String returns every recorded command with its output, in run order
+func (r *RawOutput) String() string {
+	if r == nil {
+		return ""
+	}
+	return strings.Join(r.sections, "\n")
+}
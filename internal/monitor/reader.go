@@ -0,0 +1,69 @@
+package monitor
+
+// SensorReader reads the current temperature sensors
+type SensorReader interface {
+	GetSensors() ([]TemperatureSensor, error)
+	GetSensorsRaw(raw *RawOutput) ([]TemperatureSensor, error)
+}
+
+// PortReader reads the current network ports and connections
+type PortReader interface {
+	GetPorts(showAll bool) ([]NetworkPort, error)
+	GetPortsRaw(showAll bool, raw *RawOutput) ([]NetworkPort, error)
+	GetUnixSocketsRaw(raw *RawOutput) ([]NetworkPort, error)
+	GetConnectionsByRemote() ([]RemoteConnections, error)
+	GetConnectionsByLocalPort() (map[string]int, error)
+	GetConnectionsByLocalPortRaw(raw *RawOutput) (map[string]int, error)
+}
+
+// ProcessReader reads the current top processes by memory or CPU usage
+type ProcessReader interface {
+	GetTopProcesses(limit int) ([]ProcessMemory, error)
+	GetTopProcessesRaw(limit int, raw *RawOutput) ([]ProcessMemory, error)
+	GetTopProcessesBy(sortColumn string, limit int, raw *RawOutput) ([]ProcessMemory, error)
+}
+
+// FileDescriptorReader reads file descriptor usage
+type FileDescriptorReader interface {
+	GetFileDescriptorStats(pids []string) (*FileDescriptorStats, error)
+}
+
+// SystemInfoReader reads general system information
+type SystemInfoReader interface {
+	GetProcessCounts() (*ProcessCounts, error)
+	GetMemoryUsage() (*MemoryUsage, error)
+}
+
+// PowerReader reads battery and power supply state
+type PowerReader interface {
+	GetPowerSupplies() ([]PowerSupply, error)
+}
+
+// DiskReader reads filesystem space usage
+type DiskReader interface {
+	GetDiskUsage() ([]DiskUsage, error)
+	GetFilesystems(all bool) ([]DiskUsage, error)
+}
+
+// CPUReader reads CPU utilization
+type CPUReader interface {
+	GetUsage() (*CPUUsage, error)
+}
+
+// PressureReader reads pressure stall information
+type PressureReader interface {
+	GetPressure() ([]ResourcePressure, error)
+}
+
+// Compile-time checks that the monitors satisfy the reader interfaces
+var (
+	_ SensorReader         = (*TemperatureMonitor)(nil)
+	_ PortReader           = (*NetworkMonitor)(nil)
+	_ ProcessReader        = (*MemoryMonitor)(nil)
+	_ FileDescriptorReader = (*FileDescriptorMonitor)(nil)
+	_ SystemInfoReader     = (*SystemInfoMonitor)(nil)
+	_ PowerReader          = (*PowerMonitor)(nil)
+	_ DiskReader           = (*DiskMonitor)(nil)
+	_ CPUReader            = (*CPUMonitor)(nil)
+	_ PressureReader       = (*PressureMonitor)(nil)
+)
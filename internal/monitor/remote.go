@@ -0,0 +1,165 @@
+package monitor
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"system-monitor-bot/pkg/logger"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// remoteDialTimeout bounds connecting and authenticating to the remote host
+const remoteDialTimeout = 10 * time.Second
+
+// RemoteHost is a machine whose external commands run over SSH instead of
+// locally. Only sensors, ss and top are remote; IPMI is disabled and
+// readings taken from the local /proc and /sys stay those of the bot's own
+// host.
+type RemoteHost struct {
+	Addr           string // host:port
+	User           string
+	KeyFile        string // private key; passphrase-protected keys are not supported
+	KnownHostsFile string // verifies the host key
+}
+
+// remote is the active remote host, nil when commands run locally
+var remote *remoteRunner
+
+// remoteRunner holds one SSH connection, shared by all commands and
+// redialled after it drops
+type remoteRunner struct {
+	addr   string
+	config *ssh.ClientConfig
+
+	mu     sync.Mutex
+	client *ssh.Client
+}
+
+// SetRemoteHost makes the external commands run on host over SSH; call it
+// once at startup, before any monitor reads. The key and known hosts are
+// loaded here so mistakes fail at startup, while the connection is made by
+// the first command.
+func SetRemoteHost(host RemoteHost) error {
+	key, err := os.ReadFile(host.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read SSH key: %w", err)
+	}
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to parse SSH key %s: %w", host.KeyFile, err)
+	}
+	hostKeys, err := knownhosts.New(host.KnownHostsFile)
+	if err != nil {
+		return fmt.Errorf("failed to load known hosts: %w", err)
+	}
+
+	remote = &remoteRunner{
+		addr: host.Addr,
+		config: &ssh.ClientConfig{
+			User:            host.User,
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+			HostKeyCallback: hostKeys,
+			Timeout:         remoteDialTimeout,
+		},
+	}
+	logger.Info("External commands will run on", host.User+"@"+host.Addr, "over SSH")
+	return nil
+}
+
+// IsRemote reports whether the external commands run on a remote host
+func IsRemote() bool {
+	return remote != nil
+}
+
+// session opens an SSH session, connecting first when needed. A session
+// that cannot be opened on an existing connection means it has dropped, so
+// it is redialled once.
+func (rr *remoteRunner) session() (*ssh.Session, error) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	if rr.client != nil {
+		session, err := rr.client.NewSession()
+		if err == nil {
+			return session, nil
+		}
+		logger.Warn("SSH connection to", rr.addr, "lost - reconnecting:", err)
+		rr.client.Close()
+		rr.client = nil
+	}
+
+	logger.Info("Connecting to remote host", rr.addr, "over SSH...")
+	client, err := ssh.Dial("tcp", rr.addr, rr.config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", rr.addr, err)
+	}
+	rr.client = client
+	logger.Info("Connected to remote host", rr.addr)
+	return client.NewSession()
+}
+
+// remoteCommand is an external command run on the remote host
+type remoteCommand struct {
+	runner *remoteRunner
+	argv   []string
+}
+
+// Output runs the command and returns its stdout. Like exec.Cmd.Output,
+// the stdout read so far is returned alongside a non-zero exit.
+func (rc *remoteCommand) Output() ([]byte, error) {
+	session, err := rc.runner.session()
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+
+	var stdout, stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+	err = session.Run(shellJoin(rc.argv))
+
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) {
+		return stdout.Bytes(), &remoteExitError{ExitError: exitErr, Stderr: stderr.Bytes()}
+	}
+	return stdout.Bytes(), err
+}
+
+// remoteExitError is a remote command that exited non-zero, with its
+// stderr like exec.ExitError
+type remoteExitError struct {
+	*ssh.ExitError
+	Stderr []byte
+}
+
+// ExitCode returns the remote command's exit status
+func (e *remoteExitError) ExitCode() int {
+	return e.ExitStatus()
+}
+
+// shellJoin quotes argv for the remote login shell, which SSH always runs
+// the command through
+func shellJoin(argv []string) string {
+	quoted := make([]string, len(argv))
+	for idx, arg := range argv {
+		quoted[idx] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
+
+// lookPath checks that an external command is installed. A remote host is
+// not checked up front; a missing command fails when it is run.
+func lookPath(name string) error {
+	if remote != nil {
+		return nil
+	}
+	_, err := exec.LookPath(name)
+	return err
+}
@@ -0,0 +1,67 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"system-monitor-bot/pkg/logger"
+)
+
+// readSensorsJSON runs `sensors -A -j` (lm-sensors 3.5+) and renders the
+// result in the `sensors -A -u` layout so the text parser handles both
+func readSensorsJSON(raw *RawOutput) (string, error) {
+	output, err := command("sensors", "-A", "-j").Output()
+	if err != nil {
+		return "", fmt.Errorf("sensors -j failed: %w", err)
+	}
+	raw.record("sensors", []string{"-A", "-j"}, output)
+	return sensorsJSONToText(output)
+}
+
+// sensorsJSONToText converts {"chip": {"feature": {"temp1_input": 42.0}}}
+// into chip, "feature:" and "  temp1_input: 42.000" lines
+func sensorsJSONToText(data []byte) (string, error) {
+	var chips map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(data, &chips); err != nil {
+		return "", fmt.Errorf("invalid sensors JSON: %w", err)
+	}
+
+	var b strings.Builder
+	for _, chip := range sortedKeys(chips) {
+		b.WriteString(chip + "\n")
+		features := chips[chip]
+		for _, feature := range sortedKeys(features) {
+			var subfeatures map[string]float64
+			if err := json.Unmarshal(features[feature], &subfeatures); err != nil {
+				// Scalar entries such as "Adapter" carry no readings
+				continue
+			}
+			b.WriteString(feature + ":\n")
+			for _, name := range sortedKeys(subfeatures) {
+				fmt.Fprintf(&b, "  %s: %.3f\n", name, subfeatures[name])
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// detectSensorsJSON reports whether the installed sensors supports -j
+func detectSensorsJSON() bool {
+	if _, err := readSensorsJSON(nil); err != nil {
+		logger.Info("sensors JSON output unavailable, using text parser:", err)
+		return false
+	}
+	logger.Info("sensors supports JSON output - preferring it over text parsing")
+	return true
+}
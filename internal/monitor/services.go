@@ -0,0 +1,92 @@
+package monitor
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"sync"
+	"system-monitor-bot/pkg/logger"
+)
+
+const servicesFile = "/etc/services"
+
+// wellKnownServices holds curated display names which take precedence over
+// /etc/services and act as the fallback when that file is unavailable
+var wellKnownServices = map[string]string{
+	"22":    "SSH",
+	"80":    "HTTP",
+	"443":   "HTTPS",
+	"3306":  "MySQL",
+	"5432":  "PostgreSQL",
+	"6379":  "Redis",
+	"27017": "MongoDB",
+	"8080":  "HTTP-Alt",
+	"8443":  "HTTPS-Alt",
+	"9000":  "SonarQube",
+	"5672":  "RabbitMQ",
+	"15672": "RabbitMQ-UI",
+	"1433":  "SQL Server",
+	"9200":  "Elasticsearch",
+	"9300":  "Elasticsearch",
+}
+
+var (
+	servicesOnce sync.Once
+	serviceNames map[string]string
+)
+
+// LoadServices parses /etc/services once and caches the result
+func LoadServices() {
+	servicesOnce.Do(func() {
+		logger.Info("Loading service names from", servicesFile)
+		names, err := parseServicesFile(servicesFile)
+		if err != nil {
+			logger.Warn("Could not load", servicesFile, "- using built-in service names only:", err)
+			names = make(map[string]string)
+		}
+		serviceNames = names
+		logger.Info("Loaded", len(serviceNames), "service entries from", servicesFile)
+	})
+}
+
+// LookupService returns the service name registered for a port and protocol,
+// or an empty string if none is known
+func LookupService(port, protocol string) string {
+	if name, exists := wellKnownServices[port]; exists {
+		return name
+	}
+
+	LoadServices()
+	return serviceNames[port+"/"+strings.ToLower(protocol)]
+}
+
+// parseServicesFile reads entries of the form "name port/proto [aliases]"
+func parseServicesFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	names := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.Contains(fields[1], "/") {
+			continue
+		}
+
+		// Keep the first name registered for a port/protocol pair
+		key := strings.ToLower(fields[1])
+		if _, exists := names[key]; !exists {
+			names[key] = fields[0]
+		}
+	}
+
+	return names, scanner.Err()
+}
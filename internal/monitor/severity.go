@@ -0,0 +1,193 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+	"system-monitor-bot/pkg/logger"
+)
+
+// SeverityLevel is a named temperature severity; a sensor takes the highest
+// level whose Threshold it has reached
+type SeverityLevel struct {
+	Name      string
+	Threshold float64 // °C; unused for the base Normal level
+	Color     int
+	Icon      string
+}
+
+// Names of the levels that drive alerting; every level table has both.
+// Levels below Warning are informational, levels above Critical alert like
+// Critical under their own name.
+const (
+	SeverityWarning  = "Warning"
+	SeverityCritical = "Critical"
+)
+
+// normalLevel is the base level of sensors below every threshold
+var normalLevel = SeverityLevel{Name: "Normal", Color: 0x00ff00, Icon: "✅"}
+
+// severityLevels is the active table: normalLevel followed by the configured
+// levels in ascending threshold order. A TempStatus indexes into it.
+var severityLevels = append([]SeverityLevel{normalLevel}, DefaultSeverityLevels(70, 80)...)
+
+// DefaultSeverityLevels returns the built-in warning and critical levels
+func DefaultSeverityLevels(warning, critical float64) []SeverityLevel {
+	return []SeverityLevel{
+		{Name: SeverityWarning, Threshold: warning, Color: 0xff8800, Icon: "⚠️"},
+		{Name: SeverityCritical, Threshold: critical, Color: 0xff0000, Icon: "🚨"},
+	}
+}
+
+// ValidateSeverityLevels checks that levels have unique names, strictly
+// ascending thresholds and icons, and include Warning and Critical
+func ValidateSeverityLevels(levels []SeverityLevel) error {
+	seen := make(map[string]bool)
+	for idx, level := range levels {
+		name := strings.ToLower(level.Name)
+		switch {
+		case level.Name == "":
+			return fmt.Errorf("severity level %d has no name", idx+1)
+		case strings.EqualFold(level.Name, normalLevel.Name):
+			return fmt.Errorf("severity level %q is reserved for sensors below every threshold", normalLevel.Name)
+		case seen[name]:
+			return fmt.Errorf("severity level %q is defined twice", level.Name)
+		case level.Icon == "":
+			return fmt.Errorf("severity level %q has no icon", level.Name)
+		case level.Color < 0 || level.Color > 0xffffff:
+			return fmt.Errorf("severity level %q has an invalid color", level.Name)
+		case idx > 0 && level.Threshold <= levels[idx-1].Threshold:
+			return fmt.Errorf("severity level %q (%.1f°C) must be above %q (%.1f°C)",
+				level.Name, level.Threshold, levels[idx-1].Name, levels[idx-1].Threshold)
+		}
+		seen[name] = true
+	}
+	for _, required := range []string{SeverityWarning, SeverityCritical} {
+		if !seen[strings.ToLower(required)] {
+			return fmt.Errorf("severity levels must include %q", required)
+		}
+	}
+	return nil
+}
+
+// SetSeverityLevels installs the severity table; call it once at startup,
+// before any sensors are read. Warning and Critical names are matched
+// case-insensitively and normalised.
+func SetSeverityLevels(levels []SeverityLevel) error {
+	if err := ValidateSeverityLevels(levels); err != nil {
+		return err
+	}
+
+	table := append([]SeverityLevel{normalLevel}, levels...)
+	for idx := range table {
+		switch {
+		case strings.EqualFold(table[idx].Name, SeverityWarning):
+			table[idx].Name = SeverityWarning
+			TempWarning = TempStatus(idx)
+		case strings.EqualFold(table[idx].Name, SeverityCritical):
+			table[idx].Name = SeverityCritical
+			TempCritical = TempStatus(idx)
+		}
+		logger.Info("Severity level", idx, "-", table[idx].Name, "from", table[idx].Threshold, "°C")
+	}
+	severityLevels = table
+	return nil
+}
+
+// SeverityLevels returns the active levels above Normal, lowest first
+func SeverityLevels() []SeverityLevel {
+	return append([]SeverityLevel(nil), severityLevels[1:]...)
+}
+
+// Level returns the severity level definition of the status
+func (ts TempStatus) Level() SeverityLevel {
+	if ts < 0 || int(ts) >= len(severityLevels) {
+		return SeverityLevel{Name: "Unknown", Color: normalLevel.Color, Icon: "❔"}
+	}
+	return severityLevels[ts]
+}
+
+// Threshold returns the temperature at which the status begins
+func (ts TempStatus) Threshold() float64 {
+	return ts.Level().Threshold
+}
+
+// StatusForTemperature returns the highest level whose threshold temp has
+// reached
+func StatusForTemperature(temp float64) TempStatus {
+	for idx := len(severityLevels) - 1; idx > 0; idx-- {
+		if temp >= severityLevels[idx].Threshold {
+			return TempStatus(idx)
+		}
+	}
+	return TempNormal
+}
+
+// StatusByName finds a level by name, case-insensitively
+func StatusByName(name string) (TempStatus, bool) {
+	for idx, level := range severityLevels {
+		if strings.EqualFold(level.Name, name) {
+			return TempStatus(idx), true
+		}
+	}
+	return TempNormal, false
+}
+
+// SensorThresholds overrides the global warning and critical thresholds for
+// one sensor, e.g. a drive that should alert well before the CPU does
+type SensorThresholds struct {
+	Warning  float64
+	Critical float64
+}
+
+// sensorThresholds maps sensor IDs to their overrides
+var sensorThresholds = map[string]SensorThresholds{}
+
+// SetSensorThresholds installs the per-sensor overrides; call it once at
+// startup, before any sensors are read
+func SetSensorThresholds(overrides map[string]SensorThresholds) {
+	sensorThresholds = make(map[string]SensorThresholds, len(overrides))
+	for id, override := range overrides {
+		sensorThresholds[id] = override
+		logger.Info("Sensor", id, "thresholds - Warning:", override.Warning, "°C Critical:", override.Critical, "°C")
+	}
+}
+
+// SensorThresholdsFor returns the overrides of a sensor, if it has any
+func SensorThresholdsFor(id string) (SensorThresholds, bool) {
+	override, ok := sensorThresholds[id]
+	return override, ok
+}
+
+// StatusForSensor grades a reading by the sensor's own thresholds when it
+// has them, else like StatusForTemperature. Levels below Warning and above
+// Critical keep their global thresholds.
+func StatusForSensor(id string, temp float64) TempStatus {
+	status := StatusForTemperature(temp)
+	override, ok := sensorThresholds[id]
+	switch {
+	case !ok:
+		return status
+	case temp >= override.Critical:
+		return max(status, TempCritical)
+	case temp >= override.Warning:
+		return TempWarning
+	default:
+		return min(status, TempWarning-1)
+	}
+}
+
+// SensorThreshold returns the temperature at which a sensor reaches status
+func SensorThreshold(id string, status TempStatus) float64 {
+	threshold := status.Threshold()
+	override, ok := sensorThresholds[id]
+	switch {
+	case !ok || status < TempWarning:
+		return threshold
+	case status == TempWarning:
+		return override.Warning
+	case status == TempCritical:
+		return override.Critical
+	default:
+		return max(threshold, override.Critical)
+	}
+}
@@ -0,0 +1,110 @@
+package monitor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"system-monitor-bot/pkg/logger"
+)
+
+// sysfsRoot is where device topology is read from when correlating storage
+// chips with their block devices
+var sysfsRoot = "/sys"
+
+// nvmeComposite is the feature NVMe drives report their headline temperature as
+const nvmeComposite = "composite"
+
+func isNVMeChip(chip string) bool {
+	return strings.HasPrefix(strings.ToLower(chip), "nvme-")
+}
+
+func isDrivetempChip(chip string) bool {
+	return strings.HasPrefix(strings.ToLower(chip), "drivetemp-")
+}
+
+func isStorageChip(chip string) bool {
+	return isNVMeChip(chip) || isDrivetempChip(chip)
+}
+
+// storageSensorName labels a storage temperature with the device it belongs
+// to, e.g. "NVMe nvme0" or "NVMe nvme0 Sensor 1" for secondary sensors
+func storageSensorName(chip, feature string) string {
+	device := storageDeviceName(chip)
+	if device == "" {
+		device = chip
+	}
+
+	var name string
+	if isNVMeChip(chip) {
+		name = "NVMe " + device
+	} else {
+		name = "Drive " + device
+	}
+
+	if feature == "" || strings.EqualFold(feature, nvmeComposite) || strings.HasPrefix(strings.ToLower(feature), "temp") {
+		return name
+	}
+	return name + " " + feature
+}
+
+// storageDeviceName resolves a sensors chip name to its kernel device name,
+// returning "" when it cannot be correlated
+func storageDeviceName(chip string) string {
+	parts := strings.Split(chip, "-")
+	switch {
+	case isNVMeChip(chip) && len(parts) == 3 && parts[1] == "pci":
+		return nvmeDeviceForPCI(parts[2])
+	case isDrivetempChip(chip) && len(parts) == 4 && parts[1] == "scsi":
+		return blockDeviceForSCSIHost(parts[2])
+	}
+	return ""
+}
+
+// nvmeDeviceForPCI finds the nvme controller behind a sensors PCI address,
+// which libsensors encodes as hex (bus << 8) | devfn
+func nvmeDeviceForPCI(addr string) string {
+	value, err := strconv.ParseUint(addr, 16, 16)
+	if err != nil {
+		return ""
+	}
+	bus := value >> 8
+	devfn := value & 0xff
+	pciSuffix := fmt.Sprintf("%02x:%02x.%x", bus, devfn>>3, devfn&0x7)
+
+	controllers, _ := filepath.Glob(filepath.Join(sysfsRoot, "class", "nvme", "nvme*"))
+	for _, controller := range controllers {
+		target, err := os.Readlink(filepath.Join(controller, "device"))
+		if err != nil {
+			continue
+		}
+		if strings.HasSuffix(filepath.Base(target), pciSuffix) {
+			logger.Info("Correlated NVMe chip address", addr, "with", filepath.Base(controller))
+			return filepath.Base(controller)
+		}
+	}
+	return ""
+}
+
+// blockDeviceForSCSIHost finds the disk attached to a SCSI host, giving up
+// when the host has more than one disk and the match would be ambiguous
+func blockDeviceForSCSIHost(host string) string {
+	disks, _ := filepath.Glob(filepath.Join(sysfsRoot, "bus", "scsi", "devices", host+":*", "block", "*"))
+	if len(disks) != 1 {
+		return ""
+	}
+	logger.Info("Correlated drivetemp SCSI host", host, "with", filepath.Base(disks[0]))
+	return filepath.Base(disks[0])
+}
+
+// StorageSensors returns only the storage-category sensors, in their original order
+func StorageSensors(sensors []TemperatureSensor) []TemperatureSensor {
+	var storage []TemperatureSensor
+	for _, sensor := range sensors {
+		if sensor.Category == CategoryStorage {
+			storage = append(storage, sensor)
+		}
+	}
+	return storage
+}
@@ -0,0 +1,116 @@
+package monitor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"system-monitor-bot/pkg/logger"
+)
+
+type SystemInfoMonitor struct {
+	procPath string
+}
+
+func NewSystemInfoMonitor() *SystemInfoMonitor {
+	logger.Info("Creating new SystemInfoMonitor instance")
+	return &SystemInfoMonitor{procPath: "/proc"}
+}
+
+// GetProcessCounts counts running processes from /proc and threads from
+// the runnable/total field of /proc/loadavg
+func (si *SystemInfoMonitor) GetProcessCounts() (*ProcessCounts, error) {
+	logger.Info("Starting process count reading...")
+
+	entries, err := os.ReadDir(si.procPath)
+	if err != nil {
+		logger.Error("Failed to read", si.procPath, "error:", err)
+		return nil, fmt.Errorf("failed to read %s: %v", si.procPath, err)
+	}
+
+	counts := &ProcessCounts{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := strconv.Atoi(entry.Name()); err == nil {
+			counts.Processes++
+		}
+	}
+
+	loadavgPath := filepath.Join(si.procPath, "loadavg")
+	data, err := os.ReadFile(loadavgPath)
+	if err != nil {
+		logger.Error("Failed to read", loadavgPath, "error:", err)
+		return nil, fmt.Errorf("failed to read %s: %v", loadavgPath, err)
+	}
+
+	// Format: <1m> <5m> <15m> <running>/<total> <last pid>
+	fields := strings.Fields(string(data))
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("unexpected %s format", loadavgPath)
+	}
+	parts := strings.Split(fields[3], "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("unexpected %s scheduling field %q", loadavgPath, fields[3])
+	}
+	if counts.Running, err = strconv.Atoi(parts[0]); err != nil {
+		return nil, fmt.Errorf("invalid running count %q: %v", parts[0], err)
+	}
+	if counts.Threads, err = strconv.Atoi(parts[1]); err != nil {
+		return nil, fmt.Errorf("invalid thread count %q: %v", parts[1], err)
+	}
+
+	logger.Info("Process counts - Processes:", counts.Processes, "Threads:", counts.Threads, "Running:", counts.Running)
+	return counts, nil
+}
+
+// GetMemoryUsage reads total and available memory from /proc/meminfo
+func (si *SystemInfoMonitor) GetMemoryUsage() (*MemoryUsage, error) {
+	logger.Info("Starting memory usage reading...")
+
+	meminfoPath := filepath.Join(si.procPath, "meminfo")
+	data, err := os.ReadFile(meminfoPath)
+	if err != nil {
+		logger.Error("Failed to read", meminfoPath, "error:", err)
+		return nil, fmt.Errorf("failed to read %s: %v", meminfoPath, err)
+	}
+
+	// Lines look like "MemTotal:       16318412 kB"
+	values := make(map[string]uint64)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		kib, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		values[strings.TrimSuffix(fields[0], ":")] = kib * 1024
+	}
+
+	total, ok := values["MemTotal"]
+	if !ok || total == 0 {
+		return nil, fmt.Errorf("no MemTotal in %s", meminfoPath)
+	}
+	available, ok := values["MemAvailable"]
+	if !ok {
+		// Kernels before 3.14 lack MemAvailable
+		available = values["MemFree"] + values["Buffers"] + values["Cached"]
+	}
+
+	usage := &MemoryUsage{TotalBytes: total, AvailableBytes: available}
+	logger.Info("Memory usage - Total:", total, "Available:", available, "Used:", fmt.Sprintf("%.1f%%", usage.UsedPercent()))
+	return usage, nil
+}
+
+// selfPID returns the bot's own PID when exclude is set, or "" so that no
+// process matches; the bot never runs on a remote host
+func selfPID(exclude bool) string {
+	if !exclude || remote != nil {
+		return ""
+	}
+	return strconv.Itoa(os.Getpid())
+}
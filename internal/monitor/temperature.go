@@ -1,8 +1,8 @@
 package monitor
 
 import (
+	"errors"
 	"fmt"
-	"os/exec"
 	"regexp"
 	"sort"
 	"strconv"
@@ -14,46 +14,146 @@ import (
 	"golang.org/x/text/language"
 )
 
+var (
+	// ErrSensorsNotInstalled is returned when the sensors command is missing
+	ErrSensorsNotInstalled = errors.New("lm-sensors not installed - run: sudo pacman -S lm_sensors")
+
+	// ErrNoSensorChips is returned when sensors runs but reports no chips,
+	// typically because sensors-detect has never been run
+	ErrNoSensorChips = errors.New("sensors returned no chips - run: sudo sensors-detect")
+)
+
+// maxPlausibleLimit bounds chip-reported high/crit limits in °C
+const maxPlausibleLimit = 250.0
+
 type TemperatureMonitor struct {
-	criticalThreshold float64
-	warningThreshold  float64
+	backend     string
+	ipmiEnabled bool
+	sensorsJSON bool
+	history     *statusHistory
 }
 
-func NewTemperatureMonitor(critical, warning float64) *TemperatureMonitor {
-	logger.Info("Creating new TemperatureMonitor with thresholds - Critical:", critical, "Warning:", warning)
+// NewTemperatureMonitor creates a temperature monitor that reads sensors
+// through backend and grades readings by the severity level table; a sensor
+// that reached a level only drops below it once it is hysteresis °C under
+// that level's threshold
+func NewTemperatureMonitor(hysteresis float64, backend string) *TemperatureMonitor {
+	backend = resolveTempBackend(backend)
+	logger.Info("Creating new TemperatureMonitor with", len(SeverityLevels()), "severity levels - Hysteresis:", hysteresis, "Backend:", backend)
 	return &TemperatureMonitor{
-		criticalThreshold: critical,
-		warningThreshold:  warning,
+		backend:     backend,
+		ipmiEnabled: detectIPMI(),
+		sensorsJSON: detectSensorsJSON(),
+		history:     newStatusHistory(hysteresis),
 	}
 }
 
+// GetSensors reads the sensors and applies hysteresis to their status
 func (tm *TemperatureMonitor) GetSensors() ([]TemperatureSensor, error) {
+	return tm.GetSensorsRaw(nil)
+}
+
+// GetSensorsRaw is GetSensors that also records the command output the
+// sensors were parsed from into raw
+func (tm *TemperatureMonitor) GetSensorsRaw(raw *RawOutput) ([]TemperatureSensor, error) {
+	sensors, err := tm.readSensors(raw)
+	if err != nil {
+		return sensors, err
+	}
+	tm.history.apply(sensors)
+	return sensors, nil
+}
+
+// readSensors reads the host sensors through the configured backend and,
+// when available, BMC sensors via IPMI
+func (tm *TemperatureMonitor) readSensors(raw *RawOutput) ([]TemperatureSensor, error) {
+	sensors, err := tm.getHostSensors(raw)
+	if !tm.ipmiEnabled {
+		return sensors, err
+	}
+
+	ipmiSensors, ipmiErr := tm.getIPMISensors(raw)
+	if ipmiErr != nil {
+		logger.Warn("IPMI sensor reading failed:", ipmiErr)
+		return sensors, err
+	}
+
+	if err != nil {
+		logger.Warn("Host sensor reading failed, using IPMI sensors only:", err)
+		sensors = nil
+	}
+
+	logger.Info("Merging", len(ipmiSensors), "IPMI sensors with", len(sensors), "lm-sensors sensors")
+	sensors = append(sensors, ipmiSensors...)
+	tm.sortSensors(sensors)
+	return sensors, nil
+}
+
+// getHostSensors reads the sensors of the monitored host itself
+func (tm *TemperatureMonitor) getHostSensors(raw *RawOutput) ([]TemperatureSensor, error) {
+	if tm.backend == TempBackendGopsutil {
+		return tm.getGopsutilSensors(raw)
+	}
+	return tm.getLMSensors(raw)
+}
+
+func (tm *TemperatureMonitor) getLMSensors(raw *RawOutput) ([]TemperatureSensor, error) {
 	logger.Info("Starting temperature sensor reading...")
 
 	// Check if sensors command exists
 	logger.Info("Checking for lm-sensors availability...")
-	if _, err := exec.LookPath("sensors"); err != nil {
+	if err := lookPath("sensors"); err != nil {
 		logger.Error("lm-sensors not found:", err)
-		return nil, fmt.Errorf("lm-sensors not installed - run: sudo pacman -S lm_sensors")
+		return nil, ErrSensorsNotInstalled
 	}
 	logger.Info("lm-sensors found and available")
 
+	if tm.sensorsJSON {
+		output, err := readSensorsJSON(raw)
+		if err == nil {
+			return tm.parseSensors(output)
+		}
+		logger.Warn("sensors JSON reading failed, falling back to text output:", err)
+	}
+
 	// Execute sensors command
 	logger.Info("Executing sensors command with flags: -A -u")
 	startTime := time.Now()
-	cmd := exec.Command("sensors", "-A", "-u")
+	cmd := command("sensors", "-A", "-u")
 	output, err := cmd.Output()
 	duration := time.Since(startTime)
 
 	if err != nil {
-		logger.Error("sensors command failed after", duration, "error:", err)
-		return nil, fmt.Errorf("sensors command failed: %v", err)
+		code, stderr, exited := exitStatus(err)
+		if exited && strings.Contains(string(stderr), "No sensors found") {
+			logger.Warn("sensors reported no chips after", duration)
+			return nil, ErrNoSensorChips
+		}
+		if !exited || len(output) == 0 {
+			logger.Error("sensors command failed after", duration, "error:", err)
+			return nil, fmt.Errorf("sensors command failed: %v", err)
+		}
+		// One misbehaving chip fails the command while the others still
+		// print their readings
+		logger.Warn("sensors command exited with status", code, "after", duration,
+			"- parsing partial output. stderr:", strings.TrimSpace(string(stderr)))
+	} else {
+		logger.Info("sensors command completed successfully in", duration)
 	}
 
-	logger.Info("sensors command completed successfully in", duration)
 	logger.Info("sensors output length:", len(output), "bytes")
+	raw.record("sensors", []string{"-A", "-u"}, output)
+
+	sensors, parseErr := tm.parseSensors(string(output))
+	if err != nil && (parseErr != nil || len(sensors) == 0) {
+		logger.Error("No sensors parsed from partial sensors output")
+		return nil, fmt.Errorf("sensors command failed: %v", err)
+	}
+	return sensors, parseErr
+}
 
-	sensors, parseErr := tm.parseSensorsOutput(string(output))
+func (tm *TemperatureMonitor) parseSensors(output string) ([]TemperatureSensor, error) {
+	sensors, parseErr := tm.parseSensorsOutput(output)
 	if parseErr != nil {
 		logger.Error("Failed to parse sensors output:", parseErr)
 		return nil, parseErr
@@ -70,11 +170,20 @@ func (tm *TemperatureMonitor) parseSensorsOutput(output string) ([]TemperatureSe
 	logger.Info("Processing", len(lines), "lines from sensors output")
 
 	var currentChip string
+	foundChips := 0
 	tempValues := make(map[string]float64)
 	tempLabels := make(map[string]string)
+	tempHigh := make(map[string]float64)
+	tempCrit := make(map[string]float64)
+	amdgpuFeatures := make(map[string]string)
+	storageChips := make(map[string]string)
+	storageFeatures := make(map[string]string)
+	tempFeatures := make(map[string]string)
+	var currentFeature string
 
 	tempRegex := regexp.MustCompile(`^(\w+)_input:\s+([\d.]+)`)
 	labelRegex := regexp.MustCompile(`^(\w+)_label:\s+(.+)`)
+	limitRegex := regexp.MustCompile(`^(temp\d+)_(max|crit):\s+([\d.]+)`)
 
 	processedLines := 0
 	foundTemps := 0
@@ -91,6 +200,14 @@ func (tm *TemperatureMonitor) parseSensorsOutput(output string) ([]TemperatureSe
 		if !strings.Contains(line, ":") && line != "" {
 			logger.Info("Found chip:", line, "at line", lineNum+1)
 			currentChip = line
+			currentFeature = ""
+			foundChips++
+			continue
+		}
+
+		// Track the feature header (e.g. "edge:") the following values belong to
+		if strings.HasSuffix(line, ":") {
+			currentFeature = strings.TrimSuffix(line, ":")
 			continue
 		}
 
@@ -101,12 +218,33 @@ func (tm *TemperatureMonitor) parseSensorsOutput(output string) ([]TemperatureSe
 				if strings.Contains(sensorName, "temp") || strings.Contains(sensorName, "Core") {
 					key := fmt.Sprintf("%s_%s", currentChip, sensorName)
 					tempValues[key] = temp
+					tempFeatures[key] = currentFeature
 					foundTemps++
+					if isAMDGPUChip(currentChip) {
+						amdgpuFeatures[key] = strings.ToLower(currentFeature)
+					}
+					if isStorageChip(currentChip) {
+						storageChips[key] = currentChip
+						storageFeatures[key] = currentFeature
+					}
 					logger.Info("Found temperature sensor:", key, "=", temp, "°C")
 				}
 			}
 		}
 
+		// Parse chip-reported limits, ignoring placeholders such as the
+		// 65261.8 some NVMe drives report for an unset limit
+		if matches := limitRegex.FindStringSubmatch(line); matches != nil {
+			if limit, err := strconv.ParseFloat(matches[3], 64); err == nil && limit > 0 && limit < maxPlausibleLimit {
+				key := fmt.Sprintf("%s_%s", currentChip, matches[1])
+				if matches[2] == "max" {
+					tempHigh[key] = limit
+				} else {
+					tempCrit[key] = limit
+				}
+			}
+		}
+
 		// Parse temperature labels
 		if matches := labelRegex.FindStringSubmatch(line); matches != nil {
 			sensorName := matches[1]
@@ -120,12 +258,20 @@ func (tm *TemperatureMonitor) parseSensorsOutput(output string) ([]TemperatureSe
 		}
 	}
 
-	logger.Info("Parsing statistics - Processed lines:", processedLines, "Temperature values:", foundTemps, "Labels:", foundLabels)
+	logger.Info("Parsing statistics - Processed lines:", processedLines, "Chips:", foundChips, "Temperature values:", foundTemps, "Labels:", foundLabels)
+
+	if foundChips == 0 {
+		logger.Warn("sensors output contained no chips")
+		return nil, ErrNoSensorChips
+	}
 
 	// Create sensor objects
 	logger.Info("Creating sensor objects...")
 	for key, temperature := range tempValues {
-		label := tempLabels[key]
+		// Prefer the chip's own label (from the driver or /etc/sensors.d) and
+		// only fall back to heuristic renaming when there is none
+		label := strings.TrimSpace(tempLabels[key])
+		name := label
 		if label == "" {
 			parts := strings.Split(key, "_")
 			if len(parts) >= 2 {
@@ -134,19 +280,39 @@ func (tm *TemperatureMonitor) parseSensorsOutput(output string) ([]TemperatureSe
 				label = key
 			}
 			logger.Info("Generated label for", key, ":", label)
+			name = tm.getReadableSensorName(label)
+		} else {
+			logger.Info("Using chip-provided label for", key, ":", label)
+		}
+
+		category := tm.categorizeSensor(chipOfKey(key), label)
+		if feature, isAMDGPU := amdgpuFeatures[key]; isAMDGPU {
+			name = amdgpuSensorName(feature)
+			category = CategoryGPU
+			logger.Info("Labeled amdgpu sensor", key, "feature", feature, "as", name)
+		}
+		if chip, isStorage := storageChips[key]; isStorage {
+			name = storageSensorName(chip, storageFeatures[key])
+			category = CategoryStorage
+			logger.Info("Labeled storage sensor", key, "as", name)
 		}
 
 		sensor := TemperatureSensor{
 			ID:          key,
-			Name:        tm.getReadableSensorName(label),
+			Name:        name,
 			Temperature: temperature,
-			Category:    tm.categorizeSensor(label),
-			Status:      tm.getTemperatureStatus(temperature),
+			Category:    category,
+			Status:      tm.getTemperatureStatus(key, temperature),
+			Feature:     tempFeatures[key],
+			High:        tempHigh[key],
+			Crit:        tempCrit[key],
 		}
 		sensors = append(sensors, sensor)
 		logger.Info("Created sensor:", sensor.Name, "Category:", sensor.Category, "Temp:", sensor.Temperature, "Status:", sensor.Status)
 	}
 
+	applyAMDGPUAlerting(sensors, amdgpuFeatures)
+
 	// Fallback parsing if no structured data found
 	if len(sensors) == 0 {
 		logger.Warn("No structured sensor data found, attempting fallback parsing...")
@@ -154,7 +320,14 @@ func (tm *TemperatureMonitor) parseSensorsOutput(output string) ([]TemperatureSe
 		logger.Info("Fallback parsing found", len(sensors), "sensors")
 	}
 
-	// Sort sensors
+	tm.sortSensors(sensors)
+
+	logger.Info("Temperature sensor parsing complete. Total sensors:", len(sensors))
+	return sensors, nil
+}
+
+// sortSensors orders sensors by category, hottest first within a category
+func (tm *TemperatureMonitor) sortSensors(sensors []TemperatureSensor) {
 	logger.Info("Sorting sensors by category and temperature...")
 	sort.Slice(sensors, func(i, j int) bool {
 		if sensors[i].Category != sensors[j].Category {
@@ -162,9 +335,6 @@ func (tm *TemperatureMonitor) parseSensorsOutput(output string) ([]TemperatureSe
 		}
 		return sensors[i].Temperature > sensors[j].Temperature
 	})
-
-	logger.Info("Temperature sensor parsing complete. Total sensors:", len(sensors))
-	return sensors, nil
 }
 
 func (tm *TemperatureMonitor) parseSimpleSensorsOutput(output string) []TemperatureSensor {
@@ -177,12 +347,13 @@ func (tm *TemperatureMonitor) parseSimpleSensorsOutput(output string) []Temperat
 	for lineNum, line := range lines {
 		if matches := tempRegex.FindStringSubmatch(line); matches != nil {
 			if temp, err := strconv.ParseFloat(matches[2], 64); err == nil {
+				id := strings.ToLower(strings.ReplaceAll(matches[1], " ", "_"))
 				sensor := TemperatureSensor{
-					ID:          strings.ToLower(strings.ReplaceAll(matches[1], " ", "_")),
+					ID:          id,
 					Name:        matches[1],
 					Temperature: temp,
-					Category:    tm.categorizeSensor(matches[1]),
-					Status:      tm.getTemperatureStatus(temp),
+					Category:    tm.categorizeSensor("", matches[1]),
+					Status:      tm.getTemperatureStatus(id, temp),
 				}
 				sensors = append(sensors, sensor)
 				foundSensors++
@@ -194,16 +365,12 @@ func (tm *TemperatureMonitor) parseSimpleSensorsOutput(output string) []Temperat
 	return sensors
 }
 
-func (tm *TemperatureMonitor) getTemperatureStatus(temp float64) TempStatus {
-	if temp >= tm.criticalThreshold {
-		logger.Info("Temperature", temp, "is CRITICAL (>= ", tm.criticalThreshold, ")")
-		return TempCritical
-	}
-	if temp >= tm.warningThreshold {
-		logger.Info("Temperature", temp, "is WARNING (>= ", tm.warningThreshold, ")")
-		return TempWarning
+func (tm *TemperatureMonitor) getTemperatureStatus(id string, temp float64) TempStatus {
+	status := StatusForSensor(id, temp)
+	if status != TempNormal {
+		logger.Info("Temperature", temp, "of", id, "is", strings.ToUpper(status.String()), "(>=", SensorThreshold(id, status), ")")
 	}
-	return TempNormal
+	return status
 }
 
 func (tm *TemperatureMonitor) getReadableSensorName(label string) string {
@@ -232,12 +399,64 @@ func (tm *TemperatureMonitor) getReadableSensorName(label string) string {
 	return result
 }
 
-func (tm *TemperatureMonitor) categorizeSensor(label string) string {
-	logger.Info("Categorizing sensor:", label)
+// chipCategories maps sensors chip name prefixes to the hardware they sit on
+var chipCategories = []struct {
+	prefix   string
+	category string
+}{
+	{"coretemp", CategoryCPU},
+	{"k10temp", CategoryCPU},
+	{"zenpower", CategoryCPU},
+	{"cpu_thermal", CategoryCPU},
+	{"amdgpu", CategoryGPU},
+	{"radeon", CategoryGPU},
+	{"nouveau", CategoryGPU},
+	{"nvme", CategoryStorage},
+	{"drivetemp", CategoryStorage},
+	{"iwlwifi", CategoryWiFi},
+	{"ath1", CategoryWiFi},
+	{"mt79", CategoryWiFi},
+	{"pch_", CategoryChipset},
+	{"jc42", CategoryMemory},
+	{"spd5118", CategoryMemory},
+	{"it86", CategoryMotherboard}, // it87 driver, e.g. it8686
+	{"it87", CategoryMotherboard},
+	{"nct", CategoryMotherboard},
+	{"w83", CategoryMotherboard},
+	{"f718", CategoryMotherboard},
+	{"asus", CategoryMotherboard},
+	{"gigabyte_wmi", CategoryMotherboard},
+	{"acpitz", CategorySystem},
+	{"thinkpad", CategorySystem},
+	{"dell_smm", CategorySystem},
+}
+
+// categorizeSensor assigns a hardware category from the sensor's chip name,
+// falling back to keywords in its label for generic chips
+func (tm *TemperatureMonitor) categorizeSensor(chip, label string) string {
+	logger.Info("Categorizing sensor:", label, "on chip:", chip)
+	lowerChip := strings.ToLower(chip)
 	lower := strings.ToLower(label)
 
+	for _, entry := range chipCategories {
+		if strings.HasPrefix(lowerChip, entry.prefix) {
+			// Super I/O chips also report the CPU socket and chipset
+			if entry.category == CategoryMotherboard {
+				if category := superIOCategory(lower); category != "" {
+					logger.Info("Categorized as:", category)
+					return category
+				}
+			}
+			logger.Info("Categorized as:", entry.category)
+			return entry.category
+		}
+	}
+
 	if strings.Contains(lower, "core") || strings.Contains(lower, "package") ||
-		strings.Contains(lower, "cpu") || strings.Contains(lower, "peci") {
+		strings.Contains(lower, "cpu") || strings.Contains(lower, "peci") ||
+		strings.Contains(lower, "tctl") || strings.Contains(lower, "tdie") ||
+		strings.Contains(lower, "tccd") || strings.Contains(lower, "k10temp") ||
+		strings.Contains(lower, "coretemp") {
 		logger.Info("Categorized as: CPU")
 		return CategoryCPU
 	}
@@ -248,8 +467,58 @@ func (tm *TemperatureMonitor) categorizeSensor(label string) string {
 		return CategoryGPU
 	}
 
-	// ... continue with other categories
+	if strings.Contains(lower, "nvme") || strings.Contains(lower, "drivetemp") {
+		logger.Info("Categorized as: Storage")
+		return CategoryStorage
+	}
+
+	if strings.Contains(lower, "pch") || strings.Contains(lower, "chipset") {
+		logger.Info("Categorized as: Chipset")
+		return CategoryChipset
+	}
+
+	if strings.Contains(lower, "wifi") || strings.Contains(lower, "wlan") || strings.Contains(lower, "iwlwifi") {
+		logger.Info("Categorized as: WiFi")
+		return CategoryWiFi
+	}
+
+	if strings.Contains(lower, "dimm") || strings.Contains(lower, "ddr") || strings.Contains(lower, "jc42") {
+		logger.Info("Categorized as: Memory")
+		return CategoryMemory
+	}
+
+	if strings.Contains(lower, "systin") || strings.Contains(lower, "auxtin") ||
+		strings.Contains(lower, "motherboard") || strings.Contains(lower, "vrm") {
+		logger.Info("Categorized as: Motherboard")
+		return CategoryMotherboard
+	}
+
+	if strings.Contains(lower, "acpitz") || strings.Contains(lower, "ambient") {
+		logger.Info("Categorized as: System")
+		return CategorySystem
+	}
 
 	logger.Info("Categorized as: Other")
 	return CategoryOther
 }
+
+// superIOCategory picks out the CPU and chipset readings that motherboard
+// Super I/O chips (it87, nct67xx) report, returning "" for board sensors
+func superIOCategory(lowerLabel string) string {
+	switch {
+	case strings.Contains(lowerLabel, "cputin") || strings.Contains(lowerLabel, "peci") ||
+		strings.Contains(lowerLabel, "cpu"):
+		return CategoryCPU
+	case strings.Contains(lowerLabel, "pch") || strings.Contains(lowerLabel, "chipset"):
+		return CategoryChipset
+	}
+	return ""
+}
+
+// chipOfKey returns the chip part of a "<chip>_<sensor>" key
+func chipOfKey(key string) string {
+	if idx := strings.LastIndex(key, "_"); idx >= 0 {
+		return key[:idx]
+	}
+	return key
+}
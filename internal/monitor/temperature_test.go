@@ -0,0 +1,64 @@
+package monitor
+
+import (
+	"system-monitor-bot/pkg/logger"
+	"testing"
+)
+
+func TestCategorizeSensor(t *testing.T) {
+	initLogger.Do(logger.Init)
+	tm := &TemperatureMonitor{}
+
+	tests := []struct {
+		chip, label string
+		want        string
+	}{
+		{"coretemp-isa-0000", "Package id 0", CategoryCPU},
+		{"k10temp-pci-00c3", "Tctl", CategoryCPU},
+		{"zenpower-pci-00c3", "Tdie", CategoryCPU},
+		{"cpu_thermal-virtual-0", "temp1", CategoryCPU},
+		{"amdgpu-pci-0300", "edge", CategoryGPU},
+		{"radeon-pci-0100", "temp1", CategoryGPU},
+		{"nouveau-pci-0100", "temp1", CategoryGPU},
+		{"nvme-pci-0100", "Composite", CategoryStorage},
+		{"drivetemp-scsi-0-0", "temp1", CategoryStorage},
+		{"iwlwifi_1-virtual-0", "temp1", CategoryWiFi},
+		{"ath10k_hwmon-pci-0200", "temp1", CategoryWiFi},
+		{"mt7921_phy0-pci-0400", "temp1", CategoryWiFi},
+		{"pch_cannonlake-virtual-0", "temp1", CategoryChipset},
+		{"jc42-i2c-0-18", "temp1", CategoryMemory},
+		{"spd5118-i2c-0-50", "temp1", CategoryMemory},
+		{"it8686-isa-0a40", "temp1", CategoryMotherboard},
+		{"it8721-isa-0228", "temp1", CategoryMotherboard},
+		{"nct6798-isa-0290", "SYSTIN", CategoryMotherboard},
+		{"w83627dhg-isa-0290", "temp1", CategoryMotherboard},
+		{"f71882fg-isa-0a00", "temp1", CategoryMotherboard},
+		{"asus_wmi_sensors-virtual-0", "Motherboard", CategoryMotherboard},
+		{"gigabyte_wmi-virtual-0", "temp1", CategoryMotherboard},
+		{"acpitz-acpi-0", "temp1", CategorySystem},
+		{"thinkpad-isa-0000", "CPU", CategorySystem},
+		{"dell_smm-isa-0000", "Ambient", CategorySystem},
+
+		// Super I/O chips also report the CPU socket and chipset
+		{"nct6798-isa-0290", "CPUTIN", CategoryCPU},
+		{"nct6798-isa-0290", "PECI Agent 0", CategoryCPU},
+		{"it8686-isa-0a40", "PCH", CategoryChipset},
+
+		// Generic chips fall back to the label
+		{"", "Core 0", CategoryCPU},
+		{"", "GPU Temp", CategoryGPU},
+		{"", "NVMe SSD", CategoryStorage},
+		{"", "Chipset", CategoryChipset},
+		{"", "WLAN", CategoryWiFi},
+		{"", "DIMM A1", CategoryMemory},
+		{"", "VRM MOS", CategoryMotherboard},
+		{"", "Ambient", CategorySystem},
+		{"", "temp1", CategoryOther},
+		{"unknown_chip-isa-0000", "temp3", CategoryOther},
+	}
+	for _, tt := range tests {
+		if got := tm.categorizeSensor(tt.chip, tt.label); got != tt.want {
+			t.Errorf("categorizeSensor(%q, %q) = %q, want %q", tt.chip, tt.label, got, tt.want)
+		}
+	}
+}
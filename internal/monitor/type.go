@@ -2,31 +2,28 @@
 package monitor
 
 import (
+	"strings"
 	"system-monitor-bot/pkg/logger"
 	"time"
 )
 
-// TempStatus represents temperature status
+// TempStatus is a temperature severity, an index into the severity level
+// table; higher is more severe
 type TempStatus int
 
-const (
-	TempNormal TempStatus = iota
-	TempWarning
-	TempCritical
+// TempNormal is always the lowest level. TempWarning and TempCritical are
+// the positions of the Warning and Critical levels in the table, which move
+// when levels are configured below warning.
+const TempNormal TempStatus = 0
+
+var (
+	TempWarning  TempStatus = 1
+	TempCritical TempStatus = 2
 )
 
 // String method for TempStatus to improve logging
 func (ts TempStatus) String() string {
-	switch ts {
-	case TempNormal:
-		return "Normal"
-	case TempWarning:
-		return "Warning"
-	case TempCritical:
-		return "Critical"
-	default:
-		return "Unknown"
-	}
+	return ts.Level().Name
 }
 
 // Hardware categories for temperature sensors
@@ -37,10 +34,18 @@ const (
 	CategoryChipset     = "Chipset"
 	CategoryWiFi        = "WiFi"
 	CategoryStorage     = "Storage"
+	CategoryMemory      = "Memory"
 	CategorySystem      = "System"
 	CategoryOther       = "Other"
 )
 
+// Categories lists every sensor category in display order
+var Categories = []string{
+	CategoryCPU, CategoryGPU, CategoryMotherboard,
+	CategoryChipset, CategoryWiFi, CategoryStorage,
+	CategoryMemory, CategorySystem, CategoryOther,
+}
+
 // TemperatureSensor represents a temperature reading
 type TemperatureSensor struct {
 	ID          string
@@ -48,6 +53,22 @@ type TemperatureSensor struct {
 	Temperature float64
 	Category    string
 	Status      TempStatus
+
+	// ExcludeFromAlerts marks informational sensors that are shown but do
+	// not drive alerting, e.g. amdgpu edge/memory when junction is present
+	ExcludeFromAlerts bool
+
+	// Feature is the chip's feature name for the reading, e.g. "Core 0"
+	Feature string
+
+	// High and Crit are the chip-reported limits in °C; zero when unreported
+	High float64
+	Crit float64
+
+	// Delta is the change in °C since the previous monitoring cycle; only
+	// meaningful when HasDelta is set
+	Delta    float64
+	HasDelta bool
 }
 
 // LogDetails logs detailed information about the temperature sensor
@@ -62,12 +83,58 @@ func (ts *TemperatureSensor) LogDetails() {
 
 // NetworkPort represents a network port
 type NetworkPort struct {
-	Protocol    string
-	Address     string
-	Port        string
-	State       string
+	Protocol string
+	Address  string
+	Port     string
+	State    string
+
+	// ProcessName is the display name of the first process holding the
+	// socket; Processes lists every one, e.g. forked workers sharing it
 	ProcessName string
-	PID         string
+	Processes   []ProcessRef
+
+	// Established is the number of established TCP connections to a
+	// listening port; only filled when connection counts are requested
+	Established int
+}
+
+// ProcessRef is a process holding a socket
+type ProcessRef struct {
+	Name string
+	PID  string // empty when ss does not report it
+}
+
+// PIDs returns the known PIDs of the processes holding the port
+func (np NetworkPort) PIDs() []string {
+	var pids []string
+	for _, process := range np.Processes {
+		if process.PID != "" {
+			pids = append(pids, process.PID)
+		}
+	}
+	return pids
+}
+
+// WatchedPort is a port expected to be listening; an empty Protocol
+// accepts either TCP or UDP
+type WatchedPort struct {
+	Port     string
+	Protocol string // "tcp", "udp" or empty
+}
+
+// Matches reports whether port is a listening socket on the watched port
+func (wp WatchedPort) Matches(port NetworkPort) bool {
+	if port.Port != wp.Port {
+		return false
+	}
+	return wp.Protocol == "" || strings.EqualFold(port.Protocol, wp.Protocol)
+}
+
+func (wp WatchedPort) String() string {
+	if wp.Protocol == "" {
+		return wp.Port
+	}
+	return wp.Port + "/" + wp.Protocol
 }
 
 // LogDetails logs detailed information about the network port
@@ -78,7 +145,14 @@ func (np *NetworkPort) LogDetails() {
 	logger.Info("- Port:", np.Port)
 	logger.Info("- State:", np.State)
 	logger.Info("- ProcessName:", np.ProcessName)
-	logger.Info("- PID:", np.PID)
+	logger.Info("- Processes:", len(np.Processes), "PIDs:", strings.Join(np.PIDs(), ","))
+	logger.Info("- Established:", np.Established)
+}
+
+// RemoteConnections represents the established connections to a remote IP
+type RemoteConnections struct {
+	Address string
+	Count   int
 }
 
 // ProcessMemory represents a process's memory usage
@@ -88,6 +162,7 @@ type ProcessMemory struct {
 	Command       string
 	MemoryPercent float64
 	CPUPercent    float64
+	ResidentBytes uint64 // VmRSS, else top's RES; 0 when neither is available
 }
 
 // LogDetails logs detailed information about the process memory usage
@@ -98,6 +173,140 @@ func (pm *ProcessMemory) LogDetails() {
 	logger.Info("- Command:", pm.Command)
 	logger.Info("- Memory:", pm.MemoryPercent, "%")
 	logger.Info("- CPU:", pm.CPUPercent, "%")
+	logger.Info("- Resident:", pm.ResidentBytes, "bytes")
+}
+
+// FileDescriptorStats represents system-wide and per-process fd usage
+type FileDescriptorStats struct {
+	Allocated uint64
+	Maximum   uint64
+	Processes []ProcessFileDescriptors
+}
+
+// ProcessFileDescriptors represents the open descriptor count of a process
+type ProcessFileDescriptors struct {
+	PID   string
+	Count int
+}
+
+// UsagePercent returns allocated descriptors as a percentage of the maximum
+func (fs *FileDescriptorStats) UsagePercent() float64 {
+	if fs.Maximum == 0 {
+		return 0
+	}
+	return float64(fs.Allocated) / float64(fs.Maximum) * 100
+}
+
+// ProcessCounts represents the number of processes and threads on the system
+type ProcessCounts struct {
+	Processes int
+	Threads   int
+	Running   int
+}
+
+// MemoryUsage is system-wide memory from /proc/meminfo
+type MemoryUsage struct {
+	TotalBytes     uint64
+	AvailableBytes uint64
+}
+
+// UsedBytes returns the memory not available to new processes
+func (mu *MemoryUsage) UsedBytes() uint64 {
+	if mu.AvailableBytes >= mu.TotalBytes {
+		return 0
+	}
+	return mu.TotalBytes - mu.AvailableBytes
+}
+
+// UsedPercent returns the share of memory not available to new processes
+func (mu *MemoryUsage) UsedPercent() float64 {
+	if mu.TotalBytes == 0 || mu.AvailableBytes >= mu.TotalBytes {
+		return 0
+	}
+	return float64(mu.TotalBytes-mu.AvailableBytes) / float64(mu.TotalBytes) * 100
+}
+
+// HostSnapshot is the summary of one host that bot instances exchange for
+// fleet comparisons
+type HostSnapshot struct {
+	Name              string    `json:"name"`
+	Time              time.Time `json:"time"`
+	MaxTemperature    float64   `json:"max_temperature"`
+	MaxSensor         string    `json:"max_sensor"`
+	Status            string    `json:"status"`
+	MemoryUsedPercent float64   `json:"memory_used_percent"`
+	MemoryTotalBytes  uint64    `json:"memory_total_bytes"`
+	Processes         int       `json:"processes"`
+}
+
+// PowerSupply represents a battery, UPS or mains supply
+type PowerSupply struct {
+	Name        string
+	Type        string // Battery, UPS, Mains, USB
+	Status      string // Charging, Discharging, Full, Not charging
+	Capacity    float64
+	HasCapacity bool
+	PowerWatts  float64 // -1 when unavailable
+	Online      bool
+}
+
+// IsBattery reports whether the supply stores charge
+func (ps *PowerSupply) IsBattery() bool {
+	return ps.Type == "Battery" || ps.Type == "UPS"
+}
+
+// OnBattery reports whether the supply is currently draining
+func (ps *PowerSupply) OnBattery() bool {
+	return ps.IsBattery() && ps.Status == "Discharging"
+}
+
+// CPUUsage is the CPU load over a short sampling window
+type CPUUsage struct {
+	Overall float64
+	Cores   []CoreUsage
+}
+
+// CoreUsage is the load of one logical CPU
+type CoreUsage struct {
+	CPU       int // logical CPU index (cpuN)
+	Percent   float64
+	CoreID    int // physical core within its package, -1 when unknown
+	PackageID int // physical package, -1 when unknown
+}
+
+// PressureAverages are the share of time, in percent, that tasks stalled on
+// a resource over the last 10, 60 and 300 seconds
+type PressureAverages struct {
+	Avg10  float64
+	Avg60  float64
+	Avg300 float64
+}
+
+// ResourcePressure is the pressure stall information for one resource.
+// Some counts time at least one task stalled; Full counts time all
+// non-idle tasks stalled at once.
+type ResourcePressure struct {
+	Resource string // cpu, memory or io
+	Some     PressureAverages
+	Full     PressureAverages
+}
+
+// DiskUsage is the space usage of one mounted filesystem
+type DiskUsage struct {
+	Mountpoint string
+	Device     string
+	FSType     string
+	Total      uint64 // bytes
+	Used       uint64 // bytes
+	Available  uint64 // bytes available to unprivileged users
+}
+
+// UsagePercent returns used space as a percentage of the total
+func (du *DiskUsage) UsagePercent() float64 {
+	if du.Total == 0 {
+		return 0
+	}
+	return float64(du.Used) / float64(du.Total) * 100
 }
 
 // MonitorData contains system monitoring data
@@ -125,12 +334,12 @@ func (md *MonitorData) LogSummary() {
 		normalCount := 0
 
 		for _, sensor := range md.Sensors {
-			switch sensor.Status {
-			case TempCritical:
+			switch {
+			case sensor.Status >= TempCritical:
 				criticalCount++
-			case TempWarning:
+			case sensor.Status >= TempWarning:
 				warningCount++
-			case TempNormal:
+			default:
 				normalCount++
 			}
 		}
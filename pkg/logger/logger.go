@@ -1,35 +1,120 @@
 package logger
 
 import (
+	"fmt"
+	"io"
 	"log"
 	"os"
+	"strings"
+	"sync"
 )
 
+// RecentCapacity is the number of log lines kept in memory for Recent
+const RecentCapacity = 500
+
+const redactedPlaceholder = "[REDACTED]"
+
 var (
 	infoLogger  *log.Logger
 	errorLogger *log.Logger
 	warnLogger  *log.Logger
+	recent      = newRingBuffer(RecentCapacity)
+	logFile     *rotatingFile
+
+	secretsMu sync.RWMutex
+	secrets   []string
 )
 
 func Init() {
-	infoLogger = log.New(os.Stdout, "INFO: ", log.Ldate|log.Ltime|log.Lshortfile)
-	errorLogger = log.New(os.Stderr, "ERROR: ", log.Ldate|log.Ltime|log.Lshortfile)
-	warnLogger = log.New(os.Stdout, "WARN: ", log.Ldate|log.Ltime|log.Lshortfile)
+	setOutputs(os.Stdout, os.Stderr)
 	Info("Logger initialized successfully")
 }
 
+// setOutputs points every level at its console stream plus the recent lines
+// buffer and, when configured, the log file; a nil stream is skipped
+func setOutputs(stdout, stderr io.Writer) {
+	writers := func(console io.Writer) io.Writer {
+		outputs := []io.Writer{recent}
+		if console != nil {
+			outputs = append(outputs, console)
+		}
+		if logFile != nil {
+			outputs = append(outputs, logFile)
+		}
+		return io.MultiWriter(outputs...)
+	}
+
+	infoLogger = log.New(writers(stdout), "INFO: ", log.Ldate|log.Ltime|log.Lshortfile)
+	errorLogger = log.New(writers(stderr), "ERROR: ", log.Ldate|log.Ltime|log.Lshortfile)
+	warnLogger = log.New(writers(stdout), "WARN: ", log.Ldate|log.Ltime|log.Lshortfile)
+}
+
+// SetFile also writes log output to path, rotating it once it reaches
+// maxSizeMB and keeping maxFiles rotated copies. With fileOnly the console
+// output is dropped. Call it once, after Init.
+func SetFile(path string, maxSizeMB, maxFiles int, fileOnly bool) error {
+	file, err := openRotatingFile(path, int64(maxSizeMB)*1024*1024, maxFiles)
+	if err != nil {
+		return err
+	}
+	logFile = file
+
+	if fileOnly {
+		setOutputs(nil, nil)
+	} else {
+		setOutputs(os.Stdout, os.Stderr)
+	}
+	Info("Logging to file:", path, "max size:", maxSizeMB, "MB, rotated files kept:", maxFiles)
+	return nil
+}
+
+// Close closes the log file, if one is configured
+func Close() {
+	if logFile != nil {
+		logFile.Close()
+	}
+}
+
 func Info(v ...interface{}) {
-	infoLogger.Println(v...)
+	infoLogger.Output(2, Redact(fmt.Sprintln(v...)))
 }
 
 func Error(v ...interface{}) {
-	errorLogger.Println(v...)
+	errorLogger.Output(2, Redact(fmt.Sprintln(v...)))
 }
 
 func Warn(v ...interface{}) {
-	warnLogger.Println(v...)
+	warnLogger.Output(2, Redact(fmt.Sprintln(v...)))
 }
 
 func Fatal(v ...interface{}) {
-	errorLogger.Fatal(v...)
+	errorLogger.Output(2, Redact(fmt.Sprint(v...)))
+	os.Exit(1)
+}
+
+// Recent returns up to n of the most recently logged lines, oldest first
+func Recent(n int) []string {
+	return recent.last(n)
+}
+
+// RegisterSecret adds a value that must never appear in log output
+func RegisterSecret(secret string) {
+	if secret == "" {
+		return
+	}
+
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	secrets = append(secrets, secret)
+}
+
+// Redact replaces every registered secret in message with a placeholder
+func Redact(message string) string {
+	secretsMu.RLock()
+	defer secretsMu.RUnlock()
+
+	for _, secret := range secrets {
+		message = strings.ReplaceAll(message, secret, redactedPlaceholder)
+	}
+	return message
 }
@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"strings"
+	"sync"
+)
+
+// ringBuffer keeps the most recent log lines in memory
+type ringBuffer struct {
+	mu    sync.Mutex
+	lines []string
+	next  int
+	full  bool
+}
+
+func newRingBuffer(capacity int) *ringBuffer {
+	return &ringBuffer{lines: make([]string, capacity)}
+}
+
+// Write implements io.Writer; each log call writes a single line
+func (rb *ringBuffer) Write(p []byte) (int, error) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		rb.lines[rb.next] = line
+		rb.next = (rb.next + 1) % len(rb.lines)
+		if rb.next == 0 {
+			rb.full = true
+		}
+	}
+	return len(p), nil
+}
+
+// last returns up to n of the most recent lines, oldest first
+func (rb *ringBuffer) last(n int) []string {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	size := rb.next
+	if rb.full {
+		size = len(rb.lines)
+	}
+	if n > size {
+		n = size
+	}
+
+	result := make([]string, 0, n)
+	start := rb.next - n
+	for i := 0; i < n; i++ {
+		idx := (start + i + len(rb.lines)) % len(rb.lines)
+		result = append(result, rb.lines[idx])
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// rotatingFile is an io.Writer that appends to a log file and, once the file
+// would grow past maxSize bytes, renames it to path.1 (shifting older copies
+// up to path.<maxBackups>) and starts a fresh one
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func openRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	rf := &rotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+// open opens the log file for appending, continuing from its current size
+func (rf *rotatingFile) open() error {
+	file, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	rf.file = file
+	rf.size = info.Size()
+	return nil
+}
+
+// Write implements io.Writer, rotating first when p would overflow the file
+func (rf *rotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.size > 0 && rf.size+int64(len(p)) > rf.maxSize {
+		if err := rf.rotate(); err != nil {
+			// Keep logging to the oversized file rather than losing lines
+			fmt.Fprintln(os.Stderr, "ERROR: log rotation failed:", err)
+		}
+	}
+
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+// rotate shifts path.N-1 to path.N down to path -> path.1, dropping the
+// oldest copy, and reopens an empty log file
+func (rf *rotatingFile) rotate() error {
+	if err := rf.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+
+	os.Remove(rf.backupPath(rf.maxBackups))
+	for n := rf.maxBackups - 1; n >= 1; n-- {
+		os.Rename(rf.backupPath(n), rf.backupPath(n+1))
+	}
+	renameErr := os.Rename(rf.path, rf.backupPath(1))
+
+	// Reopen even when the rename failed so writes keep a valid file
+	if err := rf.open(); err != nil {
+		return err
+	}
+	if renameErr != nil {
+		return fmt.Errorf("failed to rotate log file: %w", renameErr)
+	}
+	return nil
+}
+
+func (rf *rotatingFile) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", rf.path, n)
+}
+
+// Close closes the current log file
+func (rf *rotatingFile) Close() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	return rf.file.Close()
+}